@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// cardsFlag is a flag holding runs of cards.
+type cardsFlag []hand.Card
+
+func (f *cardsFlag) String() string {
+	return cards.Format(*f)
+}
+
+func (f *cardsFlag) Set(s string) error {
+	cs, err := cards.Parse(s)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, cs...)
+	return nil
+}
+
+// countFlag is an integer flag that can be written like 1e6.
+type countFlag int
+
+func (f *countFlag) String() string {
+	return strconv.Itoa(int(*f))
+}
+
+func (f *countFlag) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || v != float64(int(v)) {
+		return errors.New("invalid count " + s)
+	}
+	*f = countFlag(v)
+	return nil
+}
+
+// rulesFlag selects the hand options hands are evaluated with.
+type rulesFlag string
+
+var rules = map[string][]func(*hand.Config){
+	"high":        nil,
+	"low":         {hand.Low},
+	"ace-to-five": {hand.AceToFiveLow},
+}
+
+func (f *rulesFlag) String() string {
+	return string(*f)
+}
+
+func (f *rulesFlag) Set(s string) error {
+	if _, ok := rules[s]; !ok {
+		return errors.New("rules must be high, low, or ace-to-five")
+	}
+	*f = rulesFlag(s)
+	return nil
+}
+
+func (f rulesFlag) options() []func(*hand.Config) {
+	return rules[string(f)]
+}
+
+func (f rulesFlag) sorting() hand.Sorting {
+	if f == "high" {
+		return hand.SortingHigh
+	}
+	return hand.SortingLow
+}
@@ -0,0 +1,50 @@
+/*
+Command joker evaluates hands, calculates equity, and runs simulations from
+the command line.
+
+Usage:
+
+	joker eval [-rules r] [-json] cards...
+	joker compare [-board cards] [-rules r] [-omaha] [-json] hand hand...
+	joker equity [-board cards] [-dead cards] [-iters n] [-seed n] [-rules r] [-omaha] [-json] player player...
+	joker range expand [-json] range
+	joker shuffle [-seed n] [-json]
+	joker match [-deals n] [-duplicate] [-aivat n] [-stack n] [-seed n] [-json] bot bot...
+	joker play [-seats 2|6] [-bot b] [-chips n] [-sb n] [-bb n] [-hands n] [-seed n] [-color]
+	joker sim [-players n] [-hands n] [-seed n] [-rules r] [-json] [hole]
+
+Cards are written as "As", "A♠", or runs of them such as "AhKh" or
+"2c7d9h".  An equity player is either hole cards or a range such as
+"QQ+,AKs".  The rules are high, low, or ace-to-five.  Counts such as -iters
+may be written as 1e6.  Flags may come before or after the other arguments.
+
+	$ joker eval As Ks Qs Js Ts
+	royal flush [A♠ K♠ Q♠ J♠ T♠]
+	$ joker equity AhKh QsQd --board 2c7d9h
+	AhKh        28.28%  win  28.28%  tie   0.00%
+	QsQd        71.72%  win  71.72%  tie   0.00%
+	exact over 990 runouts
+
+The sim command deals random hands to the table, with the given hole cards
+for the first player, and counts how often each hand ranking is made by
+the river and how often the first player wins.
+
+The play command runs a no-limit hold'em game in the terminal against
+bots, with you in the first seat.  Enter f to fold, c to check or call,
+b or r with an amount to bet or raise to, a to go all-in, and q to quit.
+The bots are the reference agents of pkg/agent: tag is tight and
+aggressive, equity plays its equity against random hands, calling never
+folds or raises, and random does anything.  Games with the
+same -seed deal the same cards and bot decisions.
+
+The match command plays bots against each other with stacks reset every
+hand and reports each bot's winnings in milli big blinds per hand with a
+95% confidence interval.  With -duplicate every deck is dealt once for each
+rotation of the bots around the table, and with -aivat the luck of the
+board cards is removed from the results, which narrows the intervals.
+
+With -json the result is written as a single line of JSON instead.  The
+exit status is 0 on success, 1 if the input is invalid or the calculation
+fails, and 2 for usage errors such as an unknown command or flag.
+*/
+package main
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+type equityResult struct {
+	Players []string `json:"players"`
+	*equity.Result
+}
+
+func equityCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("equity")
+	asJSON := fs.Bool("json", false, "write JSON")
+	omaha := fs.Bool("omaha", false, "players hold four Omaha hole cards that must use two")
+	seed := fs.Int64("seed", 0, "random seed for sampling (default from the time)")
+	iters := countFlag(10000)
+	fs.Var(&iters, "iters", "number of samples when the result isn't exact")
+	var board, dead cardsFlag
+	fs.Var(&board, "board", "board cards")
+	fs.Var(&dead, "dead", "cards removed from the deck")
+	r := rulesFlag("high")
+	fs.Var(&r, "rules", "high, low, or ace-to-five")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		return usageError{"equity needs at least two players"}
+	}
+	if iters == 0 {
+		return usageError{"iters must be positive"}
+	}
+	holeCards := 2
+	if *omaha {
+		holeCards = 4
+	}
+	ranges := [][][]hand.Card{}
+	for _, arg := range args {
+		if cs, err := cards.Parse(arg); err == nil && len(cs) == holeCards {
+			ranges = append(ranges, [][]hand.Card{cs})
+			continue
+		}
+		rng, err := preflop.ParseRange(arg)
+		if err != nil || *omaha {
+			return errors.New("invalid player " + arg)
+		}
+		ranges = append(ranges, rng.Combos())
+	}
+	options := []func(*equity.Config){
+		equity.Iterations(int(iters)),
+		equity.Dead(dead...),
+		equity.Rules(r.options()...),
+		equity.WithRand(newRand(fs, *seed)),
+	}
+	if *omaha {
+		options = append(options, equity.Omaha)
+	}
+	res, err := equity.Ranges(ranges, board, options...)
+	if err != nil {
+		return err
+	}
+	return output(out, *asJSON, equityResult{args, res}, func() {
+		for i, arg := range args {
+			fmt.Fprintf(out, "%-10s %6.2f%%  win %6.2f%%  tie %6.2f%%\n", arg, 100*res.Equity[i], 100*res.Win[i], 100*res.Tie[i])
+		}
+		if res.Exact {
+			fmt.Fprintf(out, "exact over %d runouts\n", res.Samples)
+		} else {
+			fmt.Fprintf(out, "%d samples\n", res.Samples)
+		}
+	})
+}
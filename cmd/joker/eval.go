@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func evalCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("eval")
+	asJSON := fs.Bool("json", false, "write JSON")
+	r := rulesFlag("high")
+	fs.Var(&r, "rules", "high, low, or ace-to-five")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return usageError{"no cards given"}
+	}
+	cs, err := cards.Parse(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	h := hand.New(cs, r.options()...)
+	return output(out, *asJSON, h, func() {
+		fmt.Fprintln(out, h)
+	})
+}
+
+type comparison struct {
+	Hands   []*hand.Hand `json:"hands"`
+	Winners []int        `json:"winners"`
+}
+
+func compareCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("compare")
+	asJSON := fs.Bool("json", false, "write JSON")
+	omaha := fs.Bool("omaha", false, "hands are four Omaha hole cards that must use two")
+	var board cardsFlag
+	fs.Var(&board, "board", "board cards shared by the hands")
+	r := rulesFlag("high")
+	fs.Var(&r, "rules", "high, low, or ace-to-five")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		return usageError{"compare needs at least two hands"}
+	}
+	if *omaha && len(board) < 3 {
+		return errors.New("omaha needs a board of at least three cards")
+	}
+	c := &comparison{}
+	all := append([]hand.Card{}, board...)
+	for _, arg := range args {
+		cs, err := cards.Parse(arg)
+		if err != nil {
+			return err
+		}
+		all = append(all, cs...)
+		var h *hand.Hand
+		switch {
+		case *omaha && len(cs) != 4:
+			return errors.New("omaha hand " + arg + " doesn't have four cards")
+		case *omaha:
+			h = hand.NewOmaha(cs, board, r.options()...)
+		case len(cs)+len(board) < 5:
+			return errors.New("hand " + arg + " doesn't have five cards with the board")
+		default:
+			h = hand.New(append(cs, board...), r.options()...)
+		}
+		c.Hands = append(c.Hands, h)
+	}
+	if err := cards.Check(all, 0, len(all), "the hands and board"); err != nil {
+		return err
+	}
+	c.Winners = cards.Winners(c.Hands, r.sorting())
+	return output(out, *asJSON, c, func() {
+		for i, h := range c.Hands {
+			fmt.Fprintf(out, "%-10s %v\n", args[i], h)
+		}
+		winners := []string{}
+		for _, i := range c.Winners {
+			winners = append(winners, args[i])
+		}
+		if len(winners) == 1 {
+			fmt.Fprintln(out, "winner:", winners[0])
+		} else {
+			fmt.Fprintln(out, "split:", strings.Join(winners, " "))
+		}
+	})
+}
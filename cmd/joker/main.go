@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// A command runs a subcommand with its arguments, reading any input from
+// in and writing its result to out.
+type command func(args []string, in io.Reader, out io.Writer) error
+
+var commands = map[string]command{
+	"eval":    evalCommand,
+	"compare": compareCommand,
+	"equity":  equityCommand,
+	"match":   matchCommand,
+	"play":    playCommand,
+	"range":   rangeCommand,
+	"shuffle": shuffleCommand,
+	"sim":     simCommand,
+}
+
+// usageError is an error in how the command was invoked rather than in its
+// input.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// run runs the command line and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "joker: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	err := cmd(args[1:], stdin, stdout)
+	var uerr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &uerr):
+		fmt.Fprintf(stderr, "joker %s: %v\n", args[0], err)
+		return 2
+	}
+	fmt.Fprintf(stderr, "joker %s: %v\n", args[0], err)
+	return 1
+}
+
+func usage(w io.Writer) {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "usage: joker <command> [flags] [arguments]")
+	fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		fmt.Fprintln(w, "\t"+name)
+	}
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors
+// instead of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("joker "+name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parse parses the flags in args, which may be mixed with the other
+// arguments, and returns the other arguments.  The usage error for a bad
+// flag lists the flags of the command.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	rest := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			b := &strings.Builder{}
+			fs.SetOutput(b)
+			fs.PrintDefaults()
+			return nil, usageError{err.Error() + "\nflags:\n" + b.String()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return rest, nil
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+}
+
+// output writes v as a line of JSON if asJSON is set and calls text
+// otherwise.
+func output(out io.Writer, asJSON bool, v interface{}, text func()) error {
+	if !asJSON {
+		text()
+		return nil
+	}
+	return json.NewEncoder(out).Encode(v)
+}
+
+// newRand returns a random source seeded with seed if the seed flag was
+// set and from the time otherwise.
+func newRand(fs *flag.FlagSet, seed int64) *rand.Rand {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == "seed"
+	})
+	if !set {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
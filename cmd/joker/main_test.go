@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		status int
+		out    string
+	}{
+		{[]string{"eval", "As", "Ks", "Qs", "Js", "Ts"}, 0, "royal flush [A♠ K♠ Q♠ J♠ T♠]\n"},
+		{[]string{"eval", "A♠K♠Q♠", "Js,Ts"}, 0, "royal flush [A♠ K♠ Q♠ J♠ T♠]\n"},
+		{[]string{"eval", "-rules", "ace-to-five", "As2d3c4h5s"}, 0, "high card five high [5♠ 4♥ 3♣ 2♦ A♠]\n"},
+		{[]string{"eval", "As", "As"}, 1, ""},
+		{[]string{"eval", "Xx"}, 1, ""},
+		{[]string{"eval"}, 2, ""},
+		{[]string{"compare", "AsAd", "KsKd", "--board", "2c7d9h3s4s"}, 0, "winner: AsAd\n"},
+		{[]string{"compare", "AsAd", "KsKd", "--board", "7c8d9hTcJd"}, 0, "split: AsAd KsKd\n"},
+		{[]string{"compare", "AsAd", "KsKd", "--board", "As7d9h"}, 1, ""},
+		{[]string{"compare", "As", "Ks"}, 1, ""},
+		{[]string{"compare", "AsAd", "KsKd", "--board", "7d9h"}, 1, ""},
+		{[]string{"compare", "--omaha", "AsKhQcJd", "7h6h2c2d", "--board", "Ts9s8s2s3d"}, 0, "winner: AsKhQcJd\n"},
+		{[]string{"equity", "AhKh", "QsQd", "--board", "2c7d9h"}, 0, "exact over 990 runouts\n"},
+		{[]string{"equity", "AhKh", "QQ+", "--iters", "1e2", "--seed", "1"}, 0, "100 samples\n"},
+		{[]string{"equity", "AhKh", "QQ+", "--iters", "1.5"}, 2, ""},
+		{[]string{"equity", "AhKh", "Qx"}, 1, ""},
+		{[]string{"equity", "AhKh"}, 2, ""},
+		{[]string{"match", "-deals", "10", "-duplicate", "tag", "tag"}, 0, "20 hands\n"},
+		{[]string{"match", "tag", "shark"}, 2, ""},
+		{[]string{"match", "tag"}, 2, ""},
+		{[]string{"range", "expand", "AKs"}, 0, "AsKs\nAhKh\nAdKd\nAcKc\n"},
+		{[]string{"range", "collapse", "AKs"}, 2, ""},
+		{[]string{"shuffle", "--seed", "1", "extra"}, 2, ""},
+		{[]string{"sim", "--hands", "10", "--players", "1"}, 2, ""},
+		{[]string{"unknown"}, 2, ""},
+		{[]string{}, 2, ""},
+	}
+	for _, test := range tests {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		status := run(test.args, nil, stdout, stderr)
+		if status != test.status {
+			t.Fatalf("run(%q) = %d; want %d (%s)", test.args, status, test.status, stderr)
+		}
+		if !strings.HasSuffix(stdout.String(), test.out) {
+			t.Fatalf("run(%q) wrote %q; want it to end with %q", test.args, stdout, test.out)
+		}
+		if status != 0 && stderr.Len() == 0 {
+			t.Fatalf("run(%q) failed without an error message", test.args)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	if status := run([]string{"equity", "-json", "AsAd", "KsKd", "-board", "2c7d9h3s4s"}, nil, stdout, &bytes.Buffer{}); status != 0 {
+		t.Fatalf("status = %d", status)
+	}
+	var res equityResult
+	if err := json.Unmarshal(stdout.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Players) != 2 || res.Equity[0] != 1 || !res.Exact || res.Samples != 1 {
+		t.Fatalf("result = %s", stdout)
+	}
+
+	a, b := &bytes.Buffer{}, &bytes.Buffer{}
+	run([]string{"shuffle", "-json", "-seed", "7"}, nil, a, &bytes.Buffer{})
+	run([]string{"shuffle", "-seed", "7", "-json"}, nil, b, &bytes.Buffer{})
+	var cards []string
+	if err := json.Unmarshal(a.Bytes(), &cards); err != nil || len(cards) != 52 || a.String() != b.String() {
+		t.Fatalf("shuffles = %s and %s", a, b)
+	}
+
+	stdout.Reset()
+	if status := run([]string{"sim", "-json", "-hands", "50", "-players", "3", "-seed", "1", "AsAd"}, nil, stdout, &bytes.Buffer{}); status != 0 {
+		t.Fatalf("status = %d", status)
+	}
+	var sim simResult
+	if err := json.Unmarshal(stdout.Bytes(), &sim); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, r := range sim.Rankings {
+		total += r.Count
+	}
+	if sim.Hands != 50 || total != 150 || sim.Win < 0.5 {
+		t.Fatalf("sim = %s", stdout)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/match"
+)
+
+type matchResult struct {
+	Bots []string `json:"bots"`
+	*match.Result
+}
+
+func matchCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("match")
+	asJSON := fs.Bool("json", false, "write JSON")
+	seed := fs.Int64("seed", 1, "seed the decks are shuffled from")
+	duplicate := fs.Bool("duplicate", false, "deal every deck once for each seat rotation")
+	samples := fs.Int("aivat", 0, "samples for the AIVAT correction, 0 to turn it off")
+	stack := fs.Int("stack", 200, "chips each bot starts every hand with, at blinds of 1 and 2")
+	deals := countFlag(1000)
+	fs.Var(&deals, "deals", "number of decks to deal")
+	names, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) < 2 || len(names) > 10 {
+		return usageError{"match needs two to ten bots"}
+	}
+	if *samples < 0 {
+		return usageError{"aivat must not be negative"}
+	}
+	// the bots are seeded too so that matches can be replayed
+	r := rand.New(rand.NewSource(*seed))
+	agents := []agent.Agent{}
+	for _, name := range names {
+		newBot, ok := bots[name]
+		if !ok {
+			return usageError{fmt.Sprintf("unknown bot %q", name)}
+		}
+		agents = append(agents, newBot(r))
+	}
+	options := []func(*match.Config){match.Deals(int(deals)), match.Seed(*seed), match.Stack(*stack)}
+	if *duplicate {
+		options = append(options, match.Duplicate)
+	}
+	if *samples > 0 {
+		options = append(options, match.AIVAT(*samples))
+	}
+	res, err := match.Play(agents, options...)
+	if err != nil {
+		return err
+	}
+	return output(out, *asJSON, matchResult{Bots: names, Result: res}, func() {
+		if *samples > 0 {
+			fmt.Fprintf(out, "%-8s %10s  %-20s  %s\n", "bot", "net", "mbb/hand", "aivat mbb/hand")
+		} else {
+			fmt.Fprintf(out, "%-8s %10s  %s\n", "bot", "net", "mbb/hand")
+		}
+		for i, p := range res.Players {
+			if p.Adjusted != nil {
+				fmt.Fprintf(out, "%-8s %10d  %-20v  %v\n", names[i], p.Net, p.Winnings, *p.Adjusted)
+			} else {
+				fmt.Fprintf(out, "%-8s %10d  %v\n", names[i], p.Net, p.Winnings)
+			}
+		}
+		fmt.Fprintf(out, "%d hands\n", res.Hands)
+	})
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// bots are the agents that can be played against.
+var bots = map[string]func(r *rand.Rand) agent.Agent{
+	"tag":     func(*rand.Rand) agent.Agent { return agent.TAG() },
+	"equity":  func(r *rand.Rand) agent.Agent { return agent.Equity(r, 200) },
+	"calling": func(*rand.Rand) agent.Agent { return agent.CallingStation() },
+	"random":  agent.Random,
+}
+
+// errQuit is returned when the player quits the game.
+var errQuit = errors.New("quit")
+
+func playCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("play")
+	seats := fs.Int("seats", 2, "number of seats, 2 for heads-up or 6 for six-max")
+	botName := fs.String("bot", "tag", "bot opponent: tag, equity, calling, or random")
+	chips := fs.Int("chips", 200, "starting chips")
+	small := fs.Int("sb", 1, "small blind")
+	big := fs.Int("bb", 2, "big blind")
+	hands := fs.Int("hands", 0, "number of hands to play, or 0 to play until someone is out")
+	color := fs.Bool("color", true, "color the cards with ANSI escapes")
+	seed := fs.Int64("seed", 0, "random seed (default from the time)")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return usageError{"play takes no arguments"}
+	}
+	if *seats != 2 && *seats != 6 {
+		return usageError{"seats must be 2 or 6"}
+	}
+	if *chips <= 0 {
+		return usageError{"chips must be positive"}
+	}
+	r := newRand(fs, *seed)
+	t, err := table.New(*seats, table.Stakes(*small, *big), table.WithDealer(hand.NewDealer(r)))
+	if err != nil {
+		return err
+	}
+	newBot, ok := bots[*botName]
+	if !ok {
+		return usageError{"unknown bot " + *botName}
+	}
+	g := &game{t: t, in: bufio.NewScanner(in), out: out, color: *color, bots: map[int]agent.Agent{}}
+	t.Sit(0, "you", *chips)
+	for i := 1; i < *seats; i++ {
+		g.bots[i] = newBot(r)
+		t.Sit(i, "bot"+strconv.Itoa(i), *chips)
+	}
+	for n := 0; *hands == 0 || n < *hands; n++ {
+		if err := g.playHand(); err == errQuit {
+			break
+		} else if err != nil {
+			return err
+		}
+		if g.t.View(0).Players[0].Chips == 0 {
+			fmt.Fprintln(out, "You're out of chips.")
+			break
+		}
+		if g.left() == 1 {
+			fmt.Fprintln(out, "You won every chip!")
+			break
+		}
+	}
+	fmt.Fprintln(out, "\nFinal stacks:")
+	for _, p := range t.View(-1).Players {
+		fmt.Fprintf(out, "  %-5s %d\n", p.Name, p.Chips)
+	}
+	return nil
+}
+
+// game is a table with a human player in seat 0 and bots in the others.
+type game struct {
+	t     *table.Table
+	bots  map[int]agent.Agent
+	in    *bufio.Scanner
+	out   io.Writer
+	color bool
+
+	// printed is the number of actions of the hand written so far and
+	// shown is the number of board cards written.
+	printed int
+	shown   int
+}
+
+// left returns the number of players with chips.
+func (g *game) left() int {
+	n := 0
+	for _, p := range g.t.View(-1).Players {
+		if p.Chips > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (g *game) playHand() error {
+	if err := g.t.Deal(); err != nil {
+		return err
+	}
+	g.printed, g.shown = 0, 0
+	v := g.t.View(0)
+	fmt.Fprintf(g.out, "\n*** Hand #%d *** blinds %d/%d, %s on the button\n", v.Hand, v.SmallBlind, v.BigBlind, v.Players[v.Button].Name)
+	if v.Players[0].InHand {
+		fmt.Fprintf(g.out, "Your cards: %s\n", g.cards(v.Players[0].Cards))
+	}
+	for g.t.Active() {
+		g.update()
+		seat := g.t.ToAct()
+		if b, ok := g.bots[seat]; ok {
+			if err := g.t.Act(seat, b.Act(g.t.View(seat))); err != nil {
+				return err
+			}
+			continue
+		}
+		for {
+			a, err := g.prompt(g.t.View(seat))
+			if err != nil {
+				return err
+			}
+			if err := g.t.Act(seat, a); err != nil {
+				fmt.Fprintln(g.out, strings.TrimPrefix(err.Error(), "table: "))
+				continue
+			}
+			break
+		}
+	}
+	g.update()
+	g.summary()
+	return nil
+}
+
+// update writes the actions and board cards that haven't been written.
+func (g *game) update() {
+	rec := g.t.Record()
+	for ; g.printed < len(rec.Actions); g.printed++ {
+		a := rec.Actions[g.printed]
+		g.board(a.Round)
+		rs, _ := rec.Seat(a.Seat)
+		verb, amount := describeAction(a.Action)
+		g.say(rs.Name, verb, amount)
+	}
+	g.board(table.River)
+}
+
+// board writes the board cards dealt up to the start of the round.
+func (g *game) board(round table.Round) {
+	rec := g.t.Record()
+	for _, street := range []struct {
+		name  string
+		round table.Round
+		cards int
+	}{{"Flop", table.Flop, 3}, {"Turn", table.Turn, 4}, {"River", table.River, 5}} {
+		if round >= street.round && g.shown < street.cards && len(rec.Board) >= street.cards {
+			fmt.Fprintf(g.out, "*** %s *** %s\n", street.name, g.cards(rec.Board[:street.cards]))
+			g.shown = street.cards
+		}
+	}
+}
+
+// say writes a sentence about a player, using the second person for the
+// human player.
+func (g *game) say(name, verb, rest string) {
+	if name == "you" {
+		name, verb = "You", strings.TrimSuffix(verb, "s")
+	}
+	fmt.Fprintln(g.out, strings.TrimSpace(name+" "+verb+" "+rest))
+}
+
+func (g *game) summary() {
+	rec := g.t.Record()
+	for _, rs := range rec.Seats {
+		if rs.Shown {
+			g.say(rs.Name, "shows", g.cards(rs.Cards)+": "+rs.Hand.Description())
+		}
+	}
+	for _, rs := range rec.Seats {
+		if rs.Won > 0 {
+			g.say(rs.Name, "wins", strconv.Itoa(rs.Won))
+		}
+	}
+	if rs, ok := rec.Seat(0); ok {
+		fmt.Fprintln(g.out, result(rs.Net, g.t.View(0).Players[0].Chips))
+	}
+}
+
+// result describes what the player won or lost in a hand.
+func result(net, chips int) string {
+	if net > 0 {
+		return fmt.Sprintf("You won %d and have %d chips.", net, chips)
+	} else if net < 0 {
+		return fmt.Sprintf("You lost %d and have %d chips.", -net, chips)
+	}
+	return fmt.Sprintf("You broke even and have %d chips.", chips)
+}
+
+// prompt shows the table to the player and reads an action.
+func (g *game) prompt(v table.View) (table.Action, error) {
+	fmt.Fprintf(g.out, "Pot %d", v.Pot)
+	if len(v.Board) > 0 {
+		fmt.Fprintf(g.out, ", board %s", g.cards(v.Board))
+	}
+	fmt.Fprintln(g.out)
+	for i, p := range v.Players {
+		if !p.InHand || p.Folded {
+			continue
+		}
+		line := fmt.Sprintf("  %-5s %5d", p.Name, p.Chips)
+		if p.Bet > 0 {
+			line += fmt.Sprintf("  bet %d", p.Bet)
+		}
+		if i == v.Button {
+			line += "  (button)"
+		}
+		fmt.Fprintln(g.out, line)
+	}
+	o := v.Options
+	choices := []string{"[f]old"}
+	if o.ToCall == 0 {
+		choices = append(choices, "[c]heck")
+	} else {
+		choices = append(choices, fmt.Sprintf("[c]all %d", o.ToCall))
+	}
+	if o.MaxRaise > 0 {
+		name := "[b]et"
+		if o.Actions[len(o.Actions)-1] == table.Raise {
+			name = "[r]aise to"
+		}
+		choices = append(choices, fmt.Sprintf("%s %d-%d", name, o.MinRaise, o.MaxRaise), "[a]ll-in")
+	}
+	choices = append(choices, "[q]uit")
+	for {
+		fmt.Fprintf(g.out, "%s > ", strings.Join(choices, ", "))
+		if !g.in.Scan() {
+			fmt.Fprintln(g.out)
+			return table.Action{}, errQuit
+		}
+		a, err := parseAction(g.in.Text(), o)
+		if err == errQuit {
+			return a, err
+		}
+		if err != nil {
+			fmt.Fprintln(g.out, err)
+			continue
+		}
+		return a, nil
+	}
+}
+
+// parseAction reads an action such as "c", "call", "r 20", or "a".
+func parseAction(s string, o *table.Options) (table.Action, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return table.Action{}, errors.New("enter an action")
+	}
+	raise := table.Bet
+	if o.Actions[len(o.Actions)-1] == table.Raise {
+		raise = table.Raise
+	}
+	switch fields[0][0] {
+	case 'f':
+		return table.Action{Type: table.Fold}, nil
+	case 'c', 'k':
+		return checkOrCall(o), nil
+	case 'b', 'r':
+		if len(fields) != 2 {
+			return table.Action{}, errors.New("enter the amount to bet or raise to, such as r 20")
+		}
+		amount, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return table.Action{}, errors.New("invalid amount " + fields[1])
+		}
+		return table.Action{Type: raise, Amount: amount}, nil
+	case 'a':
+		if o.MaxRaise == 0 {
+			return checkOrCall(o), nil
+		}
+		return table.Action{Type: raise, Amount: o.MaxRaise}, nil
+	case 'q':
+		return table.Action{}, errQuit
+	}
+	return table.Action{}, errors.New("unknown action " + fields[0])
+}
+
+// checkOrCall returns a check if there is nothing to call and a call
+// otherwise.
+func checkOrCall(o *table.Options) table.Action {
+	if o.ToCall == 0 {
+		return table.Action{Type: table.Check}
+	}
+	return table.Action{Type: table.Call}
+}
+
+// describeAction returns the verb and the rest of a sentence describing an
+// action.
+func describeAction(a table.Action) (string, string) {
+	amount := strconv.Itoa(a.Amount)
+	switch a.Type {
+	case table.Fold:
+		return "folds", ""
+	case table.Check:
+		return "checks", ""
+	case table.Call:
+		return "calls", amount
+	case table.Bet:
+		return "bets", amount
+	case table.Raise:
+		return "raises", "to " + amount
+	case table.PostAnte:
+		return "posts", "an ante of " + amount
+	case table.PostSmallBlind:
+		return "posts", "the small blind of " + amount
+	case table.PostBigBlind:
+		return "posts", "the big blind of " + amount
+	}
+	return a.String(), ""
+}
+
+// cards returns the cards separated by spaces, with hearts and diamonds
+// in red when color is on.
+func (g *game) cards(cs []hand.Card) string {
+	s := make([]string, len(cs))
+	for i, c := range cs {
+		s[i] = c.String()
+		if g.color && (c.Suit() == hand.Hearts || c.Suit() == hand.Diamonds) {
+			s[i] = "\x1b[31m" + s[i] + "\x1b[0m"
+		}
+	}
+	return strings.Join(s, " ")
+}
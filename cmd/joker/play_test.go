@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/pkg/table"
+)
+
+func TestPlay(t *testing.T) {
+	input := strings.Repeat("c\n", 40) + "q\n"
+	var outputs []string
+	for i := 0; i < 2; i++ {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		args := []string{"play", "-seed", "5", "-seats", "6", "-bot", "calling", "-color=false", "-hands", "3"}
+		if status := run(args, strings.NewReader(input), stdout, stderr); status != 0 {
+			t.Fatalf("status = %d (%s)", status, stderr)
+		}
+		outputs = append(outputs, stdout.String())
+	}
+	out := outputs[0]
+	if out != outputs[1] {
+		t.Fatal("games with the same seed differ")
+	}
+	for _, want := range []string{"*** Hand #1 ***", "*** Hand #3 ***", "Your cards: ", " shows ", "Final stacks:"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output doesn't contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatal("output has color escapes")
+	}
+}
+
+func TestPlayQuit(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	if status := run([]string{"play", "-seed", "1"}, strings.NewReader(""), stdout, &bytes.Buffer{}); status != 0 {
+		t.Fatalf("status = %d", status)
+	}
+	if !strings.Contains(stdout.String(), "Final stacks:") {
+		t.Fatalf("output = %s", stdout)
+	}
+	if status := run([]string{"play", "-bot", "nobody"}, strings.NewReader(""), stdout, &bytes.Buffer{}); status != 2 {
+		t.Fatalf("status for unknown bot = %d", status)
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	facing := &table.Options{Actions: []table.ActionType{table.Fold, table.Call, table.Raise}, ToCall: 4, MinRaise: 12, MaxRaise: 100}
+	open := &table.Options{Actions: []table.ActionType{table.Fold, table.Check, table.Bet}, MinRaise: 2, MaxRaise: 100}
+	tests := []struct {
+		s    string
+		o    *table.Options
+		want table.Action
+		err  bool
+	}{
+		{"f", facing, table.Action{Type: table.Fold}, false},
+		{"call", facing, table.Action{Type: table.Call}, false},
+		{"c", open, table.Action{Type: table.Check}, false},
+		{"r 20", facing, table.Action{Type: table.Raise, Amount: 20}, false},
+		{"bet 6", open, table.Action{Type: table.Bet, Amount: 6}, false},
+		{"a", facing, table.Action{Type: table.Raise, Amount: 100}, false},
+		{"r", facing, table.Action{}, true},
+		{"r x", facing, table.Action{}, true},
+		{"x", facing, table.Action{}, true},
+		{"", facing, table.Action{}, true},
+	}
+	for _, test := range tests {
+		a, err := parseAction(test.s, test.o)
+		if (err != nil) != test.err || a != test.want {
+			t.Fatalf("parseAction(%q) = %v, %v; want %v", test.s, a, err, test.want)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		net, chips int
+		want       string
+	}{
+		{10, 110, "You won 10 and have 110 chips."},
+		{-10, 90, "You lost 10 and have 90 chips."},
+		{0, 100, "You broke even and have 100 chips."},
+	}
+	for _, test := range tests {
+		if got := result(test.net, test.chips); got != test.want {
+			t.Fatalf("result(%d, %d) = %q; want %q", test.net, test.chips, got, test.want)
+		}
+	}
+}
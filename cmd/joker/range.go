@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+type rangeResult struct {
+	Range    string        `json:"range"`
+	Combos   [][]hand.Card `json:"combos"`
+	Size     float64       `json:"size"`
+	Fraction float64       `json:"fraction"`
+}
+
+func rangeCommand(args []string, in io.Reader, out io.Writer) error {
+	if len(args) == 0 || args[0] != "expand" {
+		return usageError{"usage: joker range expand [-json] range"}
+	}
+	fs := newFlagSet("range expand")
+	asJSON := fs.Bool("json", false, "write JSON")
+	args, err := parse(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return usageError{"no range given"}
+	}
+	r, err := preflop.ParseRange(strings.Join(args, ","))
+	if err != nil {
+		return err
+	}
+	res := rangeResult{
+		Range:    r.String(),
+		Combos:   r.Combos(),
+		Size:     r.Size(),
+		Fraction: r.Fraction(),
+	}
+	return output(out, *asJSON, res, func() {
+		for _, c := range res.Combos {
+			fmt.Fprintln(out, cards.Format(c))
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func shuffleCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("shuffle")
+	asJSON := fs.Bool("json", false, "write JSON")
+	seed := fs.Int64("seed", 0, "random seed (default from the time)")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return usageError{"shuffle takes no arguments"}
+	}
+	deck := hand.NewDealer(newRand(fs, *seed)).Deck()
+	cs := deck.PopMulti(len(deck.Cards))
+	return output(out, *asJSON, cs, func() {
+		s := make([]string, len(cs))
+		for i, c := range cs {
+			s[i] = cards.Format([]hand.Card{c})
+		}
+		fmt.Fprintln(out, strings.Join(s, " "))
+	})
+}
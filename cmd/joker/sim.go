@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+type rankingCount struct {
+	Ranking  hand.Ranking `json:"ranking"`
+	Name     string       `json:"name"`
+	Count    int          `json:"count"`
+	Fraction float64      `json:"fraction"`
+}
+
+type simResult struct {
+	Hands    int            `json:"hands"`
+	Players  int            `json:"players"`
+	Rankings []rankingCount `json:"rankings"`
+	Win      float64        `json:"win"`
+	Tie      float64        `json:"tie"`
+}
+
+func simCommand(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("sim")
+	asJSON := fs.Bool("json", false, "write JSON")
+	players := fs.Int("players", 2, "number of players")
+	seed := fs.Int64("seed", 0, "random seed (default from the time)")
+	hands := countFlag(10000)
+	fs.Var(&hands, "hands", "number of hands to deal")
+	r := rulesFlag("high")
+	fs.Var(&r, "rules", "high, low, or ace-to-five")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *players < 2 || *players > 23 {
+		return usageError{"players must be between 2 and 23"}
+	}
+	if hands == 0 {
+		return usageError{"hands must be positive"}
+	}
+	var hole []hand.Card
+	switch len(args) {
+	case 0:
+	case 1:
+		if hole, err = cards.Parse(args[0]); err != nil {
+			return err
+		}
+		if len(hole) != 2 {
+			return errors.New("hole must have two cards")
+		}
+	default:
+		return usageError{"sim takes at most one hand"}
+	}
+
+	res := simResult{Hands: int(hands), Players: *players}
+	counts := map[hand.Ranking]int{}
+	dealer := hand.NewDealer(newRand(fs, *seed))
+	for n := 0; n < res.Hands; n++ {
+		deck := dealer.Deck()
+		deck.Cards = remove(deck.Cards, hole)
+		holes := make([][]hand.Card, *players)
+		for i := range holes {
+			if i == 0 && hole != nil {
+				holes[i] = hole
+				continue
+			}
+			holes[i] = deck.PopMulti(2)
+		}
+		board := deck.PopMulti(5)
+		evals := make([]*hand.Hand, *players)
+		for i, h := range holes {
+			evals[i] = hand.New(append(h, board...), r.options()...)
+			counts[evals[i].Ranking()]++
+		}
+		best := hand.Sort(r.sorting(), hand.DESC, evals...)[0]
+		if evals[0].CompareTo(best) == 0 {
+			winners := 0
+			for _, e := range evals {
+				if e.CompareTo(best) == 0 {
+					winners++
+				}
+			}
+			if winners == 1 {
+				res.Win++
+			} else {
+				res.Tie++
+			}
+		}
+	}
+	res.Win /= float64(res.Hands)
+	res.Tie /= float64(res.Hands)
+	total := res.Hands * res.Players
+	for rk := hand.HighCard; rk <= hand.RoyalFlush; rk++ {
+		res.Rankings = append(res.Rankings, rankingCount{
+			Ranking:  rk,
+			Name:     rk.String(),
+			Count:    counts[rk],
+			Fraction: float64(counts[rk]) / float64(total),
+		})
+	}
+	return output(out, *asJSON, res, func() {
+		fmt.Fprintf(out, "%-14s %10s %8s\n", "ranking", "count", "percent")
+		for _, c := range res.Rankings {
+			fmt.Fprintf(out, "%-14s %10d %7.3f%%\n", c.Name, c.Count, 100*c.Fraction)
+		}
+		fmt.Fprintf(out, "player 1 won %.2f%% and tied %.2f%% of %d hands\n", 100*res.Win, 100*res.Tie, res.Hands)
+	})
+}
+
+// remove returns the cards that aren't in known.
+func remove(cards, known []hand.Card) []hand.Card {
+	rest := []hand.Card{}
+	for _, c := range cards {
+		found := false
+		for _, k := range known {
+			found = found || c == k
+		}
+		if !found {
+			rest = append(rest, c)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,61 @@
+// Command preflopgen regenerates the preflop equity table embedded in
+// pkg/preflop.
+//
+// By default every five card board is enumerated up to suit isomorphism,
+// so heads up equities are exact and multiway equities sample one set of
+// opponents per hand and board.  With -boards the boards are sampled
+// instead, which is faster but only approximate.
+//
+// Usage:
+//
+//	preflopgen [-boards n] [-seed n] [-o file]
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/iso"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+func main() {
+	boards := flag.Int("boards", 0, "number of random boards to sample instead of enumerating them")
+	seed := flag.Int64("seed", 1, "random seed")
+	out := flag.String("o", "equity.dat", "output file")
+	flag.Parse()
+
+	g := preflop.NewGenerator(rand.New(rand.NewSource(*seed)))
+	if *boards > 0 {
+		for g.Boards() < *boards {
+			g.AddBoard()
+			if g.Boards()%100 == 0 {
+				log.Printf("sampled %d of %d boards", g.Boards(), *boards)
+			}
+		}
+	} else {
+		idx, err := iso.NewIndexer(5)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i := uint64(0); i < idx.Size(); i++ {
+			_, board, weight := iso.Canonicalize(nil, idx.Unindex(i))
+			g.Add(board, weight)
+			if (i+1)%1000 == 0 {
+				log.Printf("enumerated %d of %d canonical boards", i+1, idx.Size())
+			}
+		}
+		if g.Boards() != 2598960 {
+			log.Fatalf("enumerated %d boards; want 2598960", g.Boards())
+		}
+	}
+	b, err := g.Table().MarshalBinary()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, b, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+	"github.com/notnil/joker/pkg/replay"
+	"github.com/notnil/joker/pkg/table"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// maxIterations caps the samples an equity request may ask for.
+const maxIterations = 100000
+
+// maxReplayIterations caps the samples a replay may ask for, since they
+// are taken again whenever the board or the players in the hand change,
+// and maxReplayActions caps the actions in the hand replayed.
+const (
+	maxReplayIterations = 1000
+	maxReplayActions    = 1000
+)
+
+// NewAPI returns the handler for the versioned JSON API described in
+// openapi.yaml.  It serves requests under /v1/.
+func NewAPI() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openAPISpec)
+	})
+	mux.Handle("/v1/evaluate", post(evaluate))
+	mux.Handle("/v1/compare", post(compare))
+	mux.Handle("/v1/equity", post(calculateEquity))
+	mux.Handle("/v1/range/parse", post(parseRange))
+	mux.Handle("/v1/replay", post(replayHand))
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	})
+	return mux
+}
+
+// apiError is the body of every error response.
+type apiError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// invalid is an error caused by the request rather than the server.
+type invalid struct {
+	error
+}
+
+func badRequest(msg string) error {
+	return invalid{errors.New(msg)}
+}
+
+// post adapts a function from a request body to a response into a
+// handler for POST requests.
+func post(f func(body json.RawMessage) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use POST")
+			return
+		}
+		var body json.RawMessage
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_json", err.Error())
+			return
+		}
+		resp, err := f(body)
+		var bad invalid
+		switch {
+		case errors.As(err, &bad):
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, "internal", err.Error())
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+	})
+}
+
+func decode(body json.RawMessage, v interface{}) error {
+	d := json.NewDecoder(strings.NewReader(string(body)))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		return invalid{err}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	var e apiError
+	e.Error.Code = code
+	e.Error.Message = msg
+	writeJSON(w, status, e)
+}
+
+// checkCards checks cards given in a request.
+func checkCards(cs []hand.Card, min, max int, what string) error {
+	if err := cards.Check(cs, min, max, what); err != nil {
+		return badRequest(err.Error())
+	}
+	return nil
+}
+
+type evaluateRequest struct {
+	Cards  cards.List   `json:"cards"`
+	Config *hand.Config `json:"config"`
+}
+
+func evaluate(body json.RawMessage) (interface{}, error) {
+	var req evaluateRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	if err := checkCards(req.Cards, 1, 7, "cards"); err != nil {
+		return nil, err
+	}
+	return hand.New(req.Cards, cards.Rules(req.Config)...), nil
+}
+
+type compareRequest struct {
+	Hands  []cards.List `json:"hands"`
+	Config *hand.Config `json:"config"`
+}
+
+type compareResponse struct {
+	Hands   []*hand.Hand `json:"hands"`
+	Winners []int        `json:"winners"`
+}
+
+func compare(body json.RawMessage) (interface{}, error) {
+	var req compareRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Hands) < 2 {
+		return nil, badRequest("compare needs at least two hands")
+	}
+	resp := compareResponse{}
+	for _, h := range req.Hands {
+		if err := checkCards(h, 5, 7, "hand"); err != nil {
+			return nil, err
+		}
+		resp.Hands = append(resp.Hands, hand.New(h, cards.Rules(req.Config)...))
+	}
+	resp.Winners = cards.Winners(resp.Hands, cards.Sorting(req.Config))
+	return resp, nil
+}
+
+type equityPlayer struct {
+	Cards cards.List `json:"cards,omitempty"`
+	Range string     `json:"range,omitempty"`
+}
+
+type equityRequest struct {
+	Players    []equityPlayer `json:"players"`
+	Board      cards.List     `json:"board"`
+	Dead       cards.List     `json:"dead"`
+	Iterations int            `json:"iterations"`
+	Seed       *int64         `json:"seed"`
+}
+
+func calculateEquity(body json.RawMessage) (interface{}, error) {
+	var req equityRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	ranges := make([][][]hand.Card, len(req.Players))
+	for i, p := range req.Players {
+		r, err := playerRange(p.Cards, p.Range, p.Cards != nil)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = r
+	}
+	return calculate(ranges, req.Board, req.Dead, req.Iterations, req.Seed)
+}
+
+// playerRange returns the combos of a player given either hole cards or
+// a range.
+func playerRange(hole []hand.Card, rng string, hasCards bool) ([][]hand.Card, error) {
+	switch {
+	case rng != "" && hasCards:
+		return nil, badRequest("a player has both cards and a range")
+	case rng != "":
+		r, err := preflop.ParseRange(rng)
+		if err != nil {
+			return nil, invalid{err}
+		}
+		return r.Combos(), nil
+	}
+	if err := checkCards(hole, 2, 2, "player"); err != nil {
+		return nil, err
+	}
+	return [][]hand.Card{hole}, nil
+}
+
+// calculate validates and runs an equity calculation.
+func calculate(ranges [][][]hand.Card, board, dead []hand.Card, iterations int, seed *int64) (*equity.Result, error) {
+	if len(ranges) < 2 || len(ranges) > 10 {
+		return nil, badRequest("equity needs two to ten players")
+	}
+	if iterations < 0 || iterations > maxIterations {
+		return nil, badRequest("iterations must be between 0 and 100000")
+	}
+	if err := checkCards(board, 0, 5, "board"); err != nil {
+		return nil, err
+	}
+	options := []func(*equity.Config){equity.Dead(dead...)}
+	if iterations > 0 {
+		options = append(options, equity.Iterations(iterations))
+	}
+	if seed != nil {
+		options = append(options, equity.WithRand(rand.New(rand.NewSource(*seed))))
+	}
+	res, err := equity.Ranges(ranges, board, options...)
+	if err != nil {
+		return nil, invalid{err}
+	}
+	return res, nil
+}
+
+type rangeRequest struct {
+	Range string `json:"range"`
+}
+
+type rangeClass struct {
+	Class  preflop.Class `json:"class"`
+	Weight float64       `json:"weight"`
+}
+
+type rangeResponse struct {
+	Range    string        `json:"range"`
+	Classes  []rangeClass  `json:"classes"`
+	Combos   [][]hand.Card `json:"combos"`
+	Size     float64       `json:"size"`
+	Fraction float64       `json:"fraction"`
+}
+
+func parseRange(body json.RawMessage) (interface{}, error) {
+	var req rangeRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	r, err := preflop.ParseRange(req.Range)
+	if err != nil {
+		return nil, invalid{err}
+	}
+	resp := rangeResponse{
+		Range:    r.String(),
+		Classes:  []rangeClass{},
+		Combos:   r.Combos(),
+		Size:     r.Size(),
+		Fraction: r.Fraction(),
+	}
+	for _, c := range preflop.Classes() {
+		if r.Contains(c) {
+			resp.Classes = append(resp.Classes, rangeClass{Class: c, Weight: r[c]})
+		}
+	}
+	return resp, nil
+}
+
+type replayRequest struct {
+	Record     *table.Record `json:"record"`
+	Iterations int           `json:"iterations"`
+	Seed       *int64        `json:"seed"`
+}
+
+type replayResponse struct {
+	Snapshots []*replay.Snapshot `json:"snapshots"`
+}
+
+func replayHand(body json.RawMessage) (interface{}, error) {
+	var req replayRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	if req.Record == nil {
+		return nil, badRequest("replay needs a hand record")
+	}
+	if len(req.Record.Actions) > maxReplayActions {
+		return nil, badRequest("replay allows at most 1000 actions")
+	}
+	if req.Iterations < 0 || req.Iterations > maxReplayIterations {
+		return nil, badRequest("iterations must be between 0 and 1000")
+	}
+	var options []func(*replay.Config)
+	if req.Iterations > 0 {
+		options = append(options, replay.Samples(req.Iterations))
+	}
+	if req.Seed != nil {
+		options = append(options, replay.WithRand(rand.New(rand.NewSource(*req.Seed))))
+	}
+	snapshots, err := replay.All(req.Record, options...)
+	if err != nil {
+		return nil, invalid{err}
+	}
+	return replayResponse{Snapshots: snapshots}, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type apiTest struct {
+	path   string
+	body   string
+	status int
+	check  func(t *testing.T, body map[string]interface{})
+}
+
+var apiTests = []apiTest{
+	{
+		path:   "/v1/evaluate",
+		body:   `{"cards":["A♠","K♠","Q♠","J♠","T♠","2♦","3♣"]}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			if body["description"] != "royal flush" || body["ranking"] != 10.0 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/evaluate",
+		body:   `{"cards":["As","2s","3d","4c","5h"],"config":{"sorting":2,"aceIsLow":true,"ignoreStraights":true,"ignoreFlushes":true}}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			if body["ranking"] != 1.0 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/evaluate",
+		body:   `{"cards":["As","As"]}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/evaluate",
+		body:   `{"cards":["Xs"]}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/evaluate",
+		body:   `{"cards":["As"],"extra":1}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/compare",
+		body:   `{"hands":[["As","2d","Kc","Kh","Qd","Qc","Jh"],["Ad","3c","Kc","Kh","Qd","Qc","Jh"],["7s","2c","Kc","Kh","Qd","Qc","Jh"]]}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			if w, _ := json.Marshal(body["winners"]); string(w) != "[0,1]" {
+				t.Fatalf("winners = %s", w)
+			}
+		},
+	},
+	{
+		path:   "/v1/compare",
+		body:   `{"hands":[["As"],["Ks"]]}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/equity",
+		body:   `{"players":[{"cards":["As","Ad"]},{"cards":["7h","7c"]}],"board":["7d","Ks","2c"]}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			eq := body["equity"].([]interface{})
+			if body["exact"] != true || eq[0].(float64) > 0.1 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/equity",
+		body:   `{"players":[{"cards":["As","Ad"]},{"range":"KK,QQ"}],"board":["7d","Ks","2c","3h"],"iterations":200,"seed":1}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			if body["exact"] != false || body["samples"] != 200.0 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/equity",
+		body:   `{"players":[{"cards":["As","Ad"]},{"range":"ZZ"}]}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/equity",
+		body:   `{"players":[{"cards":["As","Ad"]},{"cards":["Ks","Kd"]}],"iterations":1000000}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/range/parse",
+		body:   `{"range":"AA,KK,AKs"}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			if body["range"] != "KK+,AKs" || len(body["combos"].([]interface{})) != 16 || body["size"] != 16.0 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/replay",
+		body:   `{"record":{"button":0,"smallBlind":1,"bigBlind":2,"seats":[{"seat":0,"name":"a","chips":100,"cards":["A♠","A♦"],"won":0,"net":-1},{"seat":1,"name":"b","chips":100,"cards":["K♠","K♦"],"won":2,"net":1}],"actions":[{"seat":0,"round":"preflop","type":"postSmallBlind","amount":1},{"seat":1,"round":"preflop","type":"postBigBlind","amount":2},{"seat":0,"round":"preflop","type":"fold"}],"board":[]},"iterations":100,"seed":1}`,
+		status: http.StatusOK,
+		check: func(t *testing.T, body map[string]interface{}) {
+			snapshots := body["snapshots"].([]interface{})
+			end := snapshots[1].(map[string]interface{})
+			if len(snapshots) != 2 || end["active"] != false || end["players"].([]interface{})[1].(map[string]interface{})["chips"] != 101.0 {
+				t.Fatalf("body = %v", body)
+			}
+		},
+	},
+	{
+		path:   "/v1/replay",
+		body:   `{"record":{"button":0,"smallBlind":1,"bigBlind":2,"seats":[{"seat":0,"name":"a","chips":100,"cards":["A♠","A♦"],"won":0,"net":-1},{"seat":1,"name":"b","chips":100,"cards":["K♠","K♦"],"won":3,"net":1}],"actions":[{"seat":0,"round":"preflop","type":"postSmallBlind","amount":1},{"seat":1,"round":"preflop","type":"postBigBlind","amount":2},{"seat":0,"round":"preflop","type":"fold"}],"board":[]}}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/replay",
+		body:   `{"record":{"button":0,"smallBlind":1,"bigBlind":2,"seats":[{"seat":0,"name":"a","chips":100,"cards":["A♠","A♦"]},{"seat":1,"name":"b","chips":100,"cards":["K♠","K♦"]}],"actions":[]},"iterations":100000}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/replay",
+		body:   `{"record":{"button":0,"smallBlind":1,"bigBlind":2,"seats":[{"seat":0,"name":"a","chips":100,"cards":["A♠","A♦"]},{"seat":68719476736,"name":"b","chips":100,"cards":["K♠","K♦"]}],"actions":[]}}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		path:   "/v1/nope",
+		body:   `{}`,
+		status: http.StatusNotFound,
+	},
+}
+
+func TestAPI(t *testing.T) {
+	ts := httptest.NewServer(NewAPI())
+	defer ts.Close()
+	for _, test := range apiTests {
+		resp, err := http.Post(ts.URL+test.path, "application/json", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Fatalf("%s %s: status %d; want %d (%v)", test.path, test.body, resp.StatusCode, test.status, body)
+		}
+		if test.status != http.StatusOK {
+			e, ok := body["error"].(map[string]interface{})
+			if !ok || e["code"] == "" || e["message"] == "" {
+				t.Fatalf("%s %s: unstructured error %v", test.path, test.body, body)
+			}
+			continue
+		}
+		if test.check != nil {
+			test.check(t, body)
+		}
+	}
+}
+
+func TestAPIMethod(t *testing.T) {
+	ts := httptest.NewServer(NewAPI())
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/v1/evaluate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	resp, err = http.Get(ts.URL + "/v1/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("spec status = %d", resp.StatusCode)
+	}
+}
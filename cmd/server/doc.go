@@ -0,0 +1,63 @@
+/*
+Command server serves the odds calculator in public, a JSON API for hand evaluation,
+equity, and hand replays under /v1/, and live no-limit hold'em games over WebSocket.
+The same evaluation and equity calculations, along with streams of the
+tables, are served over gRPC as described in jokerpb/joker.proto.
+
+	server -addr :8080 -grpc :9090
+
+The odds calculator and the rest of public are embedded in the binary and
+run in the browser with the wasm build, so they work offline.  Pass
+-public with a directory to serve its files instead, such as while working
+on the pages.
+
+The API is described by the OpenAPI spec served at /v1/openapi.yaml.
+
+Game clients connect to /ws and exchange JSON messages.  Every message is an
+object with a "type" field; the other fields used by each type are listed
+below.  Cards are encoded as strings such as "A♠" and hands use the JSON
+encoding of hand.Hand.
+
+The first message from a client must be hello.  A new client sends only
+its name and is given a token; a client that lost its connection sends
+the token back to resume its session, keeping its seats, and is sent the
+state of every table it had joined.  A session that isn't at any table
+ends with its connection, and one that isn't resumed within the session
+timeout stands up and leaves its tables.
+
+	{"type":"hello","name":"alice"}
+	{"type":"hello","token":"4f1c..."}
+
+Clients then send:
+
+	{"type":"list"}
+	{"type":"create","table":"t1","seats":6,"smallBlind":1,"bigBlind":2,"ante":0}
+	{"type":"join","table":"t1"}
+	{"type":"sit","table":"t1","seat":2,"chips":200}
+	{"type":"act","table":"t1","action":{"type":"raise","amount":6}}
+	{"type":"stand","table":"t1"}
+	{"type":"leave","table":"t1"}
+
+Create makes a table of two to 10 seats and joins it; each client may
+create up to five tables.  Join watches a table and sit takes a seat at
+a joined table.  Action types are fold, check, call, bet, and raise,
+and the amount of a bet or raise is the total bet for the round.
+Stand gives up a seat, folding first if a hand is in progress, and leave
+also stops watching the table.  Hands are dealt whenever two players with
+chips are seated.
+
+The server sends:
+
+	{"type":"welcome","name":"alice","token":"4f1c..."}
+	{"type":"tables","tables":[{"id":"t1","seats":6,"players":2,"smallBlind":1,"bigBlind":2}]}
+	{"type":"state","table":"t1","view":{...}}
+	{"type":"error","error":"table: not your turn"}
+
+State is sent to everyone watching a table whenever it changes.  The view
+is the table as seen from the client's seat, as encoded by table.View: a
+player's hole cards are only included in their own view until they are
+shown down, and the options field is set when it is the client's turn to
+act.  Players who take longer than the action timeout to act check if
+they can and otherwise fold.
+*/
+package main
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/notnil/joker/cmd/server/jokerpb"
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewGRPCServer returns a gRPC server with the Joker service, which
+// streams the tables hosted by s.  A call that panics fails with an
+// internal error instead of stopping the server.
+func NewGRPCServer(s *Server) *grpc.Server {
+	g := grpc.NewServer(grpc.UnaryInterceptor(recoverUnary), grpc.StreamInterceptor(recoverStream))
+	jokerpb.RegisterJokerServer(g, &grpcService{server: s})
+	return g
+}
+
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer recoverError(&err)
+	return handler(ctx, req)
+}
+
+func recoverStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverError(&err)
+	return handler(srv, ss)
+}
+
+// recoverError turns a panic into an internal error.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		*err = status.Errorf(codes.Internal, "server: %v", r)
+	}
+}
+
+type grpcService struct {
+	jokerpb.UnimplementedJokerServer
+	server *Server
+}
+
+func (g *grpcService) Evaluate(ctx context.Context, req *jokerpb.EvaluateRequest) (*jokerpb.EvaluateResponse, error) {
+	cs, err := handCards(req.Cards)
+	if err == nil {
+		err = checkCards(cs, 1, 7, "cards")
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	h := hand.New(cs, req.Config.Options()...)
+	return &jokerpb.EvaluateResponse{Hand: jokerpb.FromHand(h)}, nil
+}
+
+func (g *grpcService) Compare(ctx context.Context, req *jokerpb.CompareRequest) (*jokerpb.CompareResponse, error) {
+	if len(req.Hands) < 2 {
+		return nil, grpcError(badRequest("compare needs at least two hands"))
+	}
+	var hands []*hand.Hand
+	for _, pb := range req.Hands {
+		cs, err := handCards(pb.GetCards())
+		if err == nil {
+			err = checkCards(cs, 5, 7, "hand")
+		}
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		hands = append(hands, hand.New(cs, req.Config.Options()...))
+	}
+	s := hand.SortingHigh
+	if req.Config.GetSorting() == jokerpb.Sorting_SORTING_LOW {
+		s = hand.SortingLow
+	}
+	resp := &jokerpb.CompareResponse{}
+	for _, h := range hands {
+		resp.Hands = append(resp.Hands, jokerpb.FromHand(h))
+	}
+	for _, w := range cards.Winners(hands, s) {
+		resp.Winners = append(resp.Winners, int32(w))
+	}
+	return resp, nil
+}
+
+func (g *grpcService) Equity(ctx context.Context, req *jokerpb.EquityRequest) (*jokerpb.EquityResponse, error) {
+	board, err := handCards(req.Board)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	dead, err := handCards(req.Dead)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	ranges := make([][][]hand.Card, len(req.Players))
+	for i, p := range req.Players {
+		hole, err := handCards(p.GetCards().GetCards())
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		if ranges[i], err = playerRange(hole, p.GetRange(), p.GetCards() != nil); err != nil {
+			return nil, grpcError(err)
+		}
+	}
+	res, err := calculate(ranges, board, dead, int(req.Iterations), req.Seed)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &jokerpb.EquityResponse{
+		Equity:  res.Equity,
+		Win:     res.Win,
+		Tie:     res.Tie,
+		Samples: int32(res.Samples),
+		Exact:   res.Exact,
+	}, nil
+}
+
+func (g *grpcService) ListTables(ctx context.Context, req *jokerpb.ListTablesRequest) (*jokerpb.ListTablesResponse, error) {
+	g.server.mu.Lock()
+	tables := g.server.list()
+	g.server.mu.Unlock()
+	resp := &jokerpb.ListTablesResponse{}
+	for _, t := range tables {
+		resp.Tables = append(resp.Tables, &jokerpb.TableInfo{
+			Id:         t.ID,
+			Seats:      int32(t.Seats),
+			Players:    int32(t.Players),
+			SmallBlind: int32(t.SmallBlind),
+			BigBlind:   int32(t.BigBlind),
+			Ante:       int32(t.Ante),
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcService) WatchTable(req *jokerpb.WatchTableRequest, stream jokerpb.Joker_WatchTableServer) error {
+	states, done, stop, err := g.server.watch(req.Table)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-done:
+			return status.Error(codes.ResourceExhausted, "server: watcher fell behind")
+		case m := <-states:
+			if err := stream.Send(tableState(m.Table, m.View)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// tableState converts an observer's view of a table.
+func tableState(id string, v *table.View) *jokerpb.TableState {
+	s := &jokerpb.TableState{
+		Table:      id,
+		Hand:       int32(v.Hand),
+		Active:     v.Active,
+		Button:     int32(v.Button),
+		SmallBlind: int32(v.SmallBlind),
+		BigBlind:   int32(v.BigBlind),
+		Ante:       int32(v.Ante),
+		Board:      jokerpb.FromCards(v.Board),
+		Pot:        int32(v.Pot),
+		ToAct:      int32(v.ToAct),
+	}
+	if v.Round != 0 {
+		round, _ := v.Round.MarshalText()
+		s.Round = string(round)
+	}
+	for _, p := range v.Players {
+		s.Players = append(s.Players, &jokerpb.PlayerState{
+			Name:   p.Name,
+			Chips:  int32(p.Chips),
+			Bet:    int32(p.Bet),
+			InHand: p.InHand,
+			Folded: p.Folded,
+			AllIn:  p.AllIn,
+			Cards:  jokerpb.FromCards(p.Cards),
+			Hand:   jokerpb.FromHand(p.Hand),
+			Won:    int32(p.Won),
+		})
+	}
+	for _, pot := range v.Pots {
+		pb := &jokerpb.Pot{Amount: int32(pot.Amount)}
+		for _, seat := range pot.Seats {
+			pb.Seats = append(pb.Seats, int32(seat))
+		}
+		for _, w := range pot.Winners {
+			pb.Winners = append(pb.Winners, int32(w))
+		}
+		s.Pots = append(s.Pots, pb)
+	}
+	return s
+}
+
+// handCards converts cards from a request.
+func handCards(pb []jokerpb.Card) ([]hand.Card, error) {
+	cs, err := jokerpb.HandCards(pb)
+	if err != nil {
+		return nil, invalid{err}
+	}
+	return cs, nil
+}
+
+// grpcError converts an error to a status, which is invalid argument for
+// errors caused by the request.
+func grpcError(err error) error {
+	var bad invalid
+	if errors.As(err, &bad) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/cmd/server/jokerpb"
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newGRPCClient(t *testing.T, s *Server) jokerpb.JokerClient {
+	lis := bufconn.Listen(1 << 20)
+	g := NewGRPCServer(s)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return jokerpb.NewJokerClient(conn)
+}
+
+func pbCards(s ...string) []jokerpb.Card {
+	var cs []jokerpb.Card
+	for _, str := range s {
+		c, err := cards.Parse(str)
+		if err != nil {
+			panic(err)
+		}
+		cs = append(cs, jokerpb.FromCard(c[0]))
+	}
+	return cs
+}
+
+func TestGRPCEvaluate(t *testing.T) {
+	client := newGRPCClient(t, NewServer(nil))
+	ctx := context.Background()
+	resp, err := client.Evaluate(ctx, &jokerpb.EvaluateRequest{Cards: pbCards("As", "Ks", "Qs", "Js", "Ts", "2d", "3c")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Hand.Ranking != jokerpb.Ranking_RANKING_ROYAL_FLUSH || resp.Hand.Description != "royal flush" {
+		t.Fatalf("hand = %v", resp.Hand)
+	}
+	_, err = client.Evaluate(ctx, &jokerpb.EvaluateRequest{Cards: []jokerpb.Card{jokerpb.Card_CARD_UNSPECIFIED}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("error = %v; want invalid argument", err)
+	}
+	_, err = client.Evaluate(ctx, &jokerpb.EvaluateRequest{Cards: pbCards("As", "As")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("error = %v; want invalid argument", err)
+	}
+}
+
+func TestGRPCCompare(t *testing.T) {
+	client := newGRPCClient(t, NewServer(nil))
+	resp, err := client.Compare(context.Background(), &jokerpb.CompareRequest{Hands: []*jokerpb.Cards{
+		{Cards: pbCards("As", "2d", "Kc", "Kh", "Qd", "Qc", "Jh")},
+		{Cards: pbCards("7s", "2c", "Kc", "Kh", "Qd", "Qc", "Jh")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Winners) != 1 || resp.Winners[0] != 0 || resp.Hands[1].Ranking != jokerpb.Ranking_RANKING_TWO_PAIR {
+		t.Fatalf("resp = %v", resp)
+	}
+	_, err = client.Compare(context.Background(), &jokerpb.CompareRequest{Hands: []*jokerpb.Cards{
+		{Cards: pbCards("As")},
+		{Cards: pbCards("Ks")},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("error = %v; want invalid argument", err)
+	}
+}
+
+func TestGRPCRecover(t *testing.T) {
+	_, err := recoverUnary(context.Background(), nil, nil, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("error = %v; want internal", err)
+	}
+}
+
+func TestGRPCEquity(t *testing.T) {
+	client := newGRPCClient(t, NewServer(nil))
+	seed := int64(1)
+	resp, err := client.Equity(context.Background(), &jokerpb.EquityRequest{
+		Players: []*jokerpb.Player{
+			{Hand: &jokerpb.Player_Cards{Cards: &jokerpb.Cards{Cards: pbCards("As", "Ad")}}},
+			{Hand: &jokerpb.Player_Range{Range: "KK"}},
+		},
+		Board:      pbCards("7d", "Ks", "2c", "3h"),
+		Iterations: 100,
+		Seed:       &seed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Samples != 100 || resp.Equity[0] > 0.2 {
+		t.Fatalf("resp = %v", resp)
+	}
+	_, err = client.Equity(context.Background(), &jokerpb.EquityRequest{
+		Players: []*jokerpb.Player{{Hand: &jokerpb.Player_Range{Range: "KK"}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("error = %v; want invalid argument", err)
+	}
+}
+
+func TestGRPCWatchTable(t *testing.T) {
+	s := NewServer(hand.NewDealer(rand.New(rand.NewSource(1))))
+	client := newGRPCClient(t, s)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	alice, _ := dial(t, url, Message{Type: "hello", Name: "alice"})
+	alice.send(Message{Type: "create", Table: "t1", Seats: 2, SmallBlind: 1, BigBlind: 2})
+	alice.state(func(v *table.View) bool { return true })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	list, err := client.ListTables(ctx, &jokerpb.ListTablesRequest{})
+	if err != nil || len(list.Tables) != 1 || list.Tables[0].Id != "t1" {
+		t.Fatalf("tables = %v (%v)", list, err)
+	}
+	if _, err := client.WatchTable(ctx, &jokerpb.WatchTableRequest{Table: "nope"}); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := client.WatchTable(ctx, &jokerpb.WatchTableRequest{Table: "t1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state, err := stream.Recv(); err != nil || state.Table != "t1" || state.Active {
+		t.Fatalf("first state = %v (%v)", state, err)
+	}
+
+	bob, _ := dial(t, url, Message{Type: "hello", Name: "bob"})
+	bob.send(Message{Type: "join", Table: "t1"})
+	alice.send(Message{Type: "sit", Table: "t1", Seat: 0, Chips: 100})
+	bob.send(Message{Type: "sit", Table: "t1", Seat: 1, Chips: 100})
+	alice.state(func(v *table.View) bool { return v.Options != nil })
+	alice.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Fold}})
+	for {
+		state, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range state.Players {
+			if len(p.Cards) != 0 {
+				t.Fatalf("observer was sent hole cards %v", p.Cards)
+			}
+		}
+		if state.Hand == 1 && state.Active && state.Round != "preflop" {
+			t.Fatalf("round = %q", state.Round)
+		}
+		if len(state.Pots) == 1 && state.Pots[0].Winners[0] == 1 {
+			break
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package jokerpb holds the protobuf messages and gRPC service of the
+// server along with conversions to the types of the hand package.
+package jokerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative joker.proto
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// FromCard returns the protobuf enum of a card.
+func FromCard(c hand.Card) Card {
+	return Card(c + 1)
+}
+
+// HandCard returns the card of the enum or an error if the enum is
+// unspecified or unknown.
+func (c Card) HandCard() (hand.Card, error) {
+	if c <= Card_CARD_UNSPECIFIED || c > Card_CARD_ACE_CLUBS {
+		return 0, fmt.Errorf("jokerpb: invalid card %d", c)
+	}
+	return hand.Card(c - 1), nil
+}
+
+// FromCards returns the protobuf enums of cards.
+func FromCards(cards []hand.Card) []Card {
+	pb := make([]Card, len(cards))
+	for i, c := range cards {
+		pb[i] = FromCard(c)
+	}
+	return pb
+}
+
+// HandCards returns the cards of the enums or an error if any are
+// invalid.
+func HandCards(pb []Card) ([]hand.Card, error) {
+	cards := make([]hand.Card, len(pb))
+	for i, c := range pb {
+		card, err := c.HandCard()
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+// FromRanking returns the protobuf enum of a ranking.
+func FromRanking(r hand.Ranking) Ranking {
+	return Ranking(r)
+}
+
+// HandRanking returns the ranking of the enum, which is zero if the enum
+// is unspecified.
+func (r Ranking) HandRanking() hand.Ranking {
+	return hand.Ranking(r)
+}
+
+// FromHand returns the protobuf message of a hand.
+func FromHand(h *hand.Hand) *Hand {
+	if h == nil {
+		return nil
+	}
+	return &Hand{
+		Ranking:     FromRanking(h.Ranking()),
+		Cards:       FromCards(h.Cards()),
+		Description: h.Description(),
+	}
+}
+
+// Options returns the hand options of a configuration.
+func (c *Config) Options() []func(*hand.Config) {
+	if c == nil {
+		return nil
+	}
+	// hand.Config's fields are only settable through its JSON encoding
+	b, _ := json.Marshal(map[string]interface{}{
+		"sorting":         int(c.Sorting),
+		"ignoreStraights": c.IgnoreStraights,
+		"ignoreFlushes":   c.IgnoreFlushes,
+		"aceIsLow":        c.AceIsLow,
+	})
+	var config hand.Config
+	config.UnmarshalJSON(b)
+	return []func(*hand.Config){func(hc *hand.Config) { *hc = config }}
+}
@@ -0,0 +1,51 @@
+package jokerpb_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/cmd/server/jokerpb"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func TestCards(t *testing.T) {
+	for _, c := range hand.Cards() {
+		pb := jokerpb.FromCard(c)
+		back, err := pb.HandCard()
+		if err != nil || back != c {
+			t.Fatalf("%v converted to %v and back to %v (%v)", c, pb, back, err)
+		}
+	}
+	if got := jokerpb.FromCard(hand.AceSpades).String(); got != "CARD_ACE_SPADES" {
+		t.Fatalf("ace of spades is %s", got)
+	}
+	if got := jokerpb.FromCard(hand.TenDiamonds).String(); got != "CARD_TEN_DIAMONDS" {
+		t.Fatalf("ten of diamonds is %s", got)
+	}
+	if _, err := jokerpb.Card_CARD_UNSPECIFIED.HandCard(); err == nil {
+		t.Fatal("expected error for unspecified card")
+	}
+}
+
+func TestRankings(t *testing.T) {
+	for r := hand.HighCard; r <= hand.RoyalFlush; r++ {
+		pb := jokerpb.FromRanking(r)
+		if pb.HandRanking() != r {
+			t.Fatalf("%v round tripped to %v", r, pb.HandRanking())
+		}
+	}
+	if got := jokerpb.FromRanking(hand.FullHouse).String(); got != "RANKING_FULL_HOUSE" {
+		t.Fatalf("full house is %s", got)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	cards := []hand.Card{hand.AceSpades, hand.TwoSpades, hand.ThreeSpades, hand.FourSpades, hand.FiveSpades}
+	c := &jokerpb.Config{Sorting: jokerpb.Sorting_SORTING_LOW, AceIsLow: true, IgnoreStraights: true, IgnoreFlushes: true}
+	if h := hand.New(cards, c.Options()...); h.Ranking() != hand.HighCard {
+		t.Fatalf("ace to five low is %v", h)
+	}
+	var none *jokerpb.Config
+	if h := hand.New(cards, none.Options()...); h.Ranking() != hand.StraightFlush {
+		t.Fatalf("high hand is %v", h)
+	}
+}
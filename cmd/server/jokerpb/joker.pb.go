@@ -0,0 +1,2082 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: joker.proto
+
+package jokerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Card is a playing card.  Values are one more than the matching
+// hand.Card.
+type Card int32
+
+const (
+	Card_CARD_UNSPECIFIED    Card = 0
+	Card_CARD_TWO_SPADES     Card = 1
+	Card_CARD_THREE_SPADES   Card = 2
+	Card_CARD_FOUR_SPADES    Card = 3
+	Card_CARD_FIVE_SPADES    Card = 4
+	Card_CARD_SIX_SPADES     Card = 5
+	Card_CARD_SEVEN_SPADES   Card = 6
+	Card_CARD_EIGHT_SPADES   Card = 7
+	Card_CARD_NINE_SPADES    Card = 8
+	Card_CARD_TEN_SPADES     Card = 9
+	Card_CARD_JACK_SPADES    Card = 10
+	Card_CARD_QUEEN_SPADES   Card = 11
+	Card_CARD_KING_SPADES    Card = 12
+	Card_CARD_ACE_SPADES     Card = 13
+	Card_CARD_TWO_HEARTS     Card = 14
+	Card_CARD_THREE_HEARTS   Card = 15
+	Card_CARD_FOUR_HEARTS    Card = 16
+	Card_CARD_FIVE_HEARTS    Card = 17
+	Card_CARD_SIX_HEARTS     Card = 18
+	Card_CARD_SEVEN_HEARTS   Card = 19
+	Card_CARD_EIGHT_HEARTS   Card = 20
+	Card_CARD_NINE_HEARTS    Card = 21
+	Card_CARD_TEN_HEARTS     Card = 22
+	Card_CARD_JACK_HEARTS    Card = 23
+	Card_CARD_QUEEN_HEARTS   Card = 24
+	Card_CARD_KING_HEARTS    Card = 25
+	Card_CARD_ACE_HEARTS     Card = 26
+	Card_CARD_TWO_DIAMONDS   Card = 27
+	Card_CARD_THREE_DIAMONDS Card = 28
+	Card_CARD_FOUR_DIAMONDS  Card = 29
+	Card_CARD_FIVE_DIAMONDS  Card = 30
+	Card_CARD_SIX_DIAMONDS   Card = 31
+	Card_CARD_SEVEN_DIAMONDS Card = 32
+	Card_CARD_EIGHT_DIAMONDS Card = 33
+	Card_CARD_NINE_DIAMONDS  Card = 34
+	Card_CARD_TEN_DIAMONDS   Card = 35
+	Card_CARD_JACK_DIAMONDS  Card = 36
+	Card_CARD_QUEEN_DIAMONDS Card = 37
+	Card_CARD_KING_DIAMONDS  Card = 38
+	Card_CARD_ACE_DIAMONDS   Card = 39
+	Card_CARD_TWO_CLUBS      Card = 40
+	Card_CARD_THREE_CLUBS    Card = 41
+	Card_CARD_FOUR_CLUBS     Card = 42
+	Card_CARD_FIVE_CLUBS     Card = 43
+	Card_CARD_SIX_CLUBS      Card = 44
+	Card_CARD_SEVEN_CLUBS    Card = 45
+	Card_CARD_EIGHT_CLUBS    Card = 46
+	Card_CARD_NINE_CLUBS     Card = 47
+	Card_CARD_TEN_CLUBS      Card = 48
+	Card_CARD_JACK_CLUBS     Card = 49
+	Card_CARD_QUEEN_CLUBS    Card = 50
+	Card_CARD_KING_CLUBS     Card = 51
+	Card_CARD_ACE_CLUBS      Card = 52
+)
+
+// Enum value maps for Card.
+var (
+	Card_name = map[int32]string{
+		0:  "CARD_UNSPECIFIED",
+		1:  "CARD_TWO_SPADES",
+		2:  "CARD_THREE_SPADES",
+		3:  "CARD_FOUR_SPADES",
+		4:  "CARD_FIVE_SPADES",
+		5:  "CARD_SIX_SPADES",
+		6:  "CARD_SEVEN_SPADES",
+		7:  "CARD_EIGHT_SPADES",
+		8:  "CARD_NINE_SPADES",
+		9:  "CARD_TEN_SPADES",
+		10: "CARD_JACK_SPADES",
+		11: "CARD_QUEEN_SPADES",
+		12: "CARD_KING_SPADES",
+		13: "CARD_ACE_SPADES",
+		14: "CARD_TWO_HEARTS",
+		15: "CARD_THREE_HEARTS",
+		16: "CARD_FOUR_HEARTS",
+		17: "CARD_FIVE_HEARTS",
+		18: "CARD_SIX_HEARTS",
+		19: "CARD_SEVEN_HEARTS",
+		20: "CARD_EIGHT_HEARTS",
+		21: "CARD_NINE_HEARTS",
+		22: "CARD_TEN_HEARTS",
+		23: "CARD_JACK_HEARTS",
+		24: "CARD_QUEEN_HEARTS",
+		25: "CARD_KING_HEARTS",
+		26: "CARD_ACE_HEARTS",
+		27: "CARD_TWO_DIAMONDS",
+		28: "CARD_THREE_DIAMONDS",
+		29: "CARD_FOUR_DIAMONDS",
+		30: "CARD_FIVE_DIAMONDS",
+		31: "CARD_SIX_DIAMONDS",
+		32: "CARD_SEVEN_DIAMONDS",
+		33: "CARD_EIGHT_DIAMONDS",
+		34: "CARD_NINE_DIAMONDS",
+		35: "CARD_TEN_DIAMONDS",
+		36: "CARD_JACK_DIAMONDS",
+		37: "CARD_QUEEN_DIAMONDS",
+		38: "CARD_KING_DIAMONDS",
+		39: "CARD_ACE_DIAMONDS",
+		40: "CARD_TWO_CLUBS",
+		41: "CARD_THREE_CLUBS",
+		42: "CARD_FOUR_CLUBS",
+		43: "CARD_FIVE_CLUBS",
+		44: "CARD_SIX_CLUBS",
+		45: "CARD_SEVEN_CLUBS",
+		46: "CARD_EIGHT_CLUBS",
+		47: "CARD_NINE_CLUBS",
+		48: "CARD_TEN_CLUBS",
+		49: "CARD_JACK_CLUBS",
+		50: "CARD_QUEEN_CLUBS",
+		51: "CARD_KING_CLUBS",
+		52: "CARD_ACE_CLUBS",
+	}
+	Card_value = map[string]int32{
+		"CARD_UNSPECIFIED":    0,
+		"CARD_TWO_SPADES":     1,
+		"CARD_THREE_SPADES":   2,
+		"CARD_FOUR_SPADES":    3,
+		"CARD_FIVE_SPADES":    4,
+		"CARD_SIX_SPADES":     5,
+		"CARD_SEVEN_SPADES":   6,
+		"CARD_EIGHT_SPADES":   7,
+		"CARD_NINE_SPADES":    8,
+		"CARD_TEN_SPADES":     9,
+		"CARD_JACK_SPADES":    10,
+		"CARD_QUEEN_SPADES":   11,
+		"CARD_KING_SPADES":    12,
+		"CARD_ACE_SPADES":     13,
+		"CARD_TWO_HEARTS":     14,
+		"CARD_THREE_HEARTS":   15,
+		"CARD_FOUR_HEARTS":    16,
+		"CARD_FIVE_HEARTS":    17,
+		"CARD_SIX_HEARTS":     18,
+		"CARD_SEVEN_HEARTS":   19,
+		"CARD_EIGHT_HEARTS":   20,
+		"CARD_NINE_HEARTS":    21,
+		"CARD_TEN_HEARTS":     22,
+		"CARD_JACK_HEARTS":    23,
+		"CARD_QUEEN_HEARTS":   24,
+		"CARD_KING_HEARTS":    25,
+		"CARD_ACE_HEARTS":     26,
+		"CARD_TWO_DIAMONDS":   27,
+		"CARD_THREE_DIAMONDS": 28,
+		"CARD_FOUR_DIAMONDS":  29,
+		"CARD_FIVE_DIAMONDS":  30,
+		"CARD_SIX_DIAMONDS":   31,
+		"CARD_SEVEN_DIAMONDS": 32,
+		"CARD_EIGHT_DIAMONDS": 33,
+		"CARD_NINE_DIAMONDS":  34,
+		"CARD_TEN_DIAMONDS":   35,
+		"CARD_JACK_DIAMONDS":  36,
+		"CARD_QUEEN_DIAMONDS": 37,
+		"CARD_KING_DIAMONDS":  38,
+		"CARD_ACE_DIAMONDS":   39,
+		"CARD_TWO_CLUBS":      40,
+		"CARD_THREE_CLUBS":    41,
+		"CARD_FOUR_CLUBS":     42,
+		"CARD_FIVE_CLUBS":     43,
+		"CARD_SIX_CLUBS":      44,
+		"CARD_SEVEN_CLUBS":    45,
+		"CARD_EIGHT_CLUBS":    46,
+		"CARD_NINE_CLUBS":     47,
+		"CARD_TEN_CLUBS":      48,
+		"CARD_JACK_CLUBS":     49,
+		"CARD_QUEEN_CLUBS":    50,
+		"CARD_KING_CLUBS":     51,
+		"CARD_ACE_CLUBS":      52,
+	}
+)
+
+func (x Card) Enum() *Card {
+	p := new(Card)
+	*p = x
+	return p
+}
+
+func (x Card) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Card) Descriptor() protoreflect.EnumDescriptor {
+	return file_joker_proto_enumTypes[0].Descriptor()
+}
+
+func (Card) Type() protoreflect.EnumType {
+	return &file_joker_proto_enumTypes[0]
+}
+
+func (x Card) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Card.Descriptor instead.
+func (Card) EnumDescriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{0}
+}
+
+// Ranking is a hand ranking with the same values as hand.Ranking.
+type Ranking int32
+
+const (
+	Ranking_RANKING_UNSPECIFIED     Ranking = 0
+	Ranking_RANKING_HIGH_CARD       Ranking = 1
+	Ranking_RANKING_PAIR            Ranking = 2
+	Ranking_RANKING_TWO_PAIR        Ranking = 3
+	Ranking_RANKING_THREE_OF_A_KIND Ranking = 4
+	Ranking_RANKING_STRAIGHT        Ranking = 5
+	Ranking_RANKING_FLUSH           Ranking = 6
+	Ranking_RANKING_FULL_HOUSE      Ranking = 7
+	Ranking_RANKING_FOUR_OF_A_KIND  Ranking = 8
+	Ranking_RANKING_STRAIGHT_FLUSH  Ranking = 9
+	Ranking_RANKING_ROYAL_FLUSH     Ranking = 10
+)
+
+// Enum value maps for Ranking.
+var (
+	Ranking_name = map[int32]string{
+		0:  "RANKING_UNSPECIFIED",
+		1:  "RANKING_HIGH_CARD",
+		2:  "RANKING_PAIR",
+		3:  "RANKING_TWO_PAIR",
+		4:  "RANKING_THREE_OF_A_KIND",
+		5:  "RANKING_STRAIGHT",
+		6:  "RANKING_FLUSH",
+		7:  "RANKING_FULL_HOUSE",
+		8:  "RANKING_FOUR_OF_A_KIND",
+		9:  "RANKING_STRAIGHT_FLUSH",
+		10: "RANKING_ROYAL_FLUSH",
+	}
+	Ranking_value = map[string]int32{
+		"RANKING_UNSPECIFIED":     0,
+		"RANKING_HIGH_CARD":       1,
+		"RANKING_PAIR":            2,
+		"RANKING_TWO_PAIR":        3,
+		"RANKING_THREE_OF_A_KIND": 4,
+		"RANKING_STRAIGHT":        5,
+		"RANKING_FLUSH":           6,
+		"RANKING_FULL_HOUSE":      7,
+		"RANKING_FOUR_OF_A_KIND":  8,
+		"RANKING_STRAIGHT_FLUSH":  9,
+		"RANKING_ROYAL_FLUSH":     10,
+	}
+)
+
+func (x Ranking) Enum() *Ranking {
+	p := new(Ranking)
+	*p = x
+	return p
+}
+
+func (x Ranking) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Ranking) Descriptor() protoreflect.EnumDescriptor {
+	return file_joker_proto_enumTypes[1].Descriptor()
+}
+
+func (Ranking) Type() protoreflect.EnumType {
+	return &file_joker_proto_enumTypes[1]
+}
+
+func (x Ranking) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Ranking.Descriptor instead.
+func (Ranking) EnumDescriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{1}
+}
+
+// Sorting selects high or low hands.  Unspecified means high.
+type Sorting int32
+
+const (
+	Sorting_SORTING_UNSPECIFIED Sorting = 0
+	Sorting_SORTING_HIGH        Sorting = 1
+	Sorting_SORTING_LOW         Sorting = 2
+)
+
+// Enum value maps for Sorting.
+var (
+	Sorting_name = map[int32]string{
+		0: "SORTING_UNSPECIFIED",
+		1: "SORTING_HIGH",
+		2: "SORTING_LOW",
+	}
+	Sorting_value = map[string]int32{
+		"SORTING_UNSPECIFIED": 0,
+		"SORTING_HIGH":        1,
+		"SORTING_LOW":         2,
+	}
+)
+
+func (x Sorting) Enum() *Sorting {
+	p := new(Sorting)
+	*p = x
+	return p
+}
+
+func (x Sorting) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Sorting) Descriptor() protoreflect.EnumDescriptor {
+	return file_joker_proto_enumTypes[2].Descriptor()
+}
+
+func (Sorting) Type() protoreflect.EnumType {
+	return &file_joker_proto_enumTypes[2]
+}
+
+func (x Sorting) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Sorting.Descriptor instead.
+func (Sorting) EnumDescriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{2}
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sorting         Sorting `protobuf:"varint,1,opt,name=sorting,proto3,enum=joker.v1.Sorting" json:"sorting,omitempty"`
+	IgnoreStraights bool    `protobuf:"varint,2,opt,name=ignore_straights,json=ignoreStraights,proto3" json:"ignore_straights,omitempty"`
+	IgnoreFlushes   bool    `protobuf:"varint,3,opt,name=ignore_flushes,json=ignoreFlushes,proto3" json:"ignore_flushes,omitempty"`
+	AceIsLow        bool    `protobuf:"varint,4,opt,name=ace_is_low,json=aceIsLow,proto3" json:"ace_is_low,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Config) GetSorting() Sorting {
+	if x != nil {
+		return x.Sorting
+	}
+	return Sorting_SORTING_UNSPECIFIED
+}
+
+func (x *Config) GetIgnoreStraights() bool {
+	if x != nil {
+		return x.IgnoreStraights
+	}
+	return false
+}
+
+func (x *Config) GetIgnoreFlushes() bool {
+	if x != nil {
+		return x.IgnoreFlushes
+	}
+	return false
+}
+
+func (x *Config) GetAceIsLow() bool {
+	if x != nil {
+		return x.AceIsLow
+	}
+	return false
+}
+
+type Hand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranking     Ranking `protobuf:"varint,1,opt,name=ranking,proto3,enum=joker.v1.Ranking" json:"ranking,omitempty"`
+	Cards       []Card  `protobuf:"varint,2,rep,packed,name=cards,proto3,enum=joker.v1.Card" json:"cards,omitempty"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *Hand) Reset() {
+	*x = Hand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hand) ProtoMessage() {}
+
+func (x *Hand) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hand.ProtoReflect.Descriptor instead.
+func (*Hand) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Hand) GetRanking() Ranking {
+	if x != nil {
+		return x.Ranking
+	}
+	return Ranking_RANKING_UNSPECIFIED
+}
+
+func (x *Hand) GetCards() []Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Hand) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Cards struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cards []Card `protobuf:"varint,1,rep,packed,name=cards,proto3,enum=joker.v1.Card" json:"cards,omitempty"`
+}
+
+func (x *Cards) Reset() {
+	*x = Cards{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cards) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cards) ProtoMessage() {}
+
+func (x *Cards) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cards.ProtoReflect.Descriptor instead.
+func (*Cards) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Cards) GetCards() []Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cards  []Card  `protobuf:"varint,1,rep,packed,name=cards,proto3,enum=joker.v1.Card" json:"cards,omitempty"`
+	Config *Config `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EvaluateRequest) GetCards() []Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hand *Hand `protobuf:"bytes,1,opt,name=hand,proto3" json:"hand,omitempty"`
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EvaluateResponse) GetHand() *Hand {
+	if x != nil {
+		return x.Hand
+	}
+	return nil
+}
+
+type CompareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hands  []*Cards `protobuf:"bytes,1,rep,name=hands,proto3" json:"hands,omitempty"`
+	Config *Config  `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompareRequest) GetHands() []*Cards {
+	if x != nil {
+		return x.Hands
+	}
+	return nil
+}
+
+func (x *CompareRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CompareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hands   []*Hand `protobuf:"bytes,1,rep,name=hands,proto3" json:"hands,omitempty"`
+	Winners []int32 `protobuf:"varint,2,rep,packed,name=winners,proto3" json:"winners,omitempty"`
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CompareResponse) GetHands() []*Hand {
+	if x != nil {
+		return x.Hands
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetWinners() []int32 {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Hand:
+	//	*Player_Cards
+	//	*Player_Range
+	Hand isPlayer_Hand `protobuf_oneof:"hand"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *Player) GetHand() isPlayer_Hand {
+	if m != nil {
+		return m.Hand
+	}
+	return nil
+}
+
+func (x *Player) GetCards() *Cards {
+	if x, ok := x.GetHand().(*Player_Cards); ok {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Player) GetRange() string {
+	if x, ok := x.GetHand().(*Player_Range); ok {
+		return x.Range
+	}
+	return ""
+}
+
+type isPlayer_Hand interface {
+	isPlayer_Hand()
+}
+
+type Player_Cards struct {
+	Cards *Cards `protobuf:"bytes,1,opt,name=cards,proto3,oneof"`
+}
+
+type Player_Range struct {
+	Range string `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
+}
+
+func (*Player_Cards) isPlayer_Hand() {}
+
+func (*Player_Range) isPlayer_Hand() {}
+
+type EquityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Players    []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	Board      []Card    `protobuf:"varint,2,rep,packed,name=board,proto3,enum=joker.v1.Card" json:"board,omitempty"`
+	Dead       []Card    `protobuf:"varint,3,rep,packed,name=dead,proto3,enum=joker.v1.Card" json:"dead,omitempty"`
+	Iterations int32     `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Seed       *int64    `protobuf:"varint,5,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+}
+
+func (x *EquityRequest) Reset() {
+	*x = EquityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EquityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityRequest) ProtoMessage() {}
+
+func (x *EquityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityRequest.ProtoReflect.Descriptor instead.
+func (*EquityRequest) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EquityRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetBoard() []Card {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetDead() []Card {
+	if x != nil {
+		return x.Dead
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *EquityRequest) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+type EquityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Equity  []float64 `protobuf:"fixed64,1,rep,packed,name=equity,proto3" json:"equity,omitempty"`
+	Win     []float64 `protobuf:"fixed64,2,rep,packed,name=win,proto3" json:"win,omitempty"`
+	Tie     []float64 `protobuf:"fixed64,3,rep,packed,name=tie,proto3" json:"tie,omitempty"`
+	Samples int32     `protobuf:"varint,4,opt,name=samples,proto3" json:"samples,omitempty"`
+	Exact   bool      `protobuf:"varint,5,opt,name=exact,proto3" json:"exact,omitempty"`
+}
+
+func (x *EquityResponse) Reset() {
+	*x = EquityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EquityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityResponse) ProtoMessage() {}
+
+func (x *EquityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityResponse.ProtoReflect.Descriptor instead.
+func (*EquityResponse) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EquityResponse) GetEquity() []float64 {
+	if x != nil {
+		return x.Equity
+	}
+	return nil
+}
+
+func (x *EquityResponse) GetWin() []float64 {
+	if x != nil {
+		return x.Win
+	}
+	return nil
+}
+
+func (x *EquityResponse) GetTie() []float64 {
+	if x != nil {
+		return x.Tie
+	}
+	return nil
+}
+
+func (x *EquityResponse) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+type ListTablesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTablesRequest) Reset() {
+	*x = ListTablesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesRequest) ProtoMessage() {}
+
+func (x *ListTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesRequest.ProtoReflect.Descriptor instead.
+func (*ListTablesRequest) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{10}
+}
+
+type TableInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seats      int32  `protobuf:"varint,2,opt,name=seats,proto3" json:"seats,omitempty"`
+	Players    int32  `protobuf:"varint,3,opt,name=players,proto3" json:"players,omitempty"`
+	SmallBlind int32  `protobuf:"varint,4,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
+	BigBlind   int32  `protobuf:"varint,5,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
+	Ante       int32  `protobuf:"varint,6,opt,name=ante,proto3" json:"ante,omitempty"`
+}
+
+func (x *TableInfo) Reset() {
+	*x = TableInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableInfo) ProtoMessage() {}
+
+func (x *TableInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableInfo.ProtoReflect.Descriptor instead.
+func (*TableInfo) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TableInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TableInfo) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *TableInfo) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *TableInfo) GetSmallBlind() int32 {
+	if x != nil {
+		return x.SmallBlind
+	}
+	return 0
+}
+
+func (x *TableInfo) GetBigBlind() int32 {
+	if x != nil {
+		return x.BigBlind
+	}
+	return 0
+}
+
+func (x *TableInfo) GetAnte() int32 {
+	if x != nil {
+		return x.Ante
+	}
+	return 0
+}
+
+type ListTablesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tables []*TableInfo `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+}
+
+func (x *ListTablesResponse) Reset() {
+	*x = ListTablesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesResponse) ProtoMessage() {}
+
+func (x *ListTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesResponse.ProtoReflect.Descriptor instead.
+func (*ListTablesResponse) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTablesResponse) GetTables() []*TableInfo {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type WatchTableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *WatchTableRequest) Reset() {
+	*x = WatchTableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTableRequest) ProtoMessage() {}
+
+func (x *WatchTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTableRequest.ProtoReflect.Descriptor instead.
+func (*WatchTableRequest) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchTableRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type PlayerState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Chips  int32  `protobuf:"varint,2,opt,name=chips,proto3" json:"chips,omitempty"`
+	Bet    int32  `protobuf:"varint,3,opt,name=bet,proto3" json:"bet,omitempty"`
+	InHand bool   `protobuf:"varint,4,opt,name=in_hand,json=inHand,proto3" json:"in_hand,omitempty"`
+	Folded bool   `protobuf:"varint,5,opt,name=folded,proto3" json:"folded,omitempty"`
+	AllIn  bool   `protobuf:"varint,6,opt,name=all_in,json=allIn,proto3" json:"all_in,omitempty"`
+	Cards  []Card `protobuf:"varint,7,rep,packed,name=cards,proto3,enum=joker.v1.Card" json:"cards,omitempty"`
+	Hand   *Hand  `protobuf:"bytes,8,opt,name=hand,proto3" json:"hand,omitempty"`
+	Won    int32  `protobuf:"varint,9,opt,name=won,proto3" json:"won,omitempty"`
+}
+
+func (x *PlayerState) Reset() {
+	*x = PlayerState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerState) ProtoMessage() {}
+
+func (x *PlayerState) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerState.ProtoReflect.Descriptor instead.
+func (*PlayerState) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlayerState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayerState) GetChips() int32 {
+	if x != nil {
+		return x.Chips
+	}
+	return 0
+}
+
+func (x *PlayerState) GetBet() int32 {
+	if x != nil {
+		return x.Bet
+	}
+	return 0
+}
+
+func (x *PlayerState) GetInHand() bool {
+	if x != nil {
+		return x.InHand
+	}
+	return false
+}
+
+func (x *PlayerState) GetFolded() bool {
+	if x != nil {
+		return x.Folded
+	}
+	return false
+}
+
+func (x *PlayerState) GetAllIn() bool {
+	if x != nil {
+		return x.AllIn
+	}
+	return false
+}
+
+func (x *PlayerState) GetCards() []Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *PlayerState) GetHand() *Hand {
+	if x != nil {
+		return x.Hand
+	}
+	return nil
+}
+
+func (x *PlayerState) GetWon() int32 {
+	if x != nil {
+		return x.Won
+	}
+	return 0
+}
+
+type Pot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount  int32   `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Seats   []int32 `protobuf:"varint,2,rep,packed,name=seats,proto3" json:"seats,omitempty"`
+	Winners []int32 `protobuf:"varint,3,rep,packed,name=winners,proto3" json:"winners,omitempty"`
+}
+
+func (x *Pot) Reset() {
+	*x = Pot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pot) ProtoMessage() {}
+
+func (x *Pot) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pot.ProtoReflect.Descriptor instead.
+func (*Pot) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Pot) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Pot) GetSeats() []int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *Pot) GetWinners() []int32 {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+// TableState mirrors table.View for an observer.
+type TableState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table      string         `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Hand       int32          `protobuf:"varint,2,opt,name=hand,proto3" json:"hand,omitempty"`
+	Active     bool           `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Round      string         `protobuf:"bytes,4,opt,name=round,proto3" json:"round,omitempty"`
+	Button     int32          `protobuf:"varint,5,opt,name=button,proto3" json:"button,omitempty"`
+	SmallBlind int32          `protobuf:"varint,6,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
+	BigBlind   int32          `protobuf:"varint,7,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
+	Ante       int32          `protobuf:"varint,8,opt,name=ante,proto3" json:"ante,omitempty"`
+	Board      []Card         `protobuf:"varint,9,rep,packed,name=board,proto3,enum=joker.v1.Card" json:"board,omitempty"`
+	Pot        int32          `protobuf:"varint,10,opt,name=pot,proto3" json:"pot,omitempty"`
+	ToAct      int32          `protobuf:"varint,11,opt,name=to_act,json=toAct,proto3" json:"to_act,omitempty"`
+	Players    []*PlayerState `protobuf:"bytes,12,rep,name=players,proto3" json:"players,omitempty"`
+	Pots       []*Pot         `protobuf:"bytes,13,rep,name=pots,proto3" json:"pots,omitempty"`
+}
+
+func (x *TableState) Reset() {
+	*x = TableState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_joker_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableState) ProtoMessage() {}
+
+func (x *TableState) ProtoReflect() protoreflect.Message {
+	mi := &file_joker_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableState.ProtoReflect.Descriptor instead.
+func (*TableState) Descriptor() ([]byte, []int) {
+	return file_joker_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TableState) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *TableState) GetHand() int32 {
+	if x != nil {
+		return x.Hand
+	}
+	return 0
+}
+
+func (x *TableState) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TableState) GetRound() string {
+	if x != nil {
+		return x.Round
+	}
+	return ""
+}
+
+func (x *TableState) GetButton() int32 {
+	if x != nil {
+		return x.Button
+	}
+	return 0
+}
+
+func (x *TableState) GetSmallBlind() int32 {
+	if x != nil {
+		return x.SmallBlind
+	}
+	return 0
+}
+
+func (x *TableState) GetBigBlind() int32 {
+	if x != nil {
+		return x.BigBlind
+	}
+	return 0
+}
+
+func (x *TableState) GetAnte() int32 {
+	if x != nil {
+		return x.Ante
+	}
+	return 0
+}
+
+func (x *TableState) GetBoard() []Card {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *TableState) GetPot() int32 {
+	if x != nil {
+		return x.Pot
+	}
+	return 0
+}
+
+func (x *TableState) GetToAct() int32 {
+	if x != nil {
+		return x.ToAct
+	}
+	return 0
+}
+
+func (x *TableState) GetPlayers() []*PlayerState {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *TableState) GetPots() []*Pot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+var File_joker_proto protoreflect.FileDescriptor
+
+var file_joker_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6a,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0xa5, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x29, 0x0a, 0x10, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x53, 0x74, 0x72, 0x61, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x73, 0x5f, 0x6c, 0x6f, 0x77, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x65, 0x49, 0x73, 0x4c, 0x6f, 0x77, 0x22,
+	0x7b, 0x0a, 0x04, 0x48, 0x61, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x07, 0x72, 0x61, 0x6e, 0x6b, 0x69,
+	0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x72, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x72, 0x64, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x05,
+	0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x22, 0x61, 0x0a, 0x0f, 0x45,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24,
+	0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x36,
+	0x0a, 0x10, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64,
+	0x52, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x22, 0x61, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x68, 0x61, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x52, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x12,
+	0x28, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x51, 0x0a, 0x0f, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x05,
+	0x68, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6a, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x52, 0x05, 0x68, 0x61, 0x6e,
+	0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x51, 0x0a, 0x06,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x48, 0x00, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12,
+	0x16, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x22,
+	0xc7, 0x01, 0x0a, 0x0d, 0x45, 0x71, 0x75, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a,
+	0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x64, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72,
+	0x64, 0x52, 0x04, 0x64, 0x65, 0x61, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x88, 0x01, 0x01,
+	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x22, 0x7c, 0x0a, 0x0e, 0x45, 0x71, 0x75,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x71, 0x75, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x65, 0x71, 0x75,
+	0x69, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x03, 0x77, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x69, 0x65, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x03, 0x74, 0x69, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a,
+	0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x69, 0x67, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x62, 0x69, 0x67, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6e, 0x74, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x22, 0x41, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22,
+	0x29, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xed, 0x01, 0x0a, 0x0b, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x68, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x68, 0x69, 0x70, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x62, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x68, 0x61, 0x6e,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x48, 0x61, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x6c, 0x6c, 0x5f, 0x69,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x49, 0x6e, 0x12, 0x24,
+	0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61,
+	0x6e, 0x64, 0x52, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6f, 0x6e, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x77, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x03, 0x50, 0x6f,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x61,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x07, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x22, 0xf1, 0x02, 0x0a, 0x0a, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68, 0x61,
+	0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67,
+	0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x62, 0x69,
+	0x67, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70,
+	0x6f, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x41, 0x63, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x04, 0x70, 0x6f,
+	0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x74, 0x73, 0x2a, 0xa1, 0x09,
+	0x0a, 0x04, 0x43, 0x61, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
+	0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10,
+	0x01, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f,
+	0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x46, 0x4f, 0x55, 0x52, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x03, 0x12, 0x14,
+	0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x46, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x50, 0x41, 0x44,
+	0x45, 0x53, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x49, 0x58,
+	0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52,
+	0x44, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x4e, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x06,
+	0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x53,
+	0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f,
+	0x4e, 0x49, 0x4e, 0x45, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x08, 0x12, 0x13, 0x0a,
+	0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x45, 0x4e, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53,
+	0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4a, 0x41, 0x43, 0x4b, 0x5f,
+	0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x0a, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x45, 0x4e, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x0b, 0x12,
+	0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x50, 0x41,
+	0x44, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x41, 0x43,
+	0x45, 0x5f, 0x53, 0x50, 0x41, 0x44, 0x45, 0x53, 0x10, 0x0d, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41,
+	0x52, 0x44, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x0e, 0x12,
+	0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x48, 0x45,
+	0x41, 0x52, 0x54, 0x53, 0x10, 0x0f, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x46,
+	0x4f, 0x55, 0x52, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10,
+	0x43, 0x41, 0x52, 0x44, 0x5f, 0x46, 0x49, 0x56, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53,
+	0x10, 0x11, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x49, 0x58, 0x5f, 0x48,
+	0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x12, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f,
+	0x53, 0x45, 0x56, 0x45, 0x4e, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x13, 0x12, 0x15,
+	0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x48, 0x45, 0x41,
+	0x52, 0x54, 0x53, 0x10, 0x14, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4e, 0x49,
+	0x4e, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x15, 0x12, 0x13, 0x0a, 0x0f, 0x43,
+	0x41, 0x52, 0x44, 0x5f, 0x54, 0x45, 0x4e, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x16,
+	0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4a, 0x41, 0x43, 0x4b, 0x5f, 0x48, 0x45,
+	0x41, 0x52, 0x54, 0x53, 0x10, 0x17, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x51,
+	0x55, 0x45, 0x45, 0x4e, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x18, 0x12, 0x14, 0x0a,
+	0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x48, 0x45, 0x41, 0x52, 0x54,
+	0x53, 0x10, 0x19, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x41, 0x43, 0x45, 0x5f,
+	0x48, 0x45, 0x41, 0x52, 0x54, 0x53, 0x10, 0x1a, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x1b, 0x12,
+	0x17, 0x0a, 0x13, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x44, 0x49,
+	0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x1c, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x46, 0x4f, 0x55, 0x52, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x1d,
+	0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x46, 0x49, 0x56, 0x45, 0x5f, 0x44, 0x49,
+	0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x1e, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x53, 0x49, 0x58, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x1f, 0x12,
+	0x17, 0x0a, 0x13, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x4e, 0x5f, 0x44, 0x49,
+	0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x20, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10,
+	0x21, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4e, 0x49, 0x4e, 0x45, 0x5f, 0x44,
+	0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x22, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52,
+	0x44, 0x5f, 0x54, 0x45, 0x4e, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x23,
+	0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4a, 0x41, 0x43, 0x4b, 0x5f, 0x44, 0x49,
+	0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x24, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x45, 0x4e, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10,
+	0x25, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x44,
+	0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x26, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x52,
+	0x44, 0x5f, 0x41, 0x43, 0x45, 0x5f, 0x44, 0x49, 0x41, 0x4d, 0x4f, 0x4e, 0x44, 0x53, 0x10, 0x27,
+	0x12, 0x12, 0x0a, 0x0e, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x43, 0x4c, 0x55,
+	0x42, 0x53, 0x10, 0x28, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x54, 0x48, 0x52,
+	0x45, 0x45, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x29, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41,
+	0x52, 0x44, 0x5f, 0x46, 0x4f, 0x55, 0x52, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x2a, 0x12,
+	0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x46, 0x49, 0x56, 0x45, 0x5f, 0x43, 0x4c, 0x55,
+	0x42, 0x53, 0x10, 0x2b, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x49, 0x58,
+	0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x2c, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x53, 0x45, 0x56, 0x45, 0x4e, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x2d, 0x12, 0x14,
+	0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x43, 0x4c, 0x55,
+	0x42, 0x53, 0x10, 0x2e, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4e, 0x49, 0x4e,
+	0x45, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x2f, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x41, 0x52,
+	0x44, 0x5f, 0x54, 0x45, 0x4e, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x30, 0x12, 0x13, 0x0a,
+	0x0f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4a, 0x41, 0x43, 0x4b, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53,
+	0x10, 0x31, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x45, 0x4e,
+	0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x32, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x52, 0x44,
+	0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10, 0x33, 0x12, 0x12, 0x0a,
+	0x0e, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x41, 0x43, 0x45, 0x5f, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x10,
+	0x34, 0x2a, 0x90, 0x02, 0x0a, 0x07, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x17, 0x0a,
+	0x13, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e,
+	0x47, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x10, 0x02, 0x12,
+	0x14, 0x0a, 0x10, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x54, 0x57, 0x4f, 0x5f, 0x50,
+	0x41, 0x49, 0x52, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47,
+	0x5f, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4f, 0x46, 0x5f, 0x41, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54,
+	0x52, 0x41, 0x49, 0x47, 0x48, 0x54, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x41, 0x4e, 0x4b,
+	0x49, 0x4e, 0x47, 0x5f, 0x46, 0x4c, 0x55, 0x53, 0x48, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x52,
+	0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x5f, 0x48, 0x4f, 0x55, 0x53,
+	0x45, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x46,
+	0x4f, 0x55, 0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x41, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x10, 0x08, 0x12,
+	0x1a, 0x0a, 0x16, 0x52, 0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x49,
+	0x47, 0x48, 0x54, 0x5f, 0x46, 0x4c, 0x55, 0x53, 0x48, 0x10, 0x09, 0x12, 0x17, 0x0a, 0x13, 0x52,
+	0x41, 0x4e, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x52, 0x4f, 0x59, 0x41, 0x4c, 0x5f, 0x46, 0x4c, 0x55,
+	0x53, 0x48, 0x10, 0x0a, 0x2a, 0x45, 0x0a, 0x07, 0x53, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x17, 0x0a, 0x13, 0x53, 0x4f, 0x52, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x4f, 0x52, 0x54,
+	0x49, 0x4e, 0x47, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x4f,
+	0x52, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x32, 0xd3, 0x02, 0x0a, 0x05,
+	0x4a, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x65, 0x12, 0x19, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6a,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x45, 0x71, 0x75, 0x69,
+	0x74, 0x79, 0x12, 0x17, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71,
+	0x75, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6a, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41,
+	0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x6a,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6a, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x30,
+	0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6e, 0x6f, 0x74, 0x6e, 0x69, 0x6c, 0x2f, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x63, 0x6d, 0x64,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_joker_proto_rawDescOnce sync.Once
+	file_joker_proto_rawDescData = file_joker_proto_rawDesc
+)
+
+func file_joker_proto_rawDescGZIP() []byte {
+	file_joker_proto_rawDescOnce.Do(func() {
+		file_joker_proto_rawDescData = protoimpl.X.CompressGZIP(file_joker_proto_rawDescData)
+	})
+	return file_joker_proto_rawDescData
+}
+
+var file_joker_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_joker_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_joker_proto_goTypes = []interface{}{
+	(Card)(0),                  // 0: joker.v1.Card
+	(Ranking)(0),               // 1: joker.v1.Ranking
+	(Sorting)(0),               // 2: joker.v1.Sorting
+	(*Config)(nil),             // 3: joker.v1.Config
+	(*Hand)(nil),               // 4: joker.v1.Hand
+	(*Cards)(nil),              // 5: joker.v1.Cards
+	(*EvaluateRequest)(nil),    // 6: joker.v1.EvaluateRequest
+	(*EvaluateResponse)(nil),   // 7: joker.v1.EvaluateResponse
+	(*CompareRequest)(nil),     // 8: joker.v1.CompareRequest
+	(*CompareResponse)(nil),    // 9: joker.v1.CompareResponse
+	(*Player)(nil),             // 10: joker.v1.Player
+	(*EquityRequest)(nil),      // 11: joker.v1.EquityRequest
+	(*EquityResponse)(nil),     // 12: joker.v1.EquityResponse
+	(*ListTablesRequest)(nil),  // 13: joker.v1.ListTablesRequest
+	(*TableInfo)(nil),          // 14: joker.v1.TableInfo
+	(*ListTablesResponse)(nil), // 15: joker.v1.ListTablesResponse
+	(*WatchTableRequest)(nil),  // 16: joker.v1.WatchTableRequest
+	(*PlayerState)(nil),        // 17: joker.v1.PlayerState
+	(*Pot)(nil),                // 18: joker.v1.Pot
+	(*TableState)(nil),         // 19: joker.v1.TableState
+}
+var file_joker_proto_depIdxs = []int32{
+	2,  // 0: joker.v1.Config.sorting:type_name -> joker.v1.Sorting
+	1,  // 1: joker.v1.Hand.ranking:type_name -> joker.v1.Ranking
+	0,  // 2: joker.v1.Hand.cards:type_name -> joker.v1.Card
+	0,  // 3: joker.v1.Cards.cards:type_name -> joker.v1.Card
+	0,  // 4: joker.v1.EvaluateRequest.cards:type_name -> joker.v1.Card
+	3,  // 5: joker.v1.EvaluateRequest.config:type_name -> joker.v1.Config
+	4,  // 6: joker.v1.EvaluateResponse.hand:type_name -> joker.v1.Hand
+	5,  // 7: joker.v1.CompareRequest.hands:type_name -> joker.v1.Cards
+	3,  // 8: joker.v1.CompareRequest.config:type_name -> joker.v1.Config
+	4,  // 9: joker.v1.CompareResponse.hands:type_name -> joker.v1.Hand
+	5,  // 10: joker.v1.Player.cards:type_name -> joker.v1.Cards
+	10, // 11: joker.v1.EquityRequest.players:type_name -> joker.v1.Player
+	0,  // 12: joker.v1.EquityRequest.board:type_name -> joker.v1.Card
+	0,  // 13: joker.v1.EquityRequest.dead:type_name -> joker.v1.Card
+	14, // 14: joker.v1.ListTablesResponse.tables:type_name -> joker.v1.TableInfo
+	0,  // 15: joker.v1.PlayerState.cards:type_name -> joker.v1.Card
+	4,  // 16: joker.v1.PlayerState.hand:type_name -> joker.v1.Hand
+	0,  // 17: joker.v1.TableState.board:type_name -> joker.v1.Card
+	17, // 18: joker.v1.TableState.players:type_name -> joker.v1.PlayerState
+	18, // 19: joker.v1.TableState.pots:type_name -> joker.v1.Pot
+	6,  // 20: joker.v1.Joker.Evaluate:input_type -> joker.v1.EvaluateRequest
+	8,  // 21: joker.v1.Joker.Compare:input_type -> joker.v1.CompareRequest
+	11, // 22: joker.v1.Joker.Equity:input_type -> joker.v1.EquityRequest
+	13, // 23: joker.v1.Joker.ListTables:input_type -> joker.v1.ListTablesRequest
+	16, // 24: joker.v1.Joker.WatchTable:input_type -> joker.v1.WatchTableRequest
+	7,  // 25: joker.v1.Joker.Evaluate:output_type -> joker.v1.EvaluateResponse
+	9,  // 26: joker.v1.Joker.Compare:output_type -> joker.v1.CompareResponse
+	12, // 27: joker.v1.Joker.Equity:output_type -> joker.v1.EquityResponse
+	15, // 28: joker.v1.Joker.ListTables:output_type -> joker.v1.ListTablesResponse
+	19, // 29: joker.v1.Joker.WatchTable:output_type -> joker.v1.TableState
+	25, // [25:30] is the sub-list for method output_type
+	20, // [20:25] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_joker_proto_init() }
+func file_joker_proto_init() {
+	if File_joker_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_joker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cards); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EquityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EquityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTablesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTablesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchTableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_joker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_joker_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*Player_Cards)(nil),
+		(*Player_Range)(nil),
+	}
+	file_joker_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_joker_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_joker_proto_goTypes,
+		DependencyIndexes: file_joker_proto_depIdxs,
+		EnumInfos:         file_joker_proto_enumTypes,
+		MessageInfos:      file_joker_proto_msgTypes,
+	}.Build()
+	File_joker_proto = out.File
+	file_joker_proto_rawDesc = nil
+	file_joker_proto_goTypes = nil
+	file_joker_proto_depIdxs = nil
+}
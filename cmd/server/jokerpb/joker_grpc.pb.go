@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: joker.proto
+
+package jokerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Joker_Evaluate_FullMethodName   = "/joker.v1.Joker/Evaluate"
+	Joker_Compare_FullMethodName    = "/joker.v1.Joker/Compare"
+	Joker_Equity_FullMethodName     = "/joker.v1.Joker/Equity"
+	Joker_ListTables_FullMethodName = "/joker.v1.Joker/ListTables"
+	Joker_WatchTable_FullMethodName = "/joker.v1.Joker/WatchTable"
+)
+
+// JokerClient is the client API for Joker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JokerClient interface {
+	// Evaluate returns the best hand from one to seven cards.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Compare returns the hands, of five to seven cards each, and which of
+	// them win.
+	Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error)
+	// Equity calculates hold'em equities of hands or ranges.
+	Equity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error)
+	// ListTables lists the tables hosted by the server.
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+	// WatchTable streams the state of a table as an observer sees it,
+	// starting with the current state.  Hole cards are only included once
+	// they are shown down.
+	WatchTable(ctx context.Context, in *WatchTableRequest, opts ...grpc.CallOption) (Joker_WatchTableClient, error)
+}
+
+type jokerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJokerClient(cc grpc.ClientConnInterface) JokerClient {
+	return &jokerClient{cc}
+}
+
+func (c *jokerClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, Joker_Evaluate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jokerClient) Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error) {
+	out := new(CompareResponse)
+	err := c.cc.Invoke(ctx, Joker_Compare_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jokerClient) Equity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error) {
+	out := new(EquityResponse)
+	err := c.cc.Invoke(ctx, Joker_Equity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jokerClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, Joker_ListTables_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jokerClient) WatchTable(ctx context.Context, in *WatchTableRequest, opts ...grpc.CallOption) (Joker_WatchTableClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Joker_ServiceDesc.Streams[0], Joker_WatchTable_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jokerWatchTableClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Joker_WatchTableClient interface {
+	Recv() (*TableState, error)
+	grpc.ClientStream
+}
+
+type jokerWatchTableClient struct {
+	grpc.ClientStream
+}
+
+func (x *jokerWatchTableClient) Recv() (*TableState, error) {
+	m := new(TableState)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// JokerServer is the server API for Joker service.
+// All implementations must embed UnimplementedJokerServer
+// for forward compatibility
+type JokerServer interface {
+	// Evaluate returns the best hand from one to seven cards.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Compare returns the hands, of five to seven cards each, and which of
+	// them win.
+	Compare(context.Context, *CompareRequest) (*CompareResponse, error)
+	// Equity calculates hold'em equities of hands or ranges.
+	Equity(context.Context, *EquityRequest) (*EquityResponse, error)
+	// ListTables lists the tables hosted by the server.
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	// WatchTable streams the state of a table as an observer sees it,
+	// starting with the current state.  Hole cards are only included once
+	// they are shown down.
+	WatchTable(*WatchTableRequest, Joker_WatchTableServer) error
+	mustEmbedUnimplementedJokerServer()
+}
+
+// UnimplementedJokerServer must be embedded to have forward compatible implementations.
+type UnimplementedJokerServer struct {
+}
+
+func (UnimplementedJokerServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedJokerServer) Compare(context.Context, *CompareRequest) (*CompareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compare not implemented")
+}
+func (UnimplementedJokerServer) Equity(context.Context, *EquityRequest) (*EquityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Equity not implemented")
+}
+func (UnimplementedJokerServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedJokerServer) WatchTable(*WatchTableRequest, Joker_WatchTableServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTable not implemented")
+}
+func (UnimplementedJokerServer) mustEmbedUnimplementedJokerServer() {}
+
+// UnsafeJokerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JokerServer will
+// result in compilation errors.
+type UnsafeJokerServer interface {
+	mustEmbedUnimplementedJokerServer()
+}
+
+func RegisterJokerServer(s grpc.ServiceRegistrar, srv JokerServer) {
+	s.RegisterService(&Joker_ServiceDesc, srv)
+}
+
+func _Joker_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JokerServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Joker_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JokerServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Joker_Compare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JokerServer).Compare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Joker_Compare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JokerServer).Compare(ctx, req.(*CompareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Joker_Equity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EquityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JokerServer).Equity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Joker_Equity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JokerServer).Equity(ctx, req.(*EquityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Joker_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JokerServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Joker_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JokerServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Joker_WatchTable_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTableRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JokerServer).WatchTable(m, &jokerWatchTableServer{stream})
+}
+
+type Joker_WatchTableServer interface {
+	Send(*TableState) error
+	grpc.ServerStream
+}
+
+type jokerWatchTableServer struct {
+	grpc.ServerStream
+}
+
+func (x *jokerWatchTableServer) Send(m *TableState) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Joker_ServiceDesc is the grpc.ServiceDesc for Joker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Joker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "joker.v1.Joker",
+	HandlerType: (*JokerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _Joker_Evaluate_Handler,
+		},
+		{
+			MethodName: "Compare",
+			Handler:    _Joker_Compare_Handler,
+		},
+		{
+			MethodName: "Equity",
+			Handler:    _Joker_Equity_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _Joker_ListTables_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTable",
+			Handler:       _Joker_WatchTable_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "joker.proto",
+}
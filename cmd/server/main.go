@@ -1,7 +1,38 @@
 package main
 
-import "net/http"
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
 
 func main() {
-	http.ListenAndServe(`:8080`, http.FileServer(http.Dir(`public`)))
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dir := flag.String("public", "", "directory of static files to serve instead of the embedded ones")
+	grpcAddr := flag.String("grpc", ":9090", "address to serve gRPC on")
+	timeout := flag.Duration("timeout", 30*time.Second, "time players have to act")
+	session := flag.Duration("session", 5*time.Minute, "time disconnected players have to reconnect")
+	flag.Parse()
+	files, err := static(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := NewServer(nil)
+	s.ActionTimeout = *timeout
+	s.SessionTimeout = *session
+	s.HandDelay = 2 * time.Second
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		log.Fatal(NewGRPCServer(s).Serve(lis))
+	}()
+	mux := http.NewServeMux()
+	mux.Handle("/ws", s)
+	mux.Handle("/v1/", NewAPI())
+	mux.Handle("/", files)
+	log.Fatal(http.ListenAndServe(*addr, mux))
 }
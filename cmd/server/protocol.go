@@ -0,0 +1,32 @@
+package main
+
+import "github.com/notnil/joker/pkg/table"
+
+// Message is a message between the server and a client.  The fields used
+// depend on the type and are documented in the package comment.
+type Message struct {
+	Type       string        `json:"type"`
+	Name       string        `json:"name,omitempty"`
+	Token      string        `json:"token,omitempty"`
+	Table      string        `json:"table,omitempty"`
+	Seats      int           `json:"seats,omitempty"`
+	SmallBlind int           `json:"smallBlind,omitempty"`
+	BigBlind   int           `json:"bigBlind,omitempty"`
+	Ante       int           `json:"ante,omitempty"`
+	Seat       int           `json:"seat,omitempty"`
+	Chips      int           `json:"chips,omitempty"`
+	Action     *table.Action `json:"action,omitempty"`
+	Tables     []TableInfo   `json:"tables,omitempty"`
+	View       *table.View   `json:"view,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// TableInfo describes a table in a list of tables.
+type TableInfo struct {
+	ID         string `json:"id"`
+	Seats      int    `json:"seats"`
+	Players    int    `json:"players"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	Ante       int    `json:"ante,omitempty"`
+}
@@ -0,0 +1,319 @@
+// The odds calculator keeps the cards of each player and the board, and
+// recalculates equity with the wasm build whenever they change.  Equity is
+// sampled in small batches so the bars fill in as results arrive.
+
+const ranks = "AKQJT98765432";
+const suits = ["♠", "♥", "♦", "♣"];
+const configs = {
+	high: { sorting: 1, ignoreStraights: false, ignoreFlushes: false, aceIsLow: false },
+	low: { sorting: 2, ignoreStraights: false, ignoreFlushes: false, aceIsLow: false },
+	aceToFive: { sorting: 2, ignoreStraights: true, ignoreFlushes: true, aceIsLow: true },
+};
+const maxSamples = 20000;
+
+const state = {
+	game: "holdem",
+	rules: "high",
+	players: [],
+	board: [null, null, null, null, null],
+	selected: { player: 0, index: 0 },
+	ready: false,
+	generation: 0,
+	shown: [],
+};
+
+function holeCards() {
+	return state.game === "omaha" ? 4 : 2;
+}
+
+function newPlayer() {
+	return new Array(holeCards()).fill(null);
+}
+
+// slots returns the card slots in the order they are filled.
+function slots() {
+	const all = [];
+	state.players.forEach((p, i) => p.forEach((_, j) => all.push({ player: i, index: j })));
+	state.board.forEach((_, j) => all.push({ player: -1, index: j }));
+	return all;
+}
+
+function cardsOf(slot) {
+	return slot.player === -1 ? state.board : state.players[slot.player];
+}
+
+function sameSlot(a, b) {
+	return a !== null && b !== null && a.player === b.player && a.index === b.index;
+}
+
+function used() {
+	return new Set([...state.players.flat(), ...state.board].filter((c) => c !== null));
+}
+
+function pick(card) {
+	if (used().has(card)) {
+		remove(card);
+		return;
+	}
+	if (state.selected === null) {
+		return;
+	}
+	cardsOf(state.selected)[state.selected.index] = card;
+	// move on to the next empty slot
+	const all = slots();
+	const start = all.findIndex((s) => sameSlot(s, state.selected));
+	state.selected = null;
+	for (let k = 1; k <= all.length; k++) {
+		const s = all[(start + k) % all.length];
+		if (cardsOf(s)[s.index] === null) {
+			state.selected = s;
+			break;
+		}
+	}
+	changed();
+}
+
+function remove(card) {
+	for (const s of slots()) {
+		if (cardsOf(s)[s.index] === card) {
+			cardsOf(s)[s.index] = null;
+			state.selected = s;
+		}
+	}
+	changed();
+}
+
+function select(slot) {
+	const card = cardsOf(slot)[slot.index];
+	if (card !== null) {
+		remove(card);
+		return;
+	}
+	state.selected = slot;
+	render();
+}
+
+function cardElement(card, className) {
+	const el = document.createElement("span");
+	el.className = className;
+	if (card !== null) {
+		el.textContent = card;
+		if (card.endsWith("♥") || card.endsWith("♦")) {
+			el.classList.add("red");
+		}
+	}
+	return el;
+}
+
+function slotElement(slot) {
+	const card = cardsOf(slot)[slot.index];
+	const el = cardElement(card, card === null ? "slot" : "card");
+	if (card === null) {
+		el.textContent = "+";
+	}
+	if (sameSlot(slot, state.selected)) {
+		el.classList.add("selected");
+	}
+	el.onclick = () => select(slot);
+	return el;
+}
+
+function render() {
+	const board = document.getElementById("board");
+	board.replaceChildren(...state.board.map((_, j) => slotElement({ player: -1, index: j })));
+
+	const players = document.getElementById("players");
+	players.replaceChildren(...state.players.map((p, i) => {
+		const row = document.createElement("div");
+		row.className = "player";
+		row.id = "player" + i;
+		const name = document.createElement("div");
+		name.innerHTML = "Player " + (i + 1) + '<div class="description"></div>';
+		const cards = document.createElement("div");
+		cards.className = "slots";
+		cards.append(...p.map((_, j) => slotElement({ player: i, index: j })));
+		const bar = document.createElement("div");
+		bar.className = "bar";
+		bar.innerHTML = '<div class="win"></div><div class="tie"></div><span></span>';
+		row.append(name, cards, bar);
+		return row;
+	}));
+	state.players.forEach((_, i) => showEquity(i, ...(state.shown[i] || [null])));
+
+	const taken = used();
+	const deck = document.getElementById("deck");
+	deck.replaceChildren(...suits.map((suit) => {
+		const row = document.createElement("div");
+		for (const rank of ranks) {
+			const card = rank + suit;
+			const el = cardElement(card, "card");
+			if (taken.has(card)) {
+				el.classList.add("used");
+			}
+			el.onclick = () => pick(card);
+			row.append(el);
+		}
+		return row;
+	}));
+	if (state.ready) {
+		describe();
+	}
+}
+
+function describe() {
+	const config = configs[state.rules];
+	const board = state.board.filter((c) => c !== null);
+	state.players.forEach((p, i) => {
+		const el = document.querySelector("#player" + i + " .description");
+		if (p.includes(null)) {
+			el.textContent = "";
+			return;
+		}
+		let h;
+		if (state.game === "omaha") {
+			h = board.length >= 3 ? joker.evaluateOmaha(p, board, config) : null;
+		} else {
+			h = joker.evaluate([...p, ...board], config);
+		}
+		el.textContent = h === null || h instanceof Error ? "" : h.description;
+	});
+}
+
+function showEquity(i, equity, win, tie) {
+	state.shown[i] = [equity, win, tie];
+	const bar = document.querySelector("#player" + i + " .bar");
+	if (equity === null) {
+		bar.querySelector(".win").style.width = "0";
+		bar.querySelector(".tie").style.width = "0";
+		bar.querySelector("span").textContent = "";
+		return;
+	}
+	const pct = (x) => (100 * x).toFixed(1) + "%";
+	bar.querySelector(".win").style.width = pct(win);
+	bar.querySelector(".tie").style.left = pct(win);
+	bar.querySelector(".tie").style.width = pct(tie);
+	bar.querySelector("span").textContent = pct(equity) + " (win " + pct(win) + ", tie " + pct(tie) + ")";
+}
+
+function status(text) {
+	document.getElementById("status").textContent = text;
+}
+
+// calculate runs batches of samples until the cards change, the result
+// is exact, or enough samples have been taken.
+async function calculate() {
+	const generation = ++state.generation;
+	const board = state.board.filter((c) => c !== null);
+	const hands = [];
+	const seats = [];
+	state.players.forEach((p, i) => {
+		showEquity(i, null);
+		if (!p.includes(null)) {
+			hands.push(p);
+			seats.push(i);
+		}
+	});
+	if (hands.length < 2) {
+		status("Give at least two players all their cards to see their equity.");
+		return;
+	}
+	const options = {
+		iterations: state.game === "omaha" ? 25 : 100,
+		exactLimit: 50,
+		config: configs[state.rules],
+		omaha: state.game === "omaha",
+	};
+	const totals = { equity: hands.map(() => 0), win: hands.map(() => 0), tie: hands.map(() => 0) };
+	let samples = 0;
+	while (samples < maxSamples) {
+		// let the page handle input before the next batch
+		await new Promise((resolve) => setTimeout(resolve, 0));
+		if (generation !== state.generation) {
+			return;
+		}
+		let res;
+		try {
+			res = await joker.equity(hands, board, options);
+		} catch (e) {
+			status(e.message);
+			return;
+		}
+		if (generation !== state.generation) {
+			return;
+		}
+		for (const k of ["equity", "win", "tie"]) {
+			res[k].forEach((x, j) => (totals[k][j] += x * res.samples));
+		}
+		samples += res.samples;
+		seats.forEach((i, j) => showEquity(i, totals.equity[j] / samples, totals.win[j] / samples, totals.tie[j] / samples));
+		if (res.exact) {
+			status("Exact over " + res.samples.toLocaleString() + " runouts.");
+			return;
+		}
+		status(samples.toLocaleString() + " samples");
+	}
+}
+
+function changed() {
+	render();
+	if (state.ready) {
+		calculate();
+	}
+}
+
+function reset() {
+	state.players = [newPlayer(), newPlayer()];
+	state.board = [null, null, null, null, null];
+	state.selected = { player: 0, index: 0 };
+	changed();
+}
+
+document.getElementById("game").onchange = (e) => {
+	state.game = e.target.value;
+	const n = state.players.length;
+	reset();
+	while (state.players.length < n) {
+		state.players.push(newPlayer());
+	}
+	changed();
+};
+
+document.getElementById("rules").onchange = (e) => {
+	state.rules = e.target.value;
+	changed();
+};
+
+document.getElementById("add").onclick = () => {
+	if (state.players.length < 10) {
+		state.players.push(newPlayer());
+		changed();
+	}
+};
+
+document.getElementById("remove").onclick = () => {
+	if (state.players.length > 2) {
+		state.players.pop();
+		if (state.selected !== null && state.selected.player >= state.players.length) {
+			state.selected = null;
+		}
+		changed();
+	}
+};
+
+document.getElementById("clear").onclick = reset;
+
+reset();
+
+if (!WebAssembly.instantiateStreaming) { // polyfill
+	WebAssembly.instantiateStreaming = async (resp, importObject) => {
+		const source = await (await resp).arrayBuffer();
+		return await WebAssembly.instantiate(source, importObject);
+	};
+}
+
+const go = new Go();
+WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject).then((result) => {
+	go.run(result.instance);
+	state.ready = true;
+	changed();
+});
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Limits on the tables clients create.
+const (
+	maxSeats  = 10
+	maxTables = 5 // per session
+)
+
+// Server hosts games for WebSocket clients.
+type Server struct {
+	// HandDelay is the pause between hands.
+	HandDelay time.Duration
+
+	// ActionTimeout is how long players have to act, or zero for no
+	// limit.
+	ActionTimeout time.Duration
+
+	// SessionTimeout is how long the session of a disconnected client
+	// is kept for it to resume, or zero for no limit.  When it expires
+	// the player stands up and stops watching their tables.
+	SessionTimeout time.Duration
+
+	mu       sync.Mutex
+	dealer   hand.Dealer
+	games    map[string]*game
+	sessions map[string]*session
+	upgrader websocket.Upgrader
+}
+
+type game struct {
+	id       string
+	table    *table.Table
+	watchers map[*session]bool
+	seats    map[*session]int
+	dealing  bool
+	timer    *time.Timer
+}
+
+type session struct {
+	name    string
+	token   string
+	conn    *conn
+	games   map[string]bool
+	expire  *time.Timer
+	created int
+}
+
+// conn is a connection to a client.  Messages queued on send are written
+// to the client and close ends the connection.
+type conn struct {
+	send  chan Message
+	close func()
+}
+
+// NewServer returns a server that shuffles with the dealer, or with a
+// dealer seeded from the time if it is nil.
+func NewServer(d hand.Dealer) *Server {
+	return &Server{
+		dealer:   d,
+		games:    map[string]*game{},
+		sessions: map[string]*session{},
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the
+// client until it disconnects.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &conn{send: make(chan Message, 64), close: func() { ws.Close() }}
+	go write(ws, c.send)
+	defer close(c.send)
+
+	var hello Message
+	if err := ws.ReadJSON(&hello); err != nil || hello.Type != "hello" {
+		c.send <- Message{Type: "error", Error: "server: expected hello"}
+		return
+	}
+	sess, err := s.connect(c, hello)
+	if err != nil {
+		c.send <- Message{Type: "error", Error: err.Error()}
+		return
+	}
+	defer s.disconnect(sess, c)
+	for {
+		var m Message
+		if err := ws.ReadJSON(&m); err != nil {
+			return
+		}
+		if err := s.handle(sess, m); err != nil {
+			s.mu.Lock()
+			sess.deliver(Message{Type: "error", Error: err.Error()})
+			s.mu.Unlock()
+		}
+	}
+}
+
+func write(ws *websocket.Conn, send chan Message) {
+	for m := range send {
+		if err := ws.WriteJSON(m); err != nil {
+			break
+		}
+	}
+	ws.Close()
+	for range send {
+	}
+}
+
+// connect starts a session or resumes the one with the hello's token.
+func (s *Server) connect(c *conn, hello Message) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sess *session
+	if hello.Token != "" {
+		sess = s.sessions[hello.Token]
+		if sess == nil {
+			return nil, errors.New("server: unknown token")
+		}
+		if sess.conn != nil {
+			// the old connection is replaced
+			sess.conn.close()
+		}
+		if sess.expire != nil {
+			sess.expire.Stop()
+			sess.expire = nil
+		}
+	} else {
+		if hello.Name == "" {
+			return nil, errors.New("server: missing name")
+		}
+		sess = &session{name: hello.Name, token: newToken(), games: map[string]bool{}}
+		s.sessions[sess.token] = sess
+	}
+	sess.conn = c
+	sess.deliver(Message{Type: "welcome", Name: sess.name, Token: sess.token})
+	var ids []string
+	for id := range sess.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		sess.deliver(s.games[id].state(sess))
+	}
+	return sess, nil
+}
+
+// disconnect detaches the connection from its session.  A session that
+// isn't at any table is removed, and otherwise it keeps its seats until
+// the session timeout so that the client can reconnect.
+func (s *Server) disconnect(sess *session, c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sess.conn != c && sess.conn != nil {
+		// the client has already reconnected
+		return
+	}
+	sess.conn = nil
+	if len(sess.games) == 0 {
+		delete(s.sessions, sess.token)
+		return
+	}
+	if s.SessionTimeout > 0 && sess.expire == nil {
+		sess.expire = time.AfterFunc(s.SessionTimeout, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if sess.conn == nil {
+				s.remove(sess)
+			}
+		})
+	}
+}
+
+// remove stands the session up from its seats, stops it watching its
+// tables, and forgets its token.
+func (s *Server) remove(sess *session) {
+	for id := range sess.games {
+		g := s.games[id]
+		if _, ok := g.seats[sess]; ok {
+			// the seat is gone even if the table has already freed it
+			s.stand(g, sess)
+			delete(g.seats, sess)
+		}
+		delete(g.watchers, sess)
+		s.update(g)
+	}
+	sess.games = map[string]bool{}
+	sess.expire = nil
+	delete(s.sessions, sess.token)
+}
+
+func (s *Server) handle(sess *session, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m.Type == "list" {
+		sess.deliver(Message{Type: "tables", Tables: s.list()})
+		return nil
+	}
+	if m.Type == "create" {
+		if _, ok := s.games[m.Table]; ok || m.Table == "" {
+			return errors.New("server: invalid or duplicate table id")
+		}
+		if m.Seats < 2 || m.Seats > maxSeats {
+			return errors.New("server: a table needs two to 10 seats")
+		}
+		if sess.created >= maxTables {
+			return errors.New("server: too many tables created")
+		}
+		options := []func(*table.Config){table.Stakes(m.SmallBlind, m.BigBlind), table.Ante(m.Ante)}
+		if s.dealer != nil {
+			options = append(options, table.WithDealer(s.dealer))
+		}
+		t, err := table.New(m.Seats, options...)
+		if err != nil {
+			return err
+		}
+		s.games[m.Table] = &game{id: m.Table, table: t, watchers: map[*session]bool{}, seats: map[*session]int{}}
+		sess.created++
+		m.Type = "join"
+	}
+	g := s.games[m.Table]
+	if g == nil {
+		return errors.New("server: unknown table")
+	}
+	switch m.Type {
+	case "join":
+		g.watchers[sess] = true
+		sess.games[g.id] = true
+		sess.deliver(g.state(sess))
+		return nil
+	case "leave":
+		if _, ok := g.seats[sess]; ok {
+			if err := s.stand(g, sess); err != nil {
+				return err
+			}
+		}
+		delete(g.watchers, sess)
+		delete(sess.games, g.id)
+	case "sit":
+		if !g.watchers[sess] {
+			return errors.New("server: join the table first")
+		}
+		if _, ok := g.seats[sess]; ok {
+			return errors.New("server: already seated")
+		}
+		if err := g.table.Sit(m.Seat, sess.name, m.Chips); err != nil {
+			return err
+		}
+		g.seats[sess] = m.Seat
+	case "stand":
+		if err := s.stand(g, sess); err != nil {
+			return err
+		}
+	case "act":
+		seat, ok := g.seats[sess]
+		if !ok || m.Action == nil {
+			return errors.New("server: not seated or missing action")
+		}
+		if err := g.table.Act(seat, *m.Action); err != nil {
+			return err
+		}
+	default:
+		return errors.New("server: unknown message type")
+	}
+	s.update(g)
+	return nil
+}
+
+func (s *Server) stand(g *game, sess *session) error {
+	seat, ok := g.seats[sess]
+	if !ok {
+		return errors.New("server: not seated")
+	}
+	if err := g.table.Leave(seat); err != nil {
+		return err
+	}
+	delete(g.seats, sess)
+	return nil
+}
+
+// update broadcasts the table, deals the next hand when it can, and times
+// the player to act.
+func (s *Server) update(g *game) {
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	g.broadcast()
+	t := g.table
+	if !t.Active() && !g.dealing {
+		if s.HandDelay == 0 {
+			if t.Deal() == nil {
+				g.broadcast()
+			}
+		} else {
+			g.dealing = true
+			time.AfterFunc(s.HandDelay, func() {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				g.dealing = false
+				if t.Deal() == nil {
+					s.update(g)
+				}
+			})
+		}
+	}
+	if t.Active() && s.ActionTimeout > 0 {
+		seat, hand := t.ToAct(), t.View(-1).Hand
+		g.timer = time.AfterFunc(s.ActionTimeout, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if !t.Active() || t.ToAct() != seat || t.View(-1).Hand != hand {
+				return
+			}
+			if t.Act(seat, table.Action{Type: table.Check}) != nil {
+				t.Act(seat, table.Action{Type: table.Fold})
+			}
+			s.update(g)
+		})
+	}
+}
+
+func (s *Server) list() []TableInfo {
+	var tables []TableInfo
+	for id, g := range s.games {
+		v := g.table.View(-1)
+		info := TableInfo{ID: id, Seats: len(v.Players), SmallBlind: v.SmallBlind, BigBlind: v.BigBlind, Ante: v.Ante}
+		for _, p := range v.Players {
+			if p.Name != "" {
+				info.Players++
+			}
+		}
+		tables = append(tables, info)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].ID < tables[j].ID })
+	return tables
+}
+
+func (g *game) broadcast() {
+	for sess := range g.watchers {
+		sess.deliver(g.state(sess))
+	}
+}
+
+// state returns the table as seen by the session.
+func (g *game) state(sess *session) Message {
+	seat, ok := g.seats[sess]
+	if !ok {
+		seat = -1
+	}
+	v := g.table.View(seat)
+	return Message{Type: "state", Table: g.id, View: &v}
+}
+
+// deliver queues a message for the session's connection, dropping the
+// connection if it can't keep up.
+func (sess *session) deliver(m Message) {
+	c := sess.conn
+	if c == nil {
+		return
+	}
+	select {
+	case c.send <- m:
+	default:
+		c.close()
+		sess.conn = nil
+	}
+}
+
+// watch registers an observer of a table and returns the channel its
+// states are sent on, a channel closed if the observer falls too far
+// behind, and a function to stop watching.
+func (s *Server) watch(id string) (<-chan Message, <-chan struct{}, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := s.games[id]
+	if g == nil {
+		return nil, nil, nil, errors.New("server: unknown table")
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	c := &conn{send: make(chan Message, 64), close: func() { once.Do(func() { close(done) }) }}
+	sess := &session{conn: c, games: map[string]bool{id: true}}
+	g.watchers[sess] = true
+	sess.deliver(g.state(sess))
+	stop := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(g.watchers, sess)
+		sess.conn = nil
+	}
+	return c.send, done, stop, nil
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+type client struct {
+	t   *testing.T
+	ws  *websocket.Conn
+	raw []string
+}
+
+func dial(t *testing.T, url string, hello Message) (*client, Message) {
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{t: t, ws: ws}
+	c.send(hello)
+	welcome := c.read()
+	if welcome.Type != "welcome" {
+		t.Fatalf("got %+v; want welcome", welcome)
+	}
+	return c, welcome
+}
+
+func (c *client) send(m Message) {
+	if err := c.ws.WriteJSON(m); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) read() Message {
+	c.ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, b, err := c.ws.ReadMessage()
+	if err != nil {
+		c.t.Helper()
+		c.t.Fatal(err, c.raw)
+	}
+	c.raw = append(c.raw, string(b))
+	var m Message
+	if err := json.Unmarshal(b, &m); err != nil {
+		c.t.Fatal(err)
+	}
+	return m
+}
+
+// state reads messages until a state matching f arrives.
+func (c *client) state(f func(v *table.View) bool) *table.View {
+	for {
+		m := c.read()
+		if m.Type == "state" && f(m.View) {
+			return m.View
+		}
+	}
+}
+
+func newTestServer(t *testing.T, s *Server) string {
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+func TestGame(t *testing.T) {
+	url := newTestServer(t, NewServer(hand.NewDealer(rand.New(rand.NewSource(1)))))
+	alice, _ := dial(t, url, Message{Type: "hello", Name: "alice"})
+	alice.send(Message{Type: "create", Table: "t1", Seats: 2, SmallBlind: 1, BigBlind: 2})
+	alice.state(func(v *table.View) bool { return v.Seat == -1 })
+	bob, welcome := dial(t, url, Message{Type: "hello", Name: "bob"})
+	bob.send(Message{Type: "join", Table: "t1"})
+	bob.state(func(v *table.View) bool { return true })
+
+	alice.send(Message{Type: "sit", Table: "t1", Seat: 0, Chips: 100})
+	bob.send(Message{Type: "sit", Table: "t1", Seat: 1, Chips: 100})
+	active := func(v *table.View) bool { return v.Active }
+	av, bv := alice.state(active), bob.state(active)
+
+	// each player sees only their own hole cards
+	if len(av.Players[0].Cards) != 2 || av.Players[1].Cards != nil {
+		t.Fatalf("alice sees %+v", av.Players)
+	}
+	if len(bv.Players[1].Cards) != 2 || bv.Players[0].Cards != nil {
+		t.Fatalf("bob sees %+v", bv.Players)
+	}
+	for _, raw := range alice.raw {
+		for _, c := range bv.Players[1].Cards {
+			if strings.Contains(raw, c.String()) {
+				t.Fatalf("alice was sent bob's card %v in %s", c, raw)
+			}
+		}
+	}
+	if av.Options == nil || bv.Options != nil {
+		t.Fatal("expected alice to act first heads up")
+	}
+
+	bob.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Check}})
+	if m := bob.read(); m.Type != "error" {
+		t.Fatalf("got %+v; want an error for acting out of turn", m)
+	}
+	alice.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Call}})
+	bob.state(func(v *table.View) bool { return v.Options != nil })
+	bob.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Check}})
+	flop := func(v *table.View) bool { return v.Round == table.Flop }
+	alice.state(flop)
+	bob.state(flop)
+
+	// bob reconnects with his token and picks up where he left off
+	bob.ws.Close()
+	bob, again := dial(t, url, Message{Type: "hello", Token: welcome.Token})
+	if again.Name != "bob" {
+		t.Fatalf("reconnected as %q", again.Name)
+	}
+	v := bob.state(flop)
+	if v.Seat != 1 || v.Players[1].Cards[0] != bv.Players[1].Cards[0] || v.Options == nil {
+		t.Fatalf("unexpected view after reconnecting %+v", v)
+	}
+
+	bob.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Bet, Amount: 4}})
+	alice.state(func(v *table.View) bool { return v.Options != nil })
+	alice.send(Message{Type: "act", Table: "t1", Action: &table.Action{Type: table.Fold}})
+	v = alice.state(func(v *table.View) bool { return v.Hand == 2 && v.Active })
+	if v.Players[0].Chips+v.Players[0].Bet != 98 || v.Players[1].Chips+v.Players[1].Bet != 102 {
+		t.Fatalf("unexpected stacks %+v", v.Players)
+	}
+
+	alice.send(Message{Type: "list"})
+	for {
+		m := alice.read()
+		if m.Type == "tables" {
+			if len(m.Tables) != 1 || m.Tables[0].Players != 2 || m.Tables[0].BigBlind != 2 {
+				t.Fatalf("tables = %+v", m.Tables)
+			}
+			break
+		}
+	}
+}
+
+func TestActionTimeout(t *testing.T) {
+	s := NewServer(nil)
+	s.ActionTimeout = 20 * time.Millisecond
+	url := newTestServer(t, s)
+	alice, _ := dial(t, url, Message{Type: "hello", Name: "alice"})
+	alice.send(Message{Type: "create", Table: "t1", Seats: 2, SmallBlind: 1, BigBlind: 2})
+	bob, _ := dial(t, url, Message{Type: "hello", Name: "bob"})
+	bob.send(Message{Type: "join", Table: "t1"})
+	alice.send(Message{Type: "sit", Table: "t1", Seat: 0, Chips: 100})
+	bob.send(Message{Type: "sit", Table: "t1", Seat: 1, Chips: 100})
+	// nobody acts, so the small blind folds and the next hand is dealt
+	alice.state(func(v *table.View) bool { return v.Hand == 2 })
+}
+
+func TestSessionTimeout(t *testing.T) {
+	s := NewServer(nil)
+	s.SessionTimeout = 20 * time.Millisecond
+	url := newTestServer(t, s)
+	alice, _ := dial(t, url, Message{Type: "hello", Name: "alice"})
+	alice.send(Message{Type: "create", Table: "t1", Seats: 2, SmallBlind: 1, BigBlind: 2})
+	alice.state(func(v *table.View) bool { return true })
+
+	// a session without tables ends with its connection
+	carol, _ := dial(t, url, Message{Type: "hello", Name: "carol"})
+	carol.ws.Close()
+	waitSessions(t, s, 1)
+
+	// a seated session is kept until it times out
+	bob, welcome := dial(t, url, Message{Type: "hello", Name: "bob"})
+	bob.send(Message{Type: "join", Table: "t1"})
+	bob.send(Message{Type: "sit", Table: "t1", Seat: 1, Chips: 100})
+	alice.state(func(v *table.View) bool { return v.Players[1].Name == "bob" })
+	bob.ws.Close()
+	alice.state(func(v *table.View) bool { return v.Players[1].Name == "" })
+	waitSessions(t, s, 1)
+
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{t: t, ws: ws}
+	c.send(Message{Type: "hello", Token: welcome.Token})
+	if m := c.read(); m.Type != "error" {
+		t.Fatalf("got %+v; want an error for an expired token", m)
+	}
+}
+
+// waitSessions waits for the server to have n sessions.
+func waitSessions(t *testing.T, s *Server, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		got := len(s.sessions)
+		s.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sessions = %d; want %d", got, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCreateLimits(t *testing.T) {
+	url := newTestServer(t, NewServer(nil))
+	alice, _ := dial(t, url, Message{Type: "hello", Name: "alice"})
+	for _, seats := range []int{0, 1, maxSeats + 1, 1 << 36} {
+		alice.send(Message{Type: "create", Table: "big", Seats: seats, SmallBlind: 1, BigBlind: 2})
+		if m := alice.read(); m.Type != "error" {
+			t.Fatalf("got %+v; want an error for %d seats", m, seats)
+		}
+	}
+	for i := 0; i < maxTables; i++ {
+		alice.send(Message{Type: "create", Table: fmt.Sprint("t", i), Seats: 2, SmallBlind: 1, BigBlind: 2})
+		if m := alice.read(); m.Type != "state" {
+			t.Fatalf("got %+v; want the new table's state", m)
+		}
+	}
+	alice.send(Message{Type: "create", Table: "one too many", Seats: 2, SmallBlind: 1, BigBlind: 2})
+	if m := alice.read(); m.Type != "error" {
+		t.Fatalf("got %+v; want an error for too many tables", m)
+	}
+}
+
+func TestHello(t *testing.T) {
+	url := newTestServer(t, NewServer(nil))
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{t: t, ws: ws}
+	c.send(Message{Type: "hello", Token: "nope"})
+	if m := c.read(); m.Type != "error" {
+		t.Fatalf("got %+v; want an error for an unknown token", m)
+	}
+}
+
+func TestStatic(t *testing.T) {
+	files, err := static("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(files)
+	defer ts.Close()
+	for _, test := range []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{"/", "text/html; charset=utf-8", "Odds calculator"},
+		{"/app.js", "text/javascript; charset=utf-8", "joker.equity"},
+		{"/main.wasm", "application/wasm", ""},
+	} {
+		resp, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != test.contentType {
+			t.Fatalf("GET %s = %d %s", test.path, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(string(b), test.contains) {
+			t.Fatalf("GET %s doesn't contain %q", test.path, test.contains)
+		}
+	}
+}
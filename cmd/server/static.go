@@ -0,0 +1,26 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// public holds the web pages and the wasm build so that the server runs
+// without any files next to it.
+//
+//go:embed public
+var public embed.FS
+
+// static returns a handler for the files in dir, or for the embedded
+// public files if dir is empty.
+func static(dir string) (http.Handler, error) {
+	if dir != "" {
+		return http.FileServer(http.Dir(dir)), nil
+	}
+	files, err := fs.Sub(public, "public")
+	if err != nil {
+		return nil, err
+	}
+	return http.FileServer(http.FS(files)), nil
+}
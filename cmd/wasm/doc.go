@@ -0,0 +1,39 @@
+/*
+Command wasm is compiled to WebAssembly and exposes the evaluator to
+JavaScript as functions on a global joker object.  Build it with make,
+which writes main.wasm to cmd/server/public next to the demo page.
+
+	GOOS=js GOARCH=wasm go build -trimpath -buildvcs=false -o main.wasm
+
+Cards are strings such as "A♠" or "As" and hands are returned in the JSON
+encoding of hand.Hand.  A hand has one to seven cards, and at least five
+when hands are compared.  The config argument is optional and has the
+JSON encoding of hand.Config.  Functions return an Error instead of a
+result when their arguments are invalid.
+
+	joker.evaluate(["As","Ks","Qs","Js","Ts"], {"sorting":1})
+	// {"ranking":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],...}
+
+	joker.evaluateOmaha(["As","Kh","Qc","Jd"], ["Ts","9s","8s","2s","3d"], config)
+	// {"ranking":5,"cards":["Q♣","J♦","T♠","9♠","8♠"],...}
+
+	joker.compare([["As","Ad","7c","8d","9h"],["Ks","Kd","7c","8d","9h"]], config)
+	// {"hands":[{...},{...}],"winners":[0]}
+
+	joker.parseRange("QQ+,AKs")
+	// {"range":"QQ+,AKs","combos":[["A♠","A♥"],...],"size":22,"fraction":0.0165...}
+
+	joker.shuffle(42)
+	// ["7♦","K♣",...] all 52 cards in the order they are dealt
+
+Equity can take a while so it returns a Promise.  Each player is either a
+pair of hole cards or a range, and the options are all optional.  The
+config option sets the rules hands are evaluated with, so that the lowest
+hand wins with a low sorting, and the omaha option deals players four
+cards of which they must use two.  The exactLimit option is the most board
+runouts that are enumerated instead of sampled.
+
+	joker.equity([["As","Ad"], "KK,QQ"], ["Ks","7d","2c"], {"iterations":5000,"dead":["3h"],"seed":1})
+	// Promise {"equity":[0.08,0.92],"win":[...],"tie":[...],"samples":...,"exact":false}
+*/
+package main
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+func evaluate(cs cards.List, config *hand.Config) (*hand.Hand, error) {
+	if err := cards.Check(cs, 1, 7, "hand"); err != nil {
+		return nil, err
+	}
+	return hand.New(cs, cards.Rules(config)...), nil
+}
+
+func evaluateOmaha(hole, board cards.List, config *hand.Config) (*hand.Hand, error) {
+	if err := cards.Check(hole, 4, 4, "hand"); err != nil {
+		return nil, err
+	}
+	if err := cards.Check(board, 3, 5, "board"); err != nil {
+		return nil, err
+	}
+	if err := cards.Check(append(append([]hand.Card{}, hole...), board...), 7, 9, "hand and board"); err != nil {
+		return nil, err
+	}
+	return hand.NewOmaha(hole, board, cards.Rules(config)...), nil
+}
+
+type comparison struct {
+	Hands   []*hand.Hand `json:"hands"`
+	Winners []int        `json:"winners"`
+}
+
+func compare(hs []cards.List, config *hand.Config) (*comparison, error) {
+	if len(hs) < 2 {
+		return nil, errors.New("compare needs at least two hands")
+	}
+	c := &comparison{}
+	for _, cs := range hs {
+		if err := cards.Check(cs, 5, 7, "hand"); err != nil {
+			return nil, err
+		}
+		c.Hands = append(c.Hands, hand.New(cs, cards.Rules(config)...))
+	}
+	c.Winners = cards.Winners(c.Hands, cards.Sorting(config))
+	return c, nil
+}
+
+// player is either a pair of hole cards or a range.
+type player struct {
+	cards cards.List
+	rng   string
+}
+
+func (p *player) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.rng); err == nil {
+		return nil
+	}
+	return json.Unmarshal(b, &p.cards)
+}
+
+type equityOptions struct {
+	Iterations int          `json:"iterations"`
+	ExactLimit *int         `json:"exactLimit"`
+	Dead       cards.List   `json:"dead"`
+	Seed       *int64       `json:"seed"`
+	Config     *hand.Config `json:"config"`
+	Omaha      bool         `json:"omaha"`
+}
+
+func calculate(players []player, board cards.List, opts equityOptions) (*equity.Result, error) {
+	if len(players) < 2 || len(players) > 10 {
+		return nil, errors.New("equity needs two to ten players")
+	}
+	if opts.Iterations < 0 {
+		return nil, errors.New("iterations must not be negative")
+	}
+	if err := cards.Check(board, 0, 5, "board"); err != nil {
+		return nil, err
+	}
+	holeCards := 2
+	if opts.Omaha {
+		holeCards = 4
+	}
+	ranges := [][][]hand.Card{}
+	for _, p := range players {
+		if p.rng != "" {
+			if opts.Omaha {
+				return nil, errors.New("omaha players must have cards")
+			}
+			r, err := preflop.ParseRange(p.rng)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r.Combos())
+			continue
+		}
+		if err := cards.Check(p.cards, holeCards, holeCards, "player"); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, [][]hand.Card{p.cards})
+	}
+	options := []func(*equity.Config){
+		equity.Dead(opts.Dead...),
+		equity.Rules(cards.Rules(opts.Config)...),
+	}
+	if opts.Omaha {
+		options = append(options, equity.Omaha)
+	}
+	if opts.ExactLimit != nil {
+		options = append(options, equity.ExactLimit(*opts.ExactLimit))
+	}
+	if opts.Iterations > 0 {
+		options = append(options, equity.Iterations(opts.Iterations))
+	}
+	if opts.Seed != nil {
+		options = append(options, equity.WithRand(rand.New(rand.NewSource(*opts.Seed))))
+	}
+	return equity.Ranges(ranges, board, options...)
+}
+
+type rangeInfo struct {
+	Range    string        `json:"range"`
+	Combos   [][]hand.Card `json:"combos"`
+	Size     float64       `json:"size"`
+	Fraction float64       `json:"fraction"`
+}
+
+func parseRange(s string) (*rangeInfo, error) {
+	r, err := preflop.ParseRange(s)
+	if err != nil {
+		return nil, err
+	}
+	return &rangeInfo{
+		Range:    r.String(),
+		Combos:   r.Combos(),
+		Size:     r.Size(),
+		Fraction: r.Fraction(),
+	}, nil
+}
+
+// shuffle returns a deck shuffled with the seed in the order it is dealt.
+func shuffle(seed int64) []hand.Card {
+	deck := hand.NewDealer(rand.New(rand.NewSource(seed))).Deck()
+	return deck.PopMulti(52)
+}
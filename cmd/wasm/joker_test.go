@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func parseCards(t *testing.T, s string) cards.List {
+	var cs cards.List
+	if err := json.Unmarshal([]byte(s), &cs); err != nil {
+		t.Fatal(err)
+	}
+	return cs
+}
+
+func TestEvaluate(t *testing.T) {
+	h, err := evaluate(parseCards(t, `["As","K♠","Qs","Js","Ts","2d","3c"]`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(h)
+	want := `{"ranking":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],"description":"royal flush","config":{"sorting":0,"ignoreStraights":false,"ignoreFlushes":false,"aceIsLow":false}}`
+	if string(b) != want {
+		t.Fatalf("evaluate = %s; want %s", b, want)
+	}
+	if _, err := evaluate(parseCards(t, `["As","As"]`), nil); err == nil {
+		t.Fatal("expected error for duplicate cards")
+	}
+	if _, err := evaluate(parseCards(t, `["As","Ks","Qs","Js","Ts","9s","8s","7s"]`), nil); err == nil {
+		t.Fatal("expected error for more than seven cards")
+	}
+	var cs cards.List
+	if err := json.Unmarshal([]byte(`["Zz"]`), &cs); err == nil {
+		t.Fatal("expected error for invalid card")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	hs := []cards.List{
+		parseCards(t, `["As","Ad","3c","4h","5s"]`),
+		parseCards(t, `["7s","5d","4c","3h","2c"]`),
+	}
+	c, err := compare(hs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Winners) != 1 || c.Winners[0] != 0 {
+		t.Fatalf("high winners = %v", c.Winners)
+	}
+	config := &hand.Config{}
+	if err := json.Unmarshal([]byte(`{"sorting":2,"ignoreStraights":true,"ignoreFlushes":true,"aceIsLow":true}`), config); err != nil {
+		t.Fatal(err)
+	}
+	c, err = compare(hs, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Winners) != 1 || c.Winners[0] != 1 {
+		t.Fatalf("low winners = %v", c.Winners)
+	}
+	if _, err := compare(hs[:1], nil); err == nil {
+		t.Fatal("expected error for one hand")
+	}
+	short := []cards.List{parseCards(t, `["As"]`), parseCards(t, `["Ks"]`)}
+	if _, err := compare(short, nil); err == nil {
+		t.Fatal("expected error for hands of fewer than five cards")
+	}
+}
+
+func TestEquity(t *testing.T) {
+	var players []player
+	if err := json.Unmarshal([]byte(`[["As","Ad"],"KK"]`), &players); err != nil {
+		t.Fatal(err)
+	}
+	board := parseCards(t, `["Ks","7d","2c","3h","4h"]`)
+	res, err := calculate(players, board, equityOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[0] != 0 || res.Equity[1] != 1 {
+		t.Fatalf("equity = %+v", res)
+	}
+	if _, err := calculate(players[:1], nil, equityOptions{}); err == nil {
+		t.Fatal("expected error for one player")
+	}
+	players = append(players, player{rng: "X"})
+	if _, err := calculate(players, nil, equityOptions{}); err == nil {
+		t.Fatal("expected error for invalid range")
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	r, err := parseRange("AA,KK,QQ,AKs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Range != "QQ+,AKs" || len(r.Combos) != 22 || r.Size != 22 {
+		t.Fatalf("range = %+v", r)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	a, b := shuffle(1), shuffle(1)
+	seen := map[hand.Card]bool{}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatal("same seed gave different decks")
+		}
+		seen[a[i]] = true
+	}
+	if len(seen) != 52 {
+		t.Fatalf("deck has %d distinct cards", len(seen))
+	}
+}
+
+func TestOmaha(t *testing.T) {
+	hole := parseCards(t, `["As","Kh","Qc","Jd"]`)
+	board := parseCards(t, `["Ts","9s","8s","2s","3d"]`)
+	h, err := evaluateOmaha(hole, board, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Description() != "straight queen high" {
+		t.Fatalf("hand = %v", h)
+	}
+	if _, err := evaluateOmaha(hole, board[:2], nil); err == nil {
+		t.Fatal("expected error for a two card board")
+	}
+	players := []player{{cards: hole}, {cards: parseCards(t, `["7h","6h","2c","2d"]`)}}
+	res, err := calculate(players, board, equityOptions{Omaha: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[0] != 1 {
+		t.Fatalf("equity = %v", res.Equity)
+	}
+	players[1] = player{rng: "AA"}
+	if _, err := calculate(players, board, equityOptions{Omaha: true}); err == nil {
+		t.Fatal("expected error for an omaha range")
+	}
+}
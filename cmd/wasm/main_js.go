@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"syscall/js"
+	"time"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func main() {
+	joker := js.Global().Get("Object").New()
+	export(joker, "evaluate", func(args []js.Value) (interface{}, error) {
+		var cs cards.List
+		var config *hand.Config
+		if err := decode(args, &cs, &config); err != nil {
+			return nil, err
+		}
+		return evaluate(cs, config)
+	})
+	export(joker, "evaluateOmaha", func(args []js.Value) (interface{}, error) {
+		var hole, board cards.List
+		var config *hand.Config
+		if err := decode(args, &hole, &board, &config); err != nil {
+			return nil, err
+		}
+		return evaluateOmaha(hole, board, config)
+	})
+	export(joker, "compare", func(args []js.Value) (interface{}, error) {
+		var hs []cards.List
+		var config *hand.Config
+		if err := decode(args, &hs, &config); err != nil {
+			return nil, err
+		}
+		return compare(hs, config)
+	})
+	export(joker, "parseRange", func(args []js.Value) (interface{}, error) {
+		var s string
+		if err := decode(args, &s); err != nil {
+			return nil, err
+		}
+		return parseRange(s)
+	})
+	export(joker, "shuffle", func(args []js.Value) (interface{}, error) {
+		seed := time.Now().UnixNano()
+		if err := decode(args, &seed); err != nil {
+			return nil, err
+		}
+		return shuffle(seed), nil
+	})
+	joker.Set("equity", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		var players []player
+		var board cards.List
+		var opts equityOptions
+		err := decode(args, &players, &board, &opts)
+		return promise(func() (interface{}, error) {
+			if err != nil {
+				return nil, err
+			}
+			return calculate(players, board, opts)
+		})
+	}))
+	js.Global().Set("joker", joker)
+	select {}
+}
+
+// export sets a function on the object that returns its result converted
+// to a JavaScript value or an Error.
+func export(obj js.Value, name string, fn func(args []js.Value) (interface{}, error)) {
+	obj.Set(name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		v, err := fn(args)
+		if err != nil {
+			return jsError(err)
+		}
+		res, err := toJS(v)
+		if err != nil {
+			return jsError(err)
+		}
+		return res
+	}))
+}
+
+// promise returns a Promise that is settled by running fn in a goroutine
+// so that the calling JavaScript isn't blocked.
+func promise(fn func() (interface{}, error)) js.Value {
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve, reject := args[0], args[1]
+		go func() {
+			defer executor.Release()
+			v, err := fn()
+			if err == nil {
+				v, err = toJS(v)
+			}
+			if err != nil {
+				reject.Invoke(jsError(err))
+				return
+			}
+			resolve.Invoke(v)
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(executor)
+}
+
+// decode unmarshals the arguments into dst through their JSON encoding.
+// Missing, null, and undefined arguments leave dst unchanged.
+func decode(args []js.Value, dst ...interface{}) error {
+	for i, d := range dst {
+		if i >= len(args) || args[i].IsUndefined() || args[i].IsNull() {
+			continue
+		}
+		s := js.Global().Get("JSON").Call("stringify", args[i]).String()
+		if err := json.Unmarshal([]byte(s), d); err != nil {
+			return errors.New("invalid argument " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+	}
+	return nil
+}
+
+func toJS(v interface{}) (js.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return js.Undefined(), err
+	}
+	return js.Global().Get("JSON").Call("parse", string(b)), nil
+}
+
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}
@@ -0,0 +1,14 @@
+//go:build !js
+// +build !js
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "wasm: build with GOOS=js GOARCH=wasm")
+	os.Exit(2)
+}
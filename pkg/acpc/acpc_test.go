@@ -0,0 +1,257 @@
+package acpc_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/pkg/acpc"
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+func TestState(t *testing.T) {
+	tests := []struct {
+		game     *acpc.Game
+		line     string
+		round    int
+		toAct    int
+		spent    string
+		finished bool
+	}{
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:30::9s8h|", 0, 1, "[100 50]", false},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:1:31:r300r900c/:|JdTc/6dJc9c", 1, 0, "[900 900]", false},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:32:r20000c///:Ks6h|Qs5d/2c7d9h/Ts/Jc", 3, -1, "[20000 20000]", true},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:33:r300f:9s8h|", 0, -1, "[100 300]", true},
+		{acpc.HeadsUpLimit, "MATCHSTATE:1:0:rrrc/r:|AsKd/2c3d4h", 1, 1, "[50 40]", false},
+		{acpc.ThreePlayerLimit, "MATCHSTATE:2:0:rcc/cc:||AsKd/2c3d4h", 1, 2, "[20 20 20]", false},
+	}
+	for _, test := range tests {
+		s, err := acpc.ParseState(test.game, test.line)
+		if err != nil {
+			t.Fatalf("%s: %v", test.line, err)
+		}
+		if s.String() != test.line {
+			t.Fatalf("%s printed as %s", test.line, s)
+		}
+		var spent []int
+		for p := 0; p < test.game.Players; p++ {
+			spent = append(spent, s.Spent(p))
+		}
+		if s.Round() != test.round || s.ToAct() != test.toAct || fmt.Sprint(spent) != test.spent || s.Finished() != test.finished {
+			t.Fatalf("%s: round %d to act %d spent %v finished %v", test.line, s.Round(), s.ToAct(), spent, s.Finished())
+		}
+	}
+}
+
+func TestInvalidState(t *testing.T) {
+	tests := []struct {
+		game *acpc.Game
+		line string
+	}{
+		// the fourth raise is over the cap
+		{acpc.HeadsUpLimit, "MATCHSTATE:1:0:rrrr:|AsKd"},
+		// the smallest raise is to 200
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:1:0:r150:|AsKd"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:1:0:r20001:|AsKd"},
+		// the big blind can check
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:cf:AsKd|"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:cc:AsKd|"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:c/:AsKd|"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0::AsKd|/2c3d4h"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:cc/:AsKd|/2c3d"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0::AsKd"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:2:0::AsKd|"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0::AxKd|"},
+		{acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:x:AsKd|"},
+		{acpc.HeadsUpNoLimit, "STATE:0:0::AsKd|"},
+	}
+	for _, test := range tests {
+		if _, err := acpc.ParseState(test.game, test.line); err == nil {
+			t.Fatalf("%s: expected error", test.line)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		line   string
+		values string
+	}{
+		{"MATCHSTATE:0:0:r20000c///:Ks6h|Qs5d/2c7d9h/Ts/Jc", "[20000 -20000]"},
+		{"MATCHSTATE:0:0:r300c/cc/cc/cc:Ks6h|Kd6c/2c7d9h/Ts/Jc", "[0 0]"},
+		{"MATCHSTATE:0:0:r300f:|", "[-100 100]"},
+	}
+	for _, test := range tests {
+		s, err := acpc.ParseState(acpc.HeadsUpNoLimit, test.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values, err := s.Values()
+		if err != nil || fmt.Sprint(values) != test.values {
+			t.Fatalf("%s: values = %v, %v; want %s", test.line, values, err, test.values)
+		}
+	}
+	s, _ := acpc.ParseState(acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:r300:|")
+	if _, err := s.Values(); err == nil {
+		t.Fatal("expected error for a hand in progress")
+	}
+}
+
+func TestView(t *testing.T) {
+	s, _ := acpc.ParseState(acpc.HeadsUpNoLimit, "MATCHSTATE:1:0:r300r900:|JdTc")
+	v := s.View()
+	o := v.Options
+	if v.Seat != 1 || v.Button != 1 || v.SmallBlind != 50 || v.BigBlind != 100 || v.Pot != 1200 || v.Round != table.Preflop {
+		t.Fatalf("view = %+v", v)
+	}
+	if o == nil || fmt.Sprint(o.Actions) != "[Fold Call Raise]" || o.ToCall != 600 || o.MinRaise != 1500 || o.MaxRaise != 20000 {
+		t.Fatalf("options = %+v", o)
+	}
+	if p := v.Players[1]; p.Bet != 300 || p.Chips != 19700 || len(p.Cards) != 2 {
+		t.Fatalf("player = %+v", p)
+	}
+
+	// bets after the flop are counted from the start of the round
+	s, _ = acpc.ParseState(acpc.HeadsUpNoLimit, "MATCHSTATE:0:0:r300c/:9s8h|/2c7d9h")
+	o = s.View().Options
+	if fmt.Sprint(o.Actions) != "[Fold Check Bet]" || o.MinRaise != 100 || o.MaxRaise != 19700 {
+		t.Fatalf("options = %+v", o)
+	}
+	if v := s.View(); v.Players[0].Bet != 0 || v.Pot != 600 || len(v.Board) != 3 {
+		t.Fatalf("view = %+v", v)
+	}
+
+	// limit games are capped
+	s, _ = acpc.ParseState(acpc.HeadsUpLimit, "MATCHSTATE:0:0:rrr:AsKd|")
+	if o := s.View().Options; fmt.Sprint(o.Actions) != "[Fold Call]" || o.ToCall != 10 {
+		t.Fatalf("options = %+v", o)
+	}
+}
+
+func TestParseGame(t *testing.T) {
+	const nolimit = `# heads-up no-limit
+GAMEDEF
+nolimit
+numPlayers = 2
+numRounds = 4
+stack = 20000 20000
+blind = 100 50
+firstPlayer = 2 1 1 1
+numSuits = 4
+numRanks = 13
+numHoleCards = 2
+numBoardCards = 0 3 1 1
+END GAMEDEF
+`
+	const limit = `GAMEDEF
+limit
+numPlayers = 2
+numRounds = 4
+blind = 10 5
+raiseSize = 10 10 20 20
+firstPlayer = 2 1 1 1
+maxRaises = 3 4 4 4
+numSuits = 4
+numRanks = 13
+numHoleCards = 2
+numBoardCards = 0 3 1 1
+END GAMEDEF
+`
+	for def, want := range map[string]*acpc.Game{nolimit: acpc.HeadsUpNoLimit, limit: acpc.HeadsUpLimit} {
+		g, err := acpc.ParseGame(strings.NewReader(def))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprintf("%+v", g) != fmt.Sprintf("%+v", want) {
+			t.Fatalf("game = %+v; want %+v", g, want)
+		}
+	}
+	for _, bad := range []string{
+		strings.Replace(limit, "numHoleCards = 2", "numHoleCards = 4", 1),
+		strings.Replace(nolimit, "stack = 20000 20000", "stack = 20000 10000", 1),
+		strings.Replace(limit, "raiseSize = 10 10 20 20", "", 1),
+		strings.Replace(limit, "blind = 10 5", "blind = 10", 1),
+		limit + "unknown = 1\n",
+	} {
+		if _, err := acpc.ParseGame(strings.NewReader(bad)); err == nil {
+			t.Fatalf("expected error for\n%s", bad)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, g := range []*acpc.Game{acpc.HeadsUpNoLimit, acpc.HeadsUpLimit, acpc.ThreePlayerLimit} {
+		agents := []agent.Agent{agent.TAG(), agent.CallingStation(), agent.Random(rand.New(rand.NewSource(1)))}[:g.Players]
+		listeners := []net.Listener{}
+		errs := make(chan error, g.Players)
+		for _, a := range agents {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+			listeners = append(listeners, l)
+			go func(a agent.Agent, addr string) {
+				conn, err := net.Dial("tcp", addr)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer conn.Close()
+				errs <- acpc.Play(g, conn, a)
+			}(a, l.Addr().String())
+		}
+		log := &bytes.Buffer{}
+		dealer := hand.NewDealer(rand.New(rand.NewSource(1)))
+		totals, err := acpc.Serve(g, listeners, 50, acpc.WithDealer(dealer), acpc.WithLog(log))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range agents {
+			if err := <-errs; err != nil {
+				t.Fatal(err)
+			}
+		}
+		sum := 0
+		for _, v := range totals {
+			sum += v
+		}
+		lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+		if sum != 0 || len(lines) != 51 || !strings.HasPrefix(lines[50], "SCORE:") {
+			t.Fatalf("totals %v with log\n%s", totals, log)
+		}
+	}
+}
+
+func TestBadReply(t *testing.T) {
+	dealer, client := net.Pipe()
+	go func() {
+		r := bufio.NewReader(client)
+		io.WriteString(client, "VERSION:2.0.0\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			// reply to the wrong hand when it's our turn
+			if strings.HasPrefix(line, "MATCHSTATE:1:0::") {
+				io.WriteString(client, "MATCHSTATE:1:7::|AsKd:c\r\n")
+			}
+		}
+	}()
+	opponent, other := net.Pipe()
+	go acpc.Play(acpc.HeadsUpNoLimit, other, agent.CallingStation())
+	_, err := acpc.Match(acpc.HeadsUpNoLimit, []io.ReadWriter{opponent, dealer}, 1)
+	if err == nil {
+		t.Fatal("expected error for a reply to the wrong state")
+	}
+	dealer.Close()
+	opponent.Close()
+}
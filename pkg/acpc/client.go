@@ -0,0 +1,141 @@
+package acpc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// version is the protocol version sent by clients.
+const version = "VERSION:2.0.0"
+
+// Play connects an agent to a dealer, acting whenever the dealer sends a
+// state with the agent to act, until the dealer closes the connection.
+func Play(g *Game, conn io.ReadWriter, a agent.Agent) error {
+	if _, err := io.WriteString(conn, version+"\r\n"); err != nil {
+		return err
+	}
+	s := bufio.NewScanner(conn)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if comment(line) {
+			continue
+		}
+		st, err := ParseState(g, line)
+		if err != nil {
+			return err
+		}
+		if st.ToAct() != st.Position {
+			continue
+		}
+		act := st.action(a.Act(st.View()))
+		if _, err := fmt.Fprintf(conn, "%s:%s\r\n", line, act); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// comment reports whether a line is blank or a comment, which dealers and
+// clients ignore.
+func comment(line string) bool {
+	return line == "" || line[0] == '#' || line[0] == ';'
+}
+
+// View returns the state as a table view from the state's position, for
+// agents written for pkg/table.  Bets are the chips put in during the
+// current round, and limit games are given stacks of the most that can
+// be bet in a hand.
+func (s *State) View() table.View {
+	g := s.Game
+	n := g.Players
+	stack := g.Stack
+	if !g.NoLimit {
+		stack = g.limitStack()
+	}
+	v := table.View{
+		Seat:     s.Position,
+		Hand:     s.Hand,
+		Active:   !s.finished,
+		Button:   (g.FirstPlayer[1] + n - 1) % n,
+		BigBlind: g.bigBlind(),
+		Board:    s.Board,
+		ToAct:    s.ToAct(),
+		Players:  make([]table.PlayerView, n),
+	}
+	for _, b := range g.Blinds {
+		if b > 0 && b < v.BigBlind && b > v.SmallBlind {
+			v.SmallBlind = b
+		}
+	}
+	if !s.finished {
+		v.Round = table.Round(s.Round() + 1)
+	}
+	for i := range v.Players {
+		v.Pot += s.spent[i]
+		v.Players[i] = table.PlayerView{
+			Name:   fmt.Sprint("position", i),
+			Chips:  stack - s.spent[i],
+			Bet:    s.spent[i] - s.start[i],
+			InHand: true,
+			Folded: s.folded[i],
+			AllIn:  g.NoLimit && s.spent[i] == stack,
+			Cards:  s.Hole[i],
+		}
+	}
+	if s.finished || s.toAct != s.Position {
+		return v
+	}
+	p := s.toAct
+	o := &table.Options{Actions: []table.ActionType{table.Fold}, ToCall: s.maxSpent - s.spent[p]}
+	if o.ToCall == 0 {
+		o.Actions = append(o.Actions, table.Check)
+	} else {
+		o.Actions = append(o.Actions, table.Call)
+	}
+	if min, max, ok := s.RaiseRange(); ok {
+		if s.maxSpent == s.start[p] {
+			o.Actions = append(o.Actions, table.Bet)
+		} else {
+			o.Actions = append(o.Actions, table.Raise)
+		}
+		o.MinRaise = min - s.start[p]
+		o.MaxRaise = max - s.start[p]
+	}
+	v.Options = o
+	return v
+}
+
+// action converts an action on a table view to the protocol.  Folds with
+// nothing to call become checks, and bets and raises out of range are
+// moved into it.
+func (s *State) action(a table.Action) Action {
+	p := s.toAct
+	switch a.Type {
+	case table.Fold:
+		if s.spent[p] < s.maxSpent {
+			return Action{Type: Fold}
+		}
+	case table.Bet, table.Raise:
+		min, max, ok := s.RaiseRange()
+		if !ok {
+			break
+		}
+		if !s.Game.NoLimit {
+			return Action{Type: Raise}
+		}
+		to := a.Amount + s.start[p]
+		if to < min {
+			to = min
+		}
+		if to > max {
+			to = max
+		}
+		return Action{Type: Raise, Size: to}
+	}
+	return Action{Type: Call}
+}
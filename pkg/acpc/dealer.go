@@ -0,0 +1,192 @@
+package acpc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// Config represents the configuration options for a dealer.
+type Config struct {
+	dealer hand.Dealer
+	log    io.Writer
+	names  []string
+}
+
+// WithDealer sets the dealer that shuffles the decks.  A dealer seeded
+// from the time is used by default.
+func WithDealer(d hand.Dealer) func(*Config) {
+	return func(c *Config) {
+		c.dealer = d
+	}
+}
+
+// WithLog writes an ACPC log of the match, a STATE line for each hand with
+// every player's cards and the chips won and a SCORE line at the end.
+func WithLog(w io.Writer) func(*Config) {
+	return func(c *Config) {
+		c.log = w
+	}
+}
+
+// Names sets the names of the players in the log.  Players are named
+// after their index by default.
+func Names(names ...string) func(*Config) {
+	return func(c *Config) {
+		c.names = names
+	}
+}
+
+// Match deals hands of a game to players connected over conns and returns
+// the chips each player won.  Each player must first send the protocol
+// version.  Player i plays position (i+h)%n in hand h, so the players
+// rotate through the positions.  Invalid actions are replaced with a call,
+// as in the competition, but replies that aren't to the state sent end
+// the match with an error.
+func Match(g *Game, conns []io.ReadWriter, hands int, options ...func(*Config)) ([]int, error) {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	if c.dealer == nil {
+		c.dealer = hand.NewDealer(rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	n := g.Players
+	if len(conns) != n {
+		return nil, errors.New("acpc: need a connection for each player")
+	}
+	if c.names == nil {
+		for i := 0; i < n; i++ {
+			c.names = append(c.names, strconv.Itoa(i))
+		}
+	}
+	if len(c.names) != n {
+		return nil, errors.New("acpc: need a name for each player")
+	}
+	players := make([]*bufio.Reader, n)
+	for i, conn := range conns {
+		players[i] = bufio.NewReader(conn)
+		line, err := readLine(players[i])
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "VERSION:2.") {
+			return nil, fmt.Errorf("acpc: player %d sent unsupported version %q", i, line)
+		}
+	}
+	totals := make([]int, n)
+	for h := 0; h < hands; h++ {
+		// player i sits in position (i+h)%n and position p holds player
+		// (p-h)%n
+		player := func(p int) int {
+			return ((p-h)%n + n) % n
+		}
+		deck := c.dealer.Deck()
+		s := NewState(g, h, -1)
+		for p := range s.Hole {
+			s.Hole[p] = deck.PopMulti(2)
+		}
+		board := deck.PopMulti(5)
+		for {
+			dealt := 0
+			for r := 0; r <= s.Round(); r++ {
+				dealt += boardCards[r]
+			}
+			s.Board = board[:dealt]
+			for p := 0; p < n; p++ {
+				if _, err := fmt.Fprintf(conns[player(p)], "%s\r\n", s.view(p)); err != nil {
+					return nil, err
+				}
+			}
+			if s.Finished() {
+				break
+			}
+			p := s.ToAct()
+			i := player(p)
+			line, err := readLine(players[i])
+			if err != nil {
+				return nil, err
+			}
+			sent := s.view(p).String() + ":"
+			if !strings.HasPrefix(line, sent) {
+				return nil, fmt.Errorf("acpc: player %d replied %q to %q", i, line, sent)
+			}
+			a, err := ParseAction(strings.TrimPrefix(line, sent))
+			if err == nil {
+				err = s.Apply(a)
+			}
+			if err != nil {
+				s.Apply(Action{Type: Call})
+			}
+		}
+		// the log shows every hole card and the board of the rounds played
+		s.Board = board
+		values, err := s.Values()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, n)
+		for p := range values {
+			totals[player(p)] += values[p]
+			names[p] = c.names[player(p)]
+		}
+		if c.log != nil {
+			fmt.Fprintf(c.log, "STATE:%d:%s:%s:%s:%s\n", h, s.betting(), s.cards(), join(values), strings.Join(names, "|"))
+		}
+	}
+	if c.log != nil {
+		fmt.Fprintf(c.log, "SCORE:%s:%s\n", join(totals), strings.Join(c.names, "|"))
+	}
+	return totals, nil
+}
+
+// Serve accepts a connection from each player on their listener, plays a
+// match, and closes the connections.
+func Serve(g *Game, listeners []net.Listener, hands int, options ...func(*Config)) ([]int, error) {
+	conns := []io.ReadWriter{}
+	for _, l := range listeners {
+		conn, err := l.Accept()
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+	return Match(g, conns, hands, options...)
+}
+
+// readLine reads a line that isn't a comment, without its line ending.
+func readLine(r *bufio.Reader) (string, error) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if !comment(line) {
+			return line, nil
+		}
+	}
+}
+
+func join(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, "|")
+}
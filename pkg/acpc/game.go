@@ -0,0 +1,225 @@
+// Package acpc speaks the text protocol of the Annual Computer Poker
+// Competition, so that agents can play against ACPC dealers and ACPC bots
+// can play against each other on a joker dealer.
+//
+// A dealer sends every player a MATCHSTATE line whenever the hand changes.
+// The line holds the player's position, the hand number, the betting so
+// far, and the cards the player can see:
+//
+//	MATCHSTATE:0:30:r300c/r900:9s8h|/8c8d5c/6s
+//
+// Betting rounds are separated by slashes.  Actions are f to fold, c to
+// check or call, and r to raise, followed in no-limit games by the total
+// the player has put in the hand after raising.  The player to act replies
+// with the line they were sent followed by a colon and their action.
+package acpc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Game describes the hold'em variant being played, as in an ACPC game
+// definition.  Blinds are posted by position, and positions rotate from
+// hand to hand.  Rounds are indexed from 0 for preflop to 3 for the river.
+type Game struct {
+	// NoLimit is set for no-limit games, which are played with a stack
+	// that is reset every hand.  Limit games have no stack.
+	NoLimit bool
+	Players int
+	Stack   int
+	Blinds  []int
+	// RaiseSizes are the fixed raise amounts of a limit game by round.
+	RaiseSizes []int
+	// FirstPlayer is the position that acts first in each round.
+	FirstPlayer []int
+	// MaxRaises caps the raises in each round of a limit game.  Zero or
+	// no caps means no cap.
+	MaxRaises []int
+}
+
+// HeadsUpLimit is the heads-up limit hold'em game of the competition.
+// Position 0 posts the big blind and position 1 is the button.
+var HeadsUpLimit = &Game{
+	Players:     2,
+	Blinds:      []int{10, 5},
+	RaiseSizes:  []int{10, 10, 20, 20},
+	FirstPlayer: []int{1, 0, 0, 0},
+	MaxRaises:   []int{3, 4, 4, 4},
+}
+
+// HeadsUpNoLimit is the heads-up no-limit hold'em game of the competition,
+// with 200 big blind stacks.
+var HeadsUpNoLimit = &Game{
+	NoLimit:     true,
+	Players:     2,
+	Stack:       20000,
+	Blinds:      []int{100, 50},
+	FirstPlayer: []int{1, 0, 0, 0},
+}
+
+// ThreePlayerLimit is the three player limit hold'em game of the
+// competition.  Position 0 posts the small blind, 1 the big blind, and 2
+// is the button.
+var ThreePlayerLimit = &Game{
+	Players:     3,
+	Blinds:      []int{5, 10, 0},
+	RaiseSizes:  []int{10, 10, 20, 20},
+	FirstPlayer: []int{2, 0, 0, 0},
+	MaxRaises:   []int{3, 4, 4, 4},
+}
+
+const rounds = 4
+
+// boardCards are the board cards dealt in each round.
+var boardCards = []int{0, 3, 1, 1}
+
+// bigBlind returns the largest blind.
+func (g *Game) bigBlind() int {
+	b := 0
+	for _, blind := range g.Blinds {
+		if blind > b {
+			b = blind
+		}
+	}
+	return b
+}
+
+// validate checks that the game is hold'em with sensible settings.
+func (g *Game) validate() error {
+	switch {
+	case g.Players < 2 || g.Players > 10:
+		return errors.New("acpc: games need two to ten players")
+	case len(g.Blinds) != g.Players:
+		return errors.New("acpc: need a blind for each player")
+	case len(g.FirstPlayer) != rounds:
+		return errors.New("acpc: need the first player of each round")
+	case g.bigBlind() <= 0:
+		return errors.New("acpc: need a blind")
+	}
+	for _, p := range g.FirstPlayer {
+		if p < 0 || p >= g.Players {
+			return errors.New("acpc: invalid first player")
+		}
+	}
+	if g.NoLimit {
+		if g.Stack < g.bigBlind() {
+			return errors.New("acpc: stacks must cover the blinds")
+		}
+		return nil
+	}
+	if len(g.RaiseSizes) != rounds {
+		return errors.New("acpc: limit games need a raise size for each round")
+	}
+	if len(g.MaxRaises) != 0 && len(g.MaxRaises) != rounds {
+		return errors.New("acpc: need a raise cap for each round")
+	}
+	return nil
+}
+
+// ParseGame reads an ACPC game definition, such as
+//
+//	GAMEDEF
+//	nolimit
+//	numPlayers = 2
+//	numRounds = 4
+//	stack = 20000 20000
+//	blind = 100 50
+//	firstPlayer = 2 1 1 1
+//	numSuits = 4
+//	numRanks = 13
+//	numHoleCards = 2
+//	numBoardCards = 0 3 1 1
+//	END GAMEDEF
+//
+// Only hold'em with a full deck and equal stacks is supported.  Players in
+// the file are numbered from 1 but positions in a Game from 0.
+func ParseGame(r io.Reader) (*Game, error) {
+	g := &Game{}
+	fixed := map[string][]int{
+		"numRounds":     {rounds},
+		"numSuits":      {4},
+		"numRanks":      {13},
+		"numHoleCards":  {2},
+		"numBoardCards": boardCards,
+	}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if i := strings.Index(line, "#"); i != -1 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch strings.ToLower(line) {
+		case "", "gamedef", "end gamedef":
+			continue
+		case "limit":
+			g.NoLimit = false
+			continue
+		case "nolimit":
+			g.NoLimit = true
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("acpc: invalid game definition line " + line)
+		}
+		key := strings.TrimSpace(parts[0])
+		var values []int
+		for _, f := range strings.Fields(parts[1]) {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("acpc: invalid value for %s: %s", key, f)
+			}
+			values = append(values, v)
+		}
+		if want, ok := fixed[key]; ok {
+			if fmt.Sprint(values) != fmt.Sprint(want) {
+				return nil, fmt.Errorf("acpc: only hold'em is supported, %s must be %v", key, want)
+			}
+			continue
+		}
+		switch key {
+		case "numPlayers":
+			if len(values) != 1 {
+				return nil, errors.New("acpc: invalid numPlayers")
+			}
+			g.Players = values[0]
+		case "stack":
+			for _, v := range values {
+				if v != values[0] {
+					return nil, errors.New("acpc: stacks must be equal")
+				}
+			}
+			if len(values) > 0 {
+				g.Stack = values[0]
+			}
+		case "blind":
+			g.Blinds = values
+		case "raiseSize":
+			g.RaiseSizes = values
+		case "firstPlayer":
+			g.FirstPlayer = nil
+			for _, v := range values {
+				g.FirstPlayer = append(g.FirstPlayer, v-1)
+			}
+		case "maxRaises":
+			g.MaxRaises = values
+		default:
+			return nil, errors.New("acpc: unknown game definition key " + key)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if !g.NoLimit {
+		g.Stack = 0
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,520 @@
+package acpc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+//go:generate stringer -type=ActionType -output=stringer_autogen.go
+
+// ActionType is the type of an action in the protocol.
+type ActionType int
+
+const (
+	// Fold gives up the hand.  Players may only fold facing a bet.
+	Fold ActionType = iota + 1
+
+	// Call checks or calls.
+	Call
+
+	// Raise bets or raises.
+	Raise
+)
+
+// Action is an action in the protocol.  Size is the total a player has put
+// in the hand after a no-limit raise and is zero for other actions and for
+// limit raises, whose size is fixed.
+type Action struct {
+	Type ActionType
+	Size int
+}
+
+// String returns the action as written in the protocol, such as "f", "c",
+// "r", or "r300".
+func (a Action) String() string {
+	switch a.Type {
+	case Fold:
+		return "f"
+	case Call:
+		return "c"
+	case Raise:
+		if a.Size > 0 {
+			return "r" + strconv.Itoa(a.Size)
+		}
+		return "r"
+	}
+	return a.Type.String()
+}
+
+// ParseAction parses an action written in the protocol.
+func ParseAction(s string) (Action, error) {
+	switch {
+	case s == "f":
+		return Action{Type: Fold}, nil
+	case s == "c":
+		return Action{Type: Call}, nil
+	case s == "r":
+		return Action{Type: Raise}, nil
+	case strings.HasPrefix(s, "r"):
+		size, err := strconv.Atoi(s[1:])
+		if err != nil || size <= 0 {
+			return Action{}, errors.New("acpc: invalid raise " + s)
+		}
+		return Action{Type: Raise, Size: size}, nil
+	}
+	return Action{}, errors.New("acpc: invalid action " + s)
+}
+
+// State is a hand as seen by the player in a position.  Hole holds each
+// position's hole cards, which are nil when they can't be seen, and Board
+// holds the board cards dealt so far.  The betting is changed with Apply.
+type State struct {
+	Game     *Game
+	Hand     int
+	Position int
+	Hole     [][]hand.Card
+	Board    []hand.Card
+
+	actions  [][]Action
+	spent    []int
+	start    []int
+	folded   []bool
+	acted    []bool
+	maxSpent int
+	minRaise int
+	raises   int
+	toAct    int
+	finished bool
+}
+
+// NewState returns the state at the start of a hand with the blinds
+// posted.
+func NewState(g *Game, handNumber, position int) *State {
+	n := g.Players
+	s := &State{
+		Game:     g,
+		Hand:     handNumber,
+		Position: position,
+		Hole:     make([][]hand.Card, n),
+		actions:  [][]Action{nil},
+		spent:    append([]int{}, g.Blinds...),
+		start:    make([]int, n),
+		folded:   make([]bool, n),
+		acted:    make([]bool, n),
+		maxSpent: g.bigBlind(),
+		minRaise: g.bigBlind(),
+	}
+	if g.NoLimit {
+		for i := range s.spent {
+			if s.spent[i] > g.Stack {
+				s.spent[i] = g.Stack
+			}
+		}
+	}
+	s.settle(g.FirstPlayer[0])
+	return s
+}
+
+// Round returns the betting round, from 0 for preflop to 3 for the river.
+func (s *State) Round() int {
+	return len(s.actions) - 1
+}
+
+// Actions returns the actions of each round so far.  It must not be
+// modified.
+func (s *State) Actions() [][]Action {
+	return s.actions
+}
+
+// ToAct returns the position to act, or -1 once the hand is over.
+func (s *State) ToAct() int {
+	if s.finished {
+		return -1
+	}
+	return s.toAct
+}
+
+// Finished reports whether the hand is over.
+func (s *State) Finished() bool {
+	return s.finished
+}
+
+// Folded reports whether a position folded.
+func (s *State) Folded(position int) bool {
+	return s.folded[position]
+}
+
+// Spent returns the chips a position has put in the hand.
+func (s *State) Spent(position int) int {
+	return s.spent[position]
+}
+
+// Showdown reports whether the hand ended with more than one player
+// showing their cards.
+func (s *State) Showdown() bool {
+	return s.finished && s.remaining() > 1
+}
+
+// RaiseRange returns the smallest and largest totals a player may raise
+// to, which are the same in limit games.  It returns false if the player
+// to act can't raise.
+func (s *State) RaiseRange() (min, max int, ok bool) {
+	if s.finished {
+		return 0, 0, false
+	}
+	g := s.Game
+	r := s.Round()
+	if !g.NoLimit {
+		if len(g.MaxRaises) > 0 && g.MaxRaises[r] > 0 && s.raises >= g.MaxRaises[r] {
+			return 0, 0, false
+		}
+		to := s.maxSpent + g.RaiseSizes[r]
+		return to, to, true
+	}
+	others := 0
+	for i := range s.spent {
+		if i != s.toAct && s.acting(i) {
+			others++
+		}
+	}
+	if others == 0 || g.Stack <= s.maxSpent {
+		return 0, 0, false
+	}
+	min = s.maxSpent + s.minRaise
+	if min > g.Stack {
+		min = g.Stack
+	}
+	return min, g.Stack, true
+}
+
+// Apply takes an action for the player to act.
+func (s *State) Apply(a Action) error {
+	if s.finished {
+		return errors.New("acpc: the hand is over")
+	}
+	p := s.toAct
+	switch a.Type {
+	case Fold:
+		if s.spent[p] == s.maxSpent {
+			return errors.New("acpc: can't fold with nothing to call")
+		}
+		s.folded[p] = true
+	case Call:
+		s.spent[p] = s.maxSpent
+	case Raise:
+		min, max, ok := s.RaiseRange()
+		if !ok {
+			return errors.New("acpc: can't raise")
+		}
+		to := a.Size
+		if !s.Game.NoLimit {
+			if to != 0 && to != min {
+				return errors.New("acpc: limit raises have a fixed size")
+			}
+			to, a.Size = min, 0
+		}
+		if to < min || to > max {
+			return fmt.Errorf("acpc: raise to %d isn't between %d and %d", to, min, max)
+		}
+		if to-s.maxSpent > s.minRaise {
+			s.minRaise = to - s.maxSpent
+		}
+		s.maxSpent = to
+		s.spent[p] = to
+		s.raises++
+		for i := range s.acted {
+			s.acted[i] = false
+		}
+	default:
+		return errors.New("acpc: invalid action")
+	}
+	s.acted[p] = true
+	r := s.Round()
+	s.actions[r] = append(s.actions[r], Action{Type: a.Type, Size: a.Size})
+	s.settle((p + 1) % s.Game.Players)
+	return nil
+}
+
+// settle moves on to the next player to act, searching from a position,
+// and starts the next rounds or finishes the hand as needed.
+func (s *State) settle(from int) {
+	for {
+		if s.remaining() == 1 {
+			s.finish()
+			return
+		}
+		if !s.roundOver() {
+			s.toAct = s.nextActing(from)
+			return
+		}
+		if s.Round() == rounds-1 {
+			s.finish()
+			return
+		}
+		s.actions = append(s.actions, nil)
+		copy(s.start, s.spent)
+		for i := range s.acted {
+			s.acted[i] = false
+		}
+		s.raises = 0
+		s.minRaise = s.Game.bigBlind()
+		from = s.Game.FirstPlayer[s.Round()]
+	}
+}
+
+func (s *State) finish() {
+	s.finished = true
+	s.toAct = -1
+}
+
+// roundOver reports whether everyone who can act has matched the biggest
+// bet, having acted unless nobody is left to act against.
+func (s *State) roundOver() bool {
+	acting := 0
+	for i := range s.spent {
+		if s.acting(i) {
+			acting++
+		}
+	}
+	for i := range s.spent {
+		if s.acting(i) && (s.spent[i] < s.maxSpent || !s.acted[i] && acting > 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// acting reports whether a position can still act, which players who
+// folded or are all in can't.
+func (s *State) acting(i int) bool {
+	return !s.folded[i] && !(s.Game.NoLimit && s.spent[i] == s.Game.Stack)
+}
+
+func (s *State) nextActing(from int) int {
+	n := s.Game.Players
+	for k := 0; k < n; k++ {
+		if i := (from + k) % n; s.acting(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *State) remaining() int {
+	n := 0
+	for _, f := range s.folded {
+		if !f {
+			n++
+		}
+	}
+	return n
+}
+
+// Values returns what each position won or lost in a finished hand.  The
+// hole cards of the players who showed down and the board must be known.
+// Odd chips of split pots go to the lowest positions.
+func (s *State) Values() ([]int, error) {
+	if !s.finished {
+		return nil, errors.New("acpc: the hand isn't over")
+	}
+	pot := 0
+	for _, spent := range s.spent {
+		pot += spent
+	}
+	var winners []int
+	if s.remaining() == 1 {
+		for i, f := range s.folded {
+			if !f {
+				winners = []int{i}
+			}
+		}
+	} else {
+		if len(s.Board) != 5 {
+			return nil, errors.New("acpc: the board isn't known")
+		}
+		var best *hand.Hand
+		for i, f := range s.folded {
+			if f {
+				continue
+			}
+			if len(s.Hole[i]) != 2 {
+				return nil, errors.New("acpc: hole cards of a showdown aren't known")
+			}
+			h := hand.New(append(append([]hand.Card{}, s.Hole[i]...), s.Board...))
+			c := 1
+			if best != nil {
+				c = h.CompareTo(best)
+			}
+			switch {
+			case c > 0:
+				best = h
+				winners = []int{i}
+			case c == 0:
+				winners = append(winners, i)
+			}
+		}
+	}
+	values := make([]int, len(s.spent))
+	for i, spent := range s.spent {
+		values[i] = -spent
+	}
+	for k, w := range winners {
+		values[w] += pot / len(winners)
+		if k < pot%len(winners) {
+			values[w]++
+		}
+	}
+	return values, nil
+}
+
+// view returns the state as seen from a position, with the hole cards of
+// others hidden unless they were shown down.
+func (s *State) view(position int) *State {
+	v := *s
+	v.Position = position
+	v.Hole = make([][]hand.Card, len(s.Hole))
+	for i, h := range s.Hole {
+		if i == position || s.Showdown() && !s.folded[i] {
+			v.Hole[i] = h
+		}
+	}
+	return &v
+}
+
+// String returns the state as a MATCHSTATE line without the line ending.
+func (s *State) String() string {
+	return fmt.Sprintf("MATCHSTATE:%d:%d:%s:%s", s.Position, s.Hand, s.betting(), s.cards())
+}
+
+func (s *State) betting() string {
+	b := &strings.Builder{}
+	for r, actions := range s.actions {
+		if r > 0 {
+			b.WriteString("/")
+		}
+		for _, a := range actions {
+			b.WriteString(a.String())
+		}
+	}
+	return b.String()
+}
+
+func (s *State) cards() string {
+	holes := make([]string, len(s.Hole))
+	for i, h := range s.Hole {
+		holes[i] = cards.Format(h)
+	}
+	b := &strings.Builder{}
+	b.WriteString(strings.Join(holes, "|"))
+	end := 0
+	for r := 1; r <= s.Round(); r++ {
+		start := end
+		end += boardCards[r]
+		if len(s.Board) < end {
+			break
+		}
+		b.WriteString("/" + cards.Format(s.Board[start:end]))
+	}
+	return b.String()
+}
+
+// ParseState parses a MATCHSTATE line of a game.
+func ParseState(g *Game, line string) (*State, error) {
+	parts := strings.Split(strings.TrimRight(line, "\r\n"), ":")
+	if len(parts) != 5 || parts[0] != "MATCHSTATE" {
+		return nil, errors.New("acpc: invalid match state " + line)
+	}
+	position, err := strconv.Atoi(parts[1])
+	if err != nil || position < 0 || position >= g.Players {
+		return nil, errors.New("acpc: invalid position " + parts[1])
+	}
+	handNumber, err := strconv.Atoi(parts[2])
+	if err != nil || handNumber < 0 {
+		return nil, errors.New("acpc: invalid hand number " + parts[2])
+	}
+	s := NewState(g, handNumber, position)
+	for r, betting := range strings.Split(parts[3], "/") {
+		// rounds with nobody left to bet are empty
+		if s.Round() < r || s.Round() > r && betting != "" {
+			return nil, errors.New("acpc: betting rounds don't match the actions " + parts[3])
+		}
+		for i := 0; i < len(betting); {
+			j := i + 1
+			if betting[i] == 'r' {
+				for j < len(betting) && betting[j] >= '0' && betting[j] <= '9' {
+					j++
+				}
+			}
+			a, err := ParseAction(betting[i:j])
+			if err != nil {
+				return nil, err
+			}
+			if err := s.Apply(a); err != nil {
+				return nil, err
+			}
+			i = j
+		}
+	}
+	if strings.Count(parts[3], "/") != s.Round() {
+		return nil, errors.New("acpc: betting rounds don't match the actions " + parts[3])
+	}
+	dealt := strings.Split(parts[4], "/")
+	holes := strings.Split(dealt[0], "|")
+	if len(holes) != g.Players {
+		return nil, errors.New("acpc: need hole cards for each position " + parts[4])
+	}
+	for i, h := range holes {
+		if s.Hole[i], err = parseCards(h); err != nil {
+			return nil, err
+		}
+		if len(s.Hole[i]) != 0 && len(s.Hole[i]) != 2 {
+			return nil, errors.New("acpc: invalid hole cards " + h)
+		}
+		if len(s.Hole[i]) == 0 {
+			s.Hole[i] = nil
+		}
+	}
+	if len(dealt)-1 > s.Round() {
+		return nil, errors.New("acpc: board cards for rounds not reached " + parts[4])
+	}
+	for r, board := range dealt[1:] {
+		c, err := parseCards(board)
+		if err != nil {
+			return nil, err
+		}
+		if len(c) != boardCards[r+1] {
+			return nil, errors.New("acpc: wrong number of board cards " + board)
+		}
+		s.Board = append(s.Board, c...)
+	}
+	return s, nil
+}
+
+// parseCards parses cards the way the protocol writes them, such as
+// "AsKd".
+func parseCards(s string) ([]hand.Card, error) {
+	cs, err := cards.Parse(s)
+	if err != nil {
+		return nil, errors.New("acpc: invalid cards " + s)
+	}
+	return cs, nil
+}
+
+// limitStack is the most a player can put in a hand of a limit game, or
+// math.MaxInt32 if raises aren't capped.
+func (g *Game) limitStack() int {
+	total := g.bigBlind()
+	for r, size := range g.RaiseSizes {
+		if len(g.MaxRaises) == 0 || g.MaxRaises[r] <= 0 {
+			return math.MaxInt32
+		}
+		total += g.MaxRaises[r] * size
+	}
+	return total
+}
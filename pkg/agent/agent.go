@@ -0,0 +1,110 @@
+// Package agent defines automated players for tables run by pkg/table and
+// provides reference agents to benchmark strategies against.
+package agent
+
+import (
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/table"
+)
+
+// An Agent chooses actions for a seat.  Act is called with the view of the
+// table from the agent's seat when it's the agent's turn and returns one
+// of the actions in the view's options.
+type Agent interface {
+	Act(v table.View) table.Action
+}
+
+// AgentFunc is a function that acts as an Agent.
+type AgentFunc func(v table.View) table.Action
+
+// Act calls f(v).
+func (f AgentFunc) Act(v table.View) table.Action {
+	return f(v)
+}
+
+// Random returns an agent that takes a random legal action, never folding
+// when it could check, and raises a random amount.
+func Random(r *rand.Rand) Agent {
+	return AgentFunc(func(v table.View) table.Action {
+		o := v.Options
+		actions := []table.ActionType{}
+		for _, a := range o.Actions {
+			if a != table.Fold || o.ToCall > 0 {
+				actions = append(actions, a)
+			}
+		}
+		a := table.Action{Type: actions[r.Intn(len(actions))]}
+		if a.Type == table.Bet || a.Type == table.Raise {
+			a.Amount = o.MinRaise + r.Intn(o.MaxRaise-o.MinRaise+1)
+		}
+		return a
+	})
+}
+
+// CallingStation returns an agent that checks or calls every time.
+func CallingStation() Agent {
+	return AgentFunc(func(v table.View) table.Action {
+		return passive(v.Options)
+	})
+}
+
+// passive returns a check if possible and a call otherwise.
+func passive(o *table.Options) table.Action {
+	if o.ToCall == 0 {
+		return table.Action{Type: table.Check}
+	}
+	return table.Action{Type: table.Call}
+}
+
+// raise returns a bet or raise to the amount, kept within the allowed
+// amounts, or a check or call if raising isn't allowed.
+func raise(o *table.Options, amount int) table.Action {
+	if o.MaxRaise == 0 {
+		return passive(o)
+	}
+	if amount < o.MinRaise {
+		amount = o.MinRaise
+	}
+	if amount > o.MaxRaise {
+		amount = o.MaxRaise
+	}
+	return table.Action{Type: o.Actions[len(o.Actions)-1], Amount: amount}
+}
+
+// foldOrCheck returns a check if possible and a fold otherwise.
+func foldOrCheck(o *table.Options) table.Action {
+	if o.ToCall == 0 {
+		return table.Action{Type: table.Check}
+	}
+	return table.Action{Type: table.Fold}
+}
+
+// currentBet returns the largest bet in the round.
+func currentBet(v table.View) int {
+	bet := 0
+	for _, p := range v.Players {
+		if p.Bet > bet {
+			bet = p.Bet
+		}
+	}
+	return bet
+}
+
+// opponents returns the number of other players still in the hand.
+func opponents(v table.View) int {
+	n := 0
+	for i, p := range v.Players {
+		if i != v.Seat && p.InHand && !p.Folded {
+			n++
+		}
+	}
+	return n
+}
+
+// potRaise returns the amount of a raise to a fraction of the pot after
+// calling.
+func potRaise(v table.View, fraction float64) int {
+	bet := currentBet(v)
+	return bet + int(fraction*float64(v.Pot+v.Options.ToCall))
+}
@@ -0,0 +1,94 @@
+package agent_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/table"
+)
+
+func TestLegal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	agents := map[string]func() agent.Agent{
+		"random":  func() agent.Agent { return agent.Random(r) },
+		"calling": agent.CallingStation,
+		"tag":     agent.TAG,
+		"equity":  func() agent.Agent { return agent.Equity(r, 50) },
+	}
+	for name, newAgent := range agents {
+		for _, seats := range []int{2, 6, 10} {
+			tbl, _ := table.New(seats, table.WithDealer(hand.NewDealer(r)))
+			players := []agent.Agent{}
+			for i := 0; i < seats; i++ {
+				tbl.Sit(i, fmt.Sprint("p", i), 100)
+				players = append(players, newAgent())
+			}
+			for n := 0; n < 10 && tbl.Deal() == nil; n++ {
+				for tbl.Active() {
+					seat := tbl.ToAct()
+					a := players[seat].Act(tbl.View(seat))
+					if err := tbl.Act(seat, a); err != nil {
+						t.Fatalf("%s agent took invalid action %v: %v", name, a, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// view returns a view for seat 0 holding hole facing a bet of toCall
+// into a pot.
+func view(hole, board []hand.Card, pot, toCall int) table.View {
+	o := &table.Options{ToCall: toCall, MinRaise: 2 * toCall, MaxRaise: 200}
+	if toCall == 0 {
+		o.Actions = []table.ActionType{table.Fold, table.Check, table.Bet}
+		o.MinRaise = 2
+	} else {
+		o.Actions = []table.ActionType{table.Fold, table.Call, table.Raise}
+	}
+	return table.View{
+		Seat:       0,
+		Active:     true,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Board:      board,
+		Pot:        pot,
+		Players: []table.PlayerView{
+			{Chips: 200, InHand: true, Cards: hole},
+			{Chips: 200 - toCall, Bet: toCall, InHand: true},
+		},
+		Options: o,
+	}
+}
+
+func TestDecisions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name  string
+		agent agent.Agent
+		v     table.View
+		want  table.ActionType
+	}{
+		{"tag opens aces", agent.TAG(), view(Cards("As", "Ad"), nil, 3, 1), table.Raise},
+		{"tag folds trash to a raise", agent.TAG(), view(Cards("7s", "2d"), nil, 8, 6), table.Fold},
+		{"tag reraises kings", agent.TAG(), view(Cards("Ks", "Kd"), nil, 8, 6), table.Raise},
+		{"tag bets a set", agent.TAG(), view(Cards("7s", "7d"), Cards("7c", "Kd", "2h"), 12, 0), table.Bet},
+		{"tag checks air", agent.TAG(), view(Cards("4s", "5d"), Cards("Ac", "Kd", "Jh"), 12, 0), table.Check},
+		{"tag calls top pair", agent.TAG(), view(Cards("Ks", "Qd"), Cards("Kc", "8d", "2h"), 12, 6), table.Call},
+		{"tag folds air to a bet", agent.TAG(), view(Cards("4s", "5d"), Cards("Ac", "Kd", "Jh"), 12, 12), table.Fold},
+		{"equity raises the nuts", agent.Equity(r, 100), view(Cards("As", "Ad"), Cards("Ac", "Ah", "2d"), 12, 6), table.Raise},
+		{"equity folds trash", agent.Equity(r, 100), view(Cards("3s", "4d"), Cards("Ac", "Kh", "Qd", "9c"), 40, 40), table.Fold},
+		{"equity calls a draw getting a price", agent.Equity(r, 200), view(Cards("8h", "9h"), Cards("Th", "Jh", "2c"), 100, 10), table.Call},
+		{"calling station calls", agent.CallingStation(), view(Cards("3s", "4d"), nil, 100, 100), table.Call},
+		{"calling station checks", agent.CallingStation(), view(Cards("3s", "4d"), nil, 4, 0), table.Check},
+	}
+	for _, test := range tests {
+		if a := test.agent.Act(test.v); a.Type != test.want {
+			t.Fatalf("%s: got %v; want %v", test.name, a, test.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Equity returns an agent that acts on its equity against random hands for
+// each opponent still in the hand.  Before the flop the equity comes from
+// the preflop equity table and after it, or with more players than the
+// table has, from sampling the given number of runouts.  The agent raises
+// when its equity is well above its fair share of the pot, calls when
+// it's above the pot odds, and folds otherwise.
+func Equity(r *rand.Rand, samples int) Agent {
+	return AgentFunc(func(v table.View) table.Action {
+		o := v.Options
+		me := v.Players[v.Seat]
+		n := opponents(v)
+		var eq float64
+		if len(v.Board) == 0 && n+1 <= preflop.MaxPlayers {
+			eq = preflop.VsRandom(me.Cards, n+1)
+		} else {
+			ranges := [][][]hand.Card{{me.Cards}}
+			for i := 0; i < n; i++ {
+				ranges = append(ranges, allCombos)
+			}
+			res, err := equity.Ranges(ranges, v.Board, equity.WithRand(r), equity.Iterations(samples))
+			if err != nil {
+				return passive(o)
+			}
+			eq = res.Equity[0]
+		}
+		share := eq * float64(n+1)
+		odds := float64(o.ToCall) / float64(v.Pot+o.ToCall)
+		switch {
+		case share >= 1.5:
+			return raise(o, potRaise(v, share-1))
+		case o.ToCall == 0 || eq > odds:
+			return passive(o)
+		}
+		return table.Action{Type: table.Fold}
+	})
+}
+
+// allCombos holds every two card combination.
+var allCombos = func() [][]hand.Card {
+	cards := hand.Cards()
+	combos := [][]hand.Card{}
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			combos = append(combos, []hand.Card{cards[i], cards[j]})
+		}
+	}
+	return combos
+}()
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+	"github.com/notnil/joker/pkg/table"
+)
+
+var (
+	openRange, _     = preflop.ParseRange("22+,A2s+,K9s+,QTs+,J9s+,T9s,98s,87s,ATo+,KJo+,QJo")
+	threeBetRange, _ = preflop.ParseRange("QQ+,AKs,AKo")
+	callRange, _     = preflop.ParseRange("JJ-66,AQs-ATs,KQs,KJs,QJs,JTs,AQo")
+)
+
+// TAG returns a tight-aggressive rule-based agent.  Before the flop it
+// raises hands in a fixed opening range by starting hand class, re-raises
+// only premium hands, and calls raises with a narrow range when the price
+// is small.  After the flop it bets and raises two pair or better, bets top
+// pair when checked to and calls one bet with it, and gives up otherwise.
+func TAG() Agent {
+	return AgentFunc(tag)
+}
+
+func tag(v table.View) table.Action {
+	o := v.Options
+	me := v.Players[v.Seat]
+	if len(v.Board) == 0 {
+		class := preflop.ClassOf(me.Cards[0], me.Cards[1])
+		bet := currentBet(v)
+		switch {
+		case bet <= v.BigBlind && openRange.Contains(class):
+			limpers := 0
+			for i, p := range v.Players {
+				if i != v.Seat && p.Bet == v.BigBlind {
+					limpers++
+				}
+			}
+			// the big blind is counted as a limper
+			return raise(o, 3*v.BigBlind+(limpers-1)*v.BigBlind)
+		case bet > v.BigBlind && threeBetRange.Contains(class):
+			return raise(o, 3*bet)
+		case bet > v.BigBlind && callRange.Contains(class) && o.ToCall*10 <= me.Chips+me.Bet:
+			return passive(o)
+		}
+		return foldOrCheck(o)
+	}
+	switch made(me.Cards, v.Board) {
+	case strong:
+		return raise(o, potRaise(v, 0.75))
+	case medium:
+		if o.ToCall == 0 {
+			return raise(o, potRaise(v, 0.5))
+		}
+		if o.ToCall <= v.Pot/2+1 {
+			return passive(o)
+		}
+	}
+	return foldOrCheck(o)
+}
+
+type strength int
+
+const (
+	weak strength = iota
+	medium
+	strong
+)
+
+// made returns how strong the hand the hole cards make with the board is,
+// not counting what the board makes on its own.
+func made(hole, board []hand.Card) strength {
+	h := hand.New(append(append([]hand.Card{}, hole...), board...))
+	b := hand.New(board)
+	if h.Ranking() >= hand.TwoPair && h.Ranking() > b.Ranking() {
+		return strong
+	}
+	if h.Ranking() == hand.Pair && b.Ranking() == hand.HighCard {
+		top := hand.Two
+		for _, c := range board {
+			if c.Rank() > top {
+				top = c.Rank()
+			}
+		}
+		// top pair or an overpair
+		if h.Cards()[0].Rank() >= top {
+			return medium
+		}
+	}
+	return weak
+}
@@ -0,0 +1,242 @@
+// Package board analyzes the texture of hold'em community cards.
+package board
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+//go:generate stringer -type=Pairing,Suitedness -output=stringer_autogen.go
+
+// Pairing describes how the ranks of a board are paired.
+type Pairing int
+
+const (
+	// Unpaired boards have no two cards of the same rank.
+	// Ex: K♠ 9♦ 4♣
+	Unpaired Pairing = iota + 1
+
+	// Paired boards have a single pair.
+	// Ex: K♠ K♦ 4♣
+	Paired
+
+	// TwoPaired boards have two pairs.
+	// Ex: K♠ K♦ 4♣ 4♥
+	TwoPaired
+
+	// Trips boards have three cards of the same rank.
+	// Ex: K♠ K♦ K♣
+	Trips
+
+	// FullHouseBoard boards have three cards of one rank and two of another.
+	// Ex: K♠ K♦ K♣ 4♣ 4♥
+	FullHouseBoard
+
+	// Quads boards have four cards of the same rank.
+	// Ex: K♠ K♦ K♣ K♥
+	Quads
+)
+
+// Suitedness describes how the suits of a board are distributed.
+type Suitedness int
+
+const (
+	// Rainbow boards have no two cards of the same suit.
+	// Ex: K♠ 9♦ 4♣
+	Rainbow Suitedness = iota + 1
+
+	// TwoTone boards have at most two cards of any suit so a flush
+	// can't be made yet, but flush draws are possible.
+	// Ex: K♠ 9♠ 4♣
+	TwoTone
+
+	// Monotone boards have three or more cards of the same suit so a
+	// flush is possible.  On the flop this means all three cards share
+	// a suit.
+	// Ex: K♠ 9♠ 4♠
+	Monotone
+)
+
+// Texture is the result of analyzing a board.
+type Texture struct {
+	// Cards are the cards of the board.
+	Cards []hand.Card
+
+	// Pairing is how the ranks of the board are paired.
+	Pairing Pairing
+
+	// Suitedness is how the suits of the board are distributed.
+	Suitedness Suitedness
+
+	// Connectedness is the largest number of distinct board ranks that
+	// fit within a single five rank straight window (ace may be high or
+	// low).  A connectedness of three or more means a straight is possible.
+	Connectedness int
+
+	// StraightPossible is true if any two hole cards can make a straight.
+	StraightPossible bool
+
+	// FlushPossible is true if any two hole cards can make a flush.
+	FlushPossible bool
+
+	// Nuts is the best hand that can be made with the board.
+	Nuts *hand.Hand
+
+	// NutCombos are the hole card combinations that make the nuts.
+	NutCombos [][]hand.Card
+
+	// Combos is the number of hole card combinations that make each
+	// ranking.  Combos that contain board cards are not counted.
+	Combos map[hand.Ranking]int
+
+	// TotalCombos is the number of hole card combinations considered.
+	TotalCombos int
+}
+
+// Analyze returns the texture of a board of three to five cards.  Every
+// possible pair of hole cards is combined with the board and evaluated
+// to find the nuts and count the combinations that make each ranking.
+func Analyze(cards []hand.Card) (*Texture, error) {
+	if len(cards) < 3 || len(cards) > 5 {
+		return nil, errors.New("board: board must have three to five cards")
+	}
+	if hasDuplicates(cards) {
+		return nil, errors.New("board: board has duplicate cards")
+	}
+	t := &Texture{
+		Cards:         append([]hand.Card{}, cards...),
+		Pairing:       pairing(cards),
+		Suitedness:    suitedness(cards),
+		Connectedness: connectedness(cards),
+		Combos:        map[hand.Ranking]int{},
+	}
+	t.StraightPossible = t.Connectedness >= 3
+	t.FlushPossible = maxSuitCount(cards) >= 3
+
+	remaining := remainingCards(cards)
+	for _, combo := range util.Combinations(len(remaining), 2) {
+		hole := []hand.Card{remaining[combo[0]], remaining[combo[1]]}
+		h := hand.New(append(hole, cards...))
+		t.Combos[h.Ranking()]++
+		t.TotalCombos++
+
+		cmp := 1
+		if t.Nuts != nil {
+			cmp = h.CompareTo(t.Nuts)
+		}
+		switch {
+		case cmp > 0:
+			t.Nuts = h
+			t.NutCombos = [][]hand.Card{hole}
+		case cmp == 0:
+			t.NutCombos = append(t.NutCombos, hole)
+		}
+	}
+	return t, nil
+}
+
+// remainingCards returns the cards of a standard deck that aren't in
+// the given cards, ordered as in hand.Cards.
+func remainingCards(cards []hand.Card) []hand.Card {
+	used := map[hand.Card]bool{}
+	for _, c := range cards {
+		used[c] = true
+	}
+	remaining := []hand.Card{}
+	for _, c := range hand.Cards() {
+		if !used[c] {
+			remaining = append(remaining, c)
+		}
+	}
+	return remaining
+}
+
+func hasDuplicates(cards []hand.Card) bool {
+	seen := map[hand.Card]bool{}
+	for _, c := range cards {
+		if seen[c] {
+			return true
+		}
+		seen[c] = true
+	}
+	return false
+}
+
+func pairing(cards []hand.Card) Pairing {
+	counts := map[hand.Rank]int{}
+	for _, c := range cards {
+		counts[c.Rank()]++
+	}
+	groups := []int{}
+	for _, n := range counts {
+		if n > 1 {
+			groups = append(groups, n)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(groups)))
+	switch {
+	case len(groups) == 0:
+		return Unpaired
+	case groups[0] == 4:
+		return Quads
+	case groups[0] == 3 && len(groups) > 1:
+		return FullHouseBoard
+	case groups[0] == 3:
+		return Trips
+	case len(groups) > 1:
+		return TwoPaired
+	}
+	return Paired
+}
+
+func suitedness(cards []hand.Card) Suitedness {
+	switch n := maxSuitCount(cards); {
+	case n >= 3:
+		return Monotone
+	case n == 2:
+		return TwoTone
+	}
+	return Rainbow
+}
+
+func maxSuitCount(cards []hand.Card) int {
+	counts := map[hand.Suit]int{}
+	max := 0
+	for _, c := range cards {
+		counts[c.Suit()]++
+		if counts[c.Suit()] > max {
+			max = counts[c.Suit()]
+		}
+	}
+	return max
+}
+
+// connectedness returns the most distinct ranks found in any straight
+// window.  Windows are indexed by their lowest rank where -1 is the
+// wheel's ace.
+func connectedness(cards []hand.Card) int {
+	has := map[int]bool{}
+	for _, c := range cards {
+		r := int(c.Rank())
+		has[r] = true
+		if c.Rank() == hand.Ace {
+			has[-1] = true
+		}
+	}
+	max := 0
+	for low := -1; low <= int(hand.Ten); low++ {
+		n := 0
+		for r := low; r < low+5; r++ {
+			if has[r] {
+				n++
+			}
+		}
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
@@ -0,0 +1,139 @@
+package board_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/board"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+type textureTest struct {
+	cards         []hand.Card
+	pairing       board.Pairing
+	suitedness    board.Suitedness
+	connectedness int
+	straight      bool
+	flush         bool
+	nuts          hand.Ranking
+	nutCombos     int
+}
+
+var textureTests = []textureTest{
+	{
+		cards:         Cards("Ks", "9d", "4c"),
+		pairing:       board.Unpaired,
+		suitedness:    board.Rainbow,
+		connectedness: 2,
+		nuts:          hand.ThreeOfAKind,
+		nutCombos:     3,
+	},
+	{
+		cards:         Cards("As", "Ks", "Qs"),
+		pairing:       board.Unpaired,
+		suitedness:    board.Monotone,
+		connectedness: 3,
+		straight:      true,
+		flush:         true,
+		nuts:          hand.RoyalFlush,
+		nutCombos:     1,
+	},
+	{
+		cards:         Cards("9h", "8h", "7c"),
+		pairing:       board.Unpaired,
+		suitedness:    board.TwoTone,
+		connectedness: 3,
+		straight:      true,
+		nuts:          hand.Straight,
+		nutCombos:     16,
+	},
+	{
+		cards:         Cards("7s", "7d", "2c"),
+		pairing:       board.Paired,
+		suitedness:    board.Rainbow,
+		connectedness: 1,
+		nuts:          hand.FourOfAKind,
+		nutCombos:     1,
+	},
+	{
+		cards:         Cards("As", "2d", "3c", "Jh"),
+		pairing:       board.Unpaired,
+		suitedness:    board.Rainbow,
+		connectedness: 3,
+		straight:      true,
+		nuts:          hand.Straight,
+		nutCombos:     16,
+	},
+	{
+		cards:         Cards("Qs", "Qd", "Qc", "3h", "3d"),
+		pairing:       board.FullHouseBoard,
+		suitedness:    board.TwoTone,
+		connectedness: 1,
+		nuts:          hand.FourOfAKind,
+		nutCombos:     4,
+	},
+}
+
+func TestAnalyze(t *testing.T) {
+	for _, test := range textureTests {
+		tex, err := board.Analyze(test.cards)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tex.Pairing != test.pairing {
+			t.Fatalf("%v pairing = %v; want %v", test.cards, tex.Pairing, test.pairing)
+		}
+		if tex.Suitedness != test.suitedness {
+			t.Fatalf("%v suitedness = %v; want %v", test.cards, tex.Suitedness, test.suitedness)
+		}
+		if tex.Connectedness != test.connectedness {
+			t.Fatalf("%v connectedness = %d; want %d", test.cards, tex.Connectedness, test.connectedness)
+		}
+		if tex.StraightPossible != test.straight {
+			t.Fatalf("%v straight possible = %v; want %v", test.cards, tex.StraightPossible, test.straight)
+		}
+		if tex.FlushPossible != test.flush {
+			t.Fatalf("%v flush possible = %v; want %v", test.cards, tex.FlushPossible, test.flush)
+		}
+		if tex.Nuts.Ranking() != test.nuts {
+			t.Fatalf("%v nuts = %v; want %v", test.cards, tex.Nuts, test.nuts)
+		}
+		if len(tex.NutCombos) != test.nutCombos {
+			t.Fatalf("%v nut combos = %d; want %d", test.cards, len(tex.NutCombos), test.nutCombos)
+		}
+		total := 0
+		for _, n := range tex.Combos {
+			total += n
+		}
+		if total != tex.TotalCombos {
+			t.Fatalf("%v combos sum to %d; want %d", test.cards, total, tex.TotalCombos)
+		}
+	}
+}
+
+func TestAnalyzeCombos(t *testing.T) {
+	tex, err := board.Analyze(Cards("Ks", "9d", "4c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tex.TotalCombos != 1176 {
+		t.Fatalf("total combos = %d; want %d", tex.TotalCombos, 1176)
+	}
+	// sets: 3 pocket pairs of board ranks with 3 combos each
+	if n := tex.Combos[hand.ThreeOfAKind]; n != 9 {
+		t.Fatalf("three of a kind combos = %d; want %d", n, 9)
+	}
+}
+
+func TestAnalyzeInvalid(t *testing.T) {
+	invalid := [][]hand.Card{
+		Cards("Ks", "9d"),
+		Cards("Ks", "9d", "4c", "2h", "3h", "5h"),
+		Cards("Ks", "Ks", "4c"),
+	}
+	for _, cards := range invalid {
+		if _, err := board.Analyze(cards); err == nil {
+			t.Fatalf("expected error for board %v", cards)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package cards reads and writes cards as short text, such as "As" or
+// "A♠", and checks the cards given to commands and APIs before hands are
+// made from them.
+package cards
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+var suits = map[string]string{
+	"s": "♠", "h": "♥", "d": "♦", "c": "♣",
+	"♠": "♠", "♥": "♥", "♦": "♦", "♣": "♣",
+}
+
+// Parse parses runs of cards such as "AhKh", "2c 7d 9h", or "A♠,K♠".
+// Ranks and suits may be upper or lower case.  Parse returns an error if
+// a card is given more than once.
+func Parse(s string) ([]hand.Card, error) {
+	cs := []hand.Card{}
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		for field != "" {
+			if len(field) < 2 {
+				return nil, errors.New("cards: invalid card " + field)
+			}
+			_, size := utf8.DecodeRuneInString(field[1:])
+			c, err := parse(field[:1+size])
+			if err != nil {
+				return nil, err
+			}
+			cs = append(cs, c)
+			field = field[1+size:]
+		}
+	}
+	if dup := duplicate(cs); dup != nil {
+		return nil, errors.New("cards: the " + dup.String() + " is used more than once")
+	}
+	return cs, nil
+}
+
+// parse parses a single card.
+func parse(text string) (hand.Card, error) {
+	var c hand.Card
+	suit, ok := suits[strings.ToLower(text[1:])]
+	if !ok {
+		return c, errors.New("cards: invalid card " + text)
+	}
+	if err := c.UnmarshalText([]byte(strings.ToUpper(text[:1]) + suit)); err != nil {
+		return c, errors.New("cards: invalid card " + text)
+	}
+	return c, nil
+}
+
+// Format writes cards in the short form "AhKh".
+func Format(cs []hand.Card) string {
+	b := &strings.Builder{}
+	for _, c := range cs {
+		b.WriteString(c.Rank().String())
+		b.WriteString("shdc"[c.Suit() : c.Suit()+1])
+	}
+	return b.String()
+}
+
+// List is a list of cards whose JSON encoding is an array of cards such
+// as ["As","K♠"], in either form.  It is written the same way as
+// []hand.Card.
+type List []hand.Card
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *List) UnmarshalJSON(b []byte) error {
+	var texts []string
+	if err := json.Unmarshal(b, &texts); err != nil {
+		return err
+	}
+	if texts == nil {
+		return nil
+	}
+	cs := List{}
+	for _, text := range texts {
+		if len(text) < 2 {
+			return errors.New("cards: invalid card " + text)
+		}
+		c, err := parse(text)
+		if err != nil {
+			return err
+		}
+		cs = append(cs, c)
+	}
+	*l = cs
+	return nil
+}
+
+// Check returns an error unless cs has between min and max cards and none
+// of them twice.  What names the cards in the error.
+func Check(cs []hand.Card, min, max int, what string) error {
+	if len(cs) < min || len(cs) > max {
+		return errors.New(what + " has the wrong number of cards")
+	}
+	if dup := duplicate(cs); dup != nil {
+		return errors.New(what + " has the " + dup.String() + " more than once")
+	}
+	return nil
+}
+
+// duplicate returns a card that is in cs more than once or nil.
+func duplicate(cs []hand.Card) *hand.Card {
+	seen := map[hand.Card]bool{}
+	for _, c := range cs {
+		if seen[c] {
+			return &c
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// Rules returns the options hands are made with under a configuration,
+// which may be nil for the default rules.
+func Rules(c *hand.Config) []func(*hand.Config) {
+	if c == nil {
+		return nil
+	}
+	config := *c
+	return []func(*hand.Config){func(hc *hand.Config) { *hc = config }}
+}
+
+// Sorting returns the sorting of a configuration, which is high if the
+// configuration is nil.
+func Sorting(c *hand.Config) hand.Sorting {
+	if c == nil {
+		return hand.SortingHigh
+	}
+	return c.Sorting()
+}
+
+// Winners returns the indexes of the best hands under a sorting.
+func Winners(hands []*hand.Hand, s hand.Sorting) []int {
+	w := []int{}
+	if len(hands) == 0 {
+		return w
+	}
+	best := hand.Sort(s, hand.DESC, hands...)[0]
+	for i, h := range hands {
+		if h.CompareTo(best) == 0 {
+			w = append(w, i)
+		}
+	}
+	return w
+}
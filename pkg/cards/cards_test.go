@@ -0,0 +1,97 @@
+package cards_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/notnil/joker/pkg/cards"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+		err  bool
+	}{
+		{"AhKh", "AhKh", false},
+		{"2c 7d 9h", "2c7d9h", false},
+		{"A♠,K♠", "AsKs", false},
+		{"tD as", "TdAs", false},
+		{"", "", false},
+		{"AhAh", "", true},
+		{"Zz", "", true},
+		{"A", "", true},
+		{"AhK", "", true},
+	}
+	for _, test := range tests {
+		cs, err := cards.Parse(test.text)
+		if test.err {
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error", test.text)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", test.text, err)
+		}
+		if got := cards.Format(cs); got != test.want {
+			t.Fatalf("Parse(%q) = %s; want %s", test.text, got, test.want)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	var l cards.List
+	if err := json.Unmarshal([]byte(`["As","K♠","td"]`), &l); err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 3 || l[0] != hand.AceSpades || l[1] != hand.KingSpades || l[2] != hand.TenDiamonds {
+		t.Fatalf("list = %v", l)
+	}
+	b, _ := json.Marshal(l)
+	if string(b) != `["A♠","K♠","T♦"]` {
+		t.Fatalf("list = %s", b)
+	}
+	var empty, null cards.List
+	if err := json.Unmarshal([]byte(`[]`), &empty); err != nil || empty == nil {
+		t.Fatalf("empty list = %v, %v", empty, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &null); err != nil || null != nil {
+		t.Fatalf("null list = %v, %v", null, err)
+	}
+	for _, bad := range []string{`["Zz"]`, `["A"]`, `"As"`} {
+		if err := json.Unmarshal([]byte(bad), &l); err == nil {
+			t.Fatalf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cs, _ := cards.Parse("AsKsQs")
+	if err := cards.Check(cs, 1, 7, "hand"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cards.Check(cs, 4, 4, "hand"); err == nil {
+		t.Fatal("expected error for the wrong number of cards")
+	}
+	if err := cards.Check(append(cs, hand.AceSpades), 1, 7, "hand"); err == nil {
+		t.Fatal("expected error for a duplicate card")
+	}
+}
+
+func TestWinners(t *testing.T) {
+	a, _ := cards.Parse("AsAd3c4h5s")
+	b, _ := cards.Parse("7s5d4c3h2c")
+	c, _ := cards.Parse("AcAh3d4s5h")
+	high := []*hand.Hand{hand.New(a), hand.New(b), hand.New(c)}
+	if w := cards.Winners(high, cards.Sorting(nil)); len(w) != 2 || w[0] != 0 || w[1] != 2 {
+		t.Fatalf("high winners = %v", w)
+	}
+	config := &hand.Config{}
+	hand.Low(config)
+	low := []*hand.Hand{hand.New(a, cards.Rules(config)...), hand.New(b, cards.Rules(config)...)}
+	if w := cards.Winners(low, cards.Sorting(config)); len(w) != 1 || w[0] != 1 {
+		t.Fatalf("low winners = %v", w)
+	}
+}
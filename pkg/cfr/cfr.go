@@ -0,0 +1,291 @@
+// Package cfr computes approximate equilibrium strategies for two player
+// zero-sum games with counterfactual regret minimization.
+//
+// Games are described by the Node interface.  Vanilla CFR and CFR+
+// traverse the whole game tree each iteration, while Monte Carlo CFR with
+// external sampling samples chance and opponent actions and can handle
+// games that are too large to traverse, such as hold'em.
+package cfr
+
+import (
+	"math/rand"
+)
+
+// Chance is the player of chance nodes.
+const Chance = -1
+
+// A Node is a history in a two player zero-sum game.  Players are
+// numbered 0 and 1.
+type Node interface {
+	// Terminal returns true if the game is over.
+	Terminal() bool
+
+	// Utility returns the payoff of player 0 at a terminal node.  The
+	// payoff of player 1 is its negation.
+	Utility() float64
+
+	// Player returns the acting player or Chance.
+	Player() int
+
+	// InfoSet returns a key that is the same for all the nodes the acting
+	// player can't tell apart.
+	InfoSet() string
+
+	// NumActions returns the number of actions or chance outcomes.
+	NumActions() int
+
+	// Probability returns the probability of a chance outcome.
+	Probability(a int) float64
+
+	// Child returns the node after the action or chance outcome.
+	Child(a int) Node
+}
+
+// Variant is the variant of CFR used by a Solver.
+type Variant int
+
+const (
+	// Vanilla is the original CFR algorithm of Zinkevich et al.
+	Vanilla Variant = iota + 1
+
+	// Plus is CFR+ by Tammelin which floors regrets at zero, alternates
+	// player updates, and weights the average strategy by iteration.
+	Plus
+
+	// ExternalSampling is Monte Carlo CFR by Lanctot et al. which samples
+	// chance and opponent actions and explores all of the traversing
+	// player's actions.
+	ExternalSampling
+)
+
+// Config represents the configuration options of a Solver.
+type Config struct {
+	variant Variant
+	r       *rand.Rand
+}
+
+// WithVariant configures the variant of CFR.  The default is Vanilla.
+func WithVariant(v Variant) func(*Config) {
+	return func(c *Config) {
+		c.variant = v
+	}
+}
+
+// WithRand configures the random source used by ExternalSampling.  The
+// default is a source seeded with zero.
+func WithRand(r *rand.Rand) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+	}
+}
+
+// A Solver runs CFR iterations on a game and accumulates the average
+// strategy.
+type Solver struct {
+	root      func() Node
+	config    Config
+	infoSets  map[string]*infoSet
+	iteration int
+	pass      int
+}
+
+type infoSet struct {
+	regrets     []float64
+	strategySum []float64
+	current     []float64
+	pass        int
+}
+
+// New returns a solver for the game starting at the node returned by
+// root.  Root is called at the start of each traversal so that games
+// which deal cards at the root can deal a new deck.
+func New(root func() Node, options ...func(*Config)) *Solver {
+	c := Config{variant: Vanilla}
+	for _, option := range options {
+		option(&c)
+	}
+	if c.r == nil {
+		c.r = rand.New(rand.NewSource(0))
+	}
+	return &Solver{root: root, config: c, infoSets: map[string]*infoSet{}}
+}
+
+// Iterations returns the number of iterations run.
+func (s *Solver) Iterations() int {
+	return s.iteration
+}
+
+// Run runs n iterations.
+func (s *Solver) Run(n int) {
+	for i := 0; i < n; i++ {
+		s.iteration++
+		switch s.config.variant {
+		case Vanilla:
+			s.pass++
+			s.cfr(s.root(), [2]float64{1, 1}, 1, -1)
+		case Plus:
+			for p := 0; p < 2; p++ {
+				s.pass++
+				s.cfr(s.root(), [2]float64{1, 1}, 1, p)
+			}
+		case ExternalSampling:
+			for p := 0; p < 2; p++ {
+				s.pass++
+				s.external(s.root(), p)
+			}
+		}
+	}
+}
+
+// Strategy returns the average strategy, which is what converges to an
+// equilibrium.
+func (s *Solver) Strategy() Strategy {
+	strategy := Strategy{}
+	for key, is := range s.infoSets {
+		strategy[key] = normalize(is.strategySum)
+	}
+	return strategy
+}
+
+// cfr traverses the tree updating the info sets of the given player, or
+// of both players if player is -1, and returns the utility of player 0.
+func (s *Solver) cfr(n Node, reach [2]float64, chance float64, player int) float64 {
+	if n.Terminal() {
+		return n.Utility()
+	}
+	if n.Player() == Chance {
+		v := 0.0
+		for a := 0; a < n.NumActions(); a++ {
+			p := n.Probability(a)
+			v += p * s.cfr(n.Child(a), reach, chance*p, player)
+		}
+		return v
+	}
+
+	p := n.Player()
+	is := s.infoSet(n)
+	strategy := is.strategy(s.pass)
+	utils := make([]float64, len(strategy))
+	v := 0.0
+	for a := range strategy {
+		r := reach
+		r[p] *= strategy[a]
+		utils[a] = s.cfr(n.Child(a), r, chance, player)
+		v += strategy[a] * utils[a]
+	}
+	if player != -1 && player != p {
+		return v
+	}
+
+	sign := 1.0
+	if p == 1 {
+		sign = -1
+	}
+	weight := 1.0
+	if s.config.variant == Plus {
+		weight = float64(s.iteration)
+	}
+	for a := range strategy {
+		is.regrets[a] += sign * (utils[a] - v) * reach[1-p] * chance
+		if s.config.variant == Plus && is.regrets[a] < 0 {
+			is.regrets[a] = 0
+		}
+		is.strategySum[a] += weight * reach[p] * strategy[a]
+	}
+	return v
+}
+
+// external traverses the tree with external sampling and returns the
+// sampled utility of the traverser.
+func (s *Solver) external(n Node, traverser int) float64 {
+	if n.Terminal() {
+		if traverser == 0 {
+			return n.Utility()
+		}
+		return -n.Utility()
+	}
+	if n.Player() == Chance {
+		return s.external(n.Child(s.sample(n.NumActions(), n.Probability)), traverser)
+	}
+
+	is := s.infoSet(n)
+	strategy := is.strategy(s.pass)
+	if n.Player() != traverser {
+		for a := range strategy {
+			is.strategySum[a] += strategy[a]
+		}
+		a := s.sample(len(strategy), func(a int) float64 { return strategy[a] })
+		return s.external(n.Child(a), traverser)
+	}
+
+	utils := make([]float64, len(strategy))
+	v := 0.0
+	for a := range strategy {
+		utils[a] = s.external(n.Child(a), traverser)
+		v += strategy[a] * utils[a]
+	}
+	for a := range strategy {
+		is.regrets[a] += utils[a] - v
+	}
+	return v
+}
+
+func (s *Solver) sample(n int, probability func(int) float64) int {
+	x := s.config.r.Float64()
+	for a := 0; a < n-1; a++ {
+		x -= probability(a)
+		if x < 0 {
+			return a
+		}
+	}
+	return n - 1
+}
+
+func (s *Solver) infoSet(n Node) *infoSet {
+	key := n.InfoSet()
+	is, ok := s.infoSets[key]
+	if !ok {
+		is = &infoSet{
+			regrets:     make([]float64, n.NumActions()),
+			strategySum: make([]float64, n.NumActions()),
+		}
+		s.infoSets[key] = is
+	}
+	return is
+}
+
+// strategy returns the current strategy by regret matching.  The
+// strategy is computed once per pass so that regret updates during a
+// traversal don't affect later visits to the same info set.
+func (is *infoSet) strategy(pass int) []float64 {
+	if is.current != nil && is.pass == pass {
+		return is.current
+	}
+	positive := make([]float64, len(is.regrets))
+	for a, r := range is.regrets {
+		if r > 0 {
+			positive[a] = r
+		}
+	}
+	is.current = normalize(positive)
+	is.pass = pass
+	return is.current
+}
+
+// normalize returns the values scaled to sum to one, or a uniform
+// distribution if they sum to zero.
+func normalize(values []float64) []float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	out := make([]float64, len(values))
+	for i, v := range values {
+		if sum > 0 {
+			out[i] = v / sum
+		} else {
+			out[i] = 1 / float64(len(values))
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package cfr_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/cfr"
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func TestKuhn(t *testing.T) {
+	variants := []struct {
+		variant    cfr.Variant
+		iterations int
+		exploit    float64
+	}{
+		{cfr.Vanilla, 2000, 0.01},
+		{cfr.Plus, 500, 0.01},
+		{cfr.ExternalSampling, 20000, 0.05},
+	}
+	for _, v := range variants {
+		s := cfr.New(cfr.Kuhn, cfr.WithVariant(v.variant), cfr.WithRand(rand.New(rand.NewSource(0))))
+		s.Run(v.iterations)
+		strategy := s.Strategy()
+		if e := cfr.Exploitability(cfr.Kuhn(), strategy); e > v.exploit {
+			t.Fatalf("variant %d exploitability = %v; want less than %v", v.variant, e, v.exploit)
+		}
+		if value := cfr.Value(cfr.Kuhn(), strategy); math.Abs(value+1.0/18) > 0.01 {
+			t.Fatalf("variant %d game value = %v; want about %v", v.variant, value, -1.0/18)
+		}
+		// player 1 always calls a bet with a king
+		if p := strategy["K:b"]; p[1] < 0.99 {
+			t.Fatalf("variant %d calls with a king %v of the time", v.variant, p[1])
+		}
+	}
+}
+
+func TestLeduc(t *testing.T) {
+	s := cfr.New(cfr.Leduc, cfr.WithVariant(cfr.Plus))
+	uniform := cfr.Exploitability(cfr.Leduc(), s.Strategy())
+	s.Run(200)
+	e := cfr.Exploitability(cfr.Leduc(), s.Strategy())
+	if e > 0.05 || e >= uniform {
+		t.Fatalf("exploitability = %v after %d iterations; uniform is %v", e, s.Iterations(), uniform)
+	}
+	// the game value for the first player is about -0.0856
+	if value := cfr.Value(cfr.Leduc(), s.Strategy()); math.Abs(value+0.0856) > 0.02 {
+		t.Fatalf("game value = %v; want about %v", value, -0.0856)
+	}
+}
+
+func TestLimitHoldem(t *testing.T) {
+	dealer := hand.NewDealer(rand.New(rand.NewSource(0)))
+	root := func() cfr.Node { return cfr.LimitHoldem(dealer, 8) }
+	s := cfr.New(root, cfr.WithVariant(cfr.ExternalSampling), cfr.WithRand(rand.New(rand.NewSource(0))))
+	s.Run(20)
+	strategy := s.Strategy()
+	if len(strategy) == 0 {
+		t.Fatal("expected info sets")
+	}
+	for key, probs := range strategy {
+		sum := 0.0
+		for _, p := range probs {
+			sum += p
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Fatalf("info set %s probabilities sum to %v", key, sum)
+		}
+	}
+}
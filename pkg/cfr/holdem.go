@@ -0,0 +1,183 @@
+package cfr
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+// LimitHoldem returns the root of an abstraction of heads up limit
+// hold'em.  Player 0 posts the small blind of one chip and player 1 the
+// big blind of two.  Bets are two chips preflop and on the flop and four
+// on the turn and river, with at most four bets per street.  Showdowns
+// are decided with hand.New.
+//
+// The root is a chance node with a single outcome that draws a new deck
+// from the dealer, so every traversal from the root plays a different deal
+// and the game should be solved with ExternalSampling.  Info sets abstract
+// the cards into buckets: preflop the hole cards' equity against a random
+// hand is divided into the given number of buckets, and after the flop
+// the bucket is the ranking of the player's best hand.
+func LimitHoldem(d hand.Dealer, buckets int) Node {
+	return holdem{dealer: d, buckets: buckets, contributions: [2]int{1, 2}, bets: 1}
+}
+
+type holdem struct {
+	dealer        hand.Dealer
+	buckets       int
+	deal          *holdemDeal
+	street        int
+	history       [4]string
+	contributions [2]int
+	bets          int
+	folded        bool
+}
+
+// holdemDeal holds the cards of a deal shared by all of its nodes along
+// with lazily computed buckets and hands.
+type holdemDeal struct {
+	hole    [2][]hand.Card
+	board   []hand.Card
+	buckets [2][4]string
+	hands   [2]*hand.Hand
+}
+
+func (h holdem) Terminal() bool {
+	return h.folded || h.street == 4
+}
+
+func (h holdem) Utility() float64 {
+	if h.folded {
+		if h.lastPlayer() == 0 {
+			return -float64(h.contributions[0])
+		}
+		return float64(h.contributions[1])
+	}
+	for p := range h.deal.hands {
+		if h.deal.hands[p] == nil {
+			h.deal.hands[p] = hand.New(append(append([]hand.Card{}, h.deal.hole[p]...), h.deal.board...))
+		}
+	}
+	switch c := h.deal.hands[0].CompareTo(h.deal.hands[1]); {
+	case c > 0:
+		return float64(h.contributions[1])
+	case c < 0:
+		return -float64(h.contributions[0])
+	}
+	return 0
+}
+
+func (h holdem) Player() int {
+	if h.deal == nil {
+		return Chance
+	}
+	n := len(h.history[h.street])
+	if h.street == 0 {
+		return n % 2
+	}
+	return (n + 1) % 2
+}
+
+func (h holdem) InfoSet() string {
+	p := h.Player()
+	buckets := make([]string, h.street+1)
+	for s := range buckets {
+		buckets[s] = h.bucket(p, s)
+	}
+	return strings.Join(buckets, ",") + ":" + strings.Join(h.history[:h.street+1], "/")
+}
+
+func (h holdem) NumActions() int {
+	if h.deal == nil {
+		return 1
+	}
+	return len(h.actions())
+}
+
+func (h holdem) Probability(a int) float64 {
+	return 1
+}
+
+func (h holdem) Child(a int) Node {
+	if h.deal == nil {
+		deck := h.dealer.Deck()
+		h.deal = &holdemDeal{
+			hole:  [2][]hand.Card{deck.PopMulti(2), deck.PopMulti(2)},
+			board: deck.PopMulti(5),
+		}
+		return h
+	}
+
+	p := h.Player()
+	action := h.actions()[a]
+	switch action {
+	case 'f':
+		h.folded = true
+	case 'c':
+		h.contributions[p] = h.contributions[1-p]
+	case 'r':
+		h.contributions[p] = h.contributions[1-p] + h.betSize()
+		h.bets++
+	}
+	h.history[h.street] += string(action)
+	if !h.folded && h.streetOver() {
+		h.street++
+		h.bets = 0
+	}
+	return h
+}
+
+// actions returns the legal actions where 'f' is fold, 'c' is check or
+// call, and 'r' is bet or raise.
+func (h holdem) actions() string {
+	switch {
+	case h.contributions[0] == h.contributions[1]:
+		return "cr"
+	case h.bets < 4:
+		return "fcr"
+	}
+	return "fc"
+}
+
+func (h holdem) betSize() int {
+	if h.street < 2 {
+		return 2
+	}
+	return 4
+}
+
+func (h holdem) streetOver() bool {
+	return len(h.history[h.street]) >= 2 && h.contributions[0] == h.contributions[1]
+}
+
+func (h holdem) lastPlayer() int {
+	n := len(h.history[h.street]) - 1
+	if h.street == 0 {
+		return n % 2
+	}
+	return (n + 1) % 2
+}
+
+// bucket returns the player's bucket on the street.
+func (h holdem) bucket(p, street int) string {
+	if b := h.deal.buckets[p][street]; b != "" {
+		return b
+	}
+	hole := h.deal.hole[p]
+	var b string
+	if street == 0 {
+		eq := preflop.VsRandom(hole, 2)
+		i := int(eq * float64(h.buckets))
+		if i >= h.buckets {
+			i = h.buckets - 1
+		}
+		b = strconv.Itoa(i)
+	} else {
+		cards := append(append([]hand.Card{}, hole...), h.deal.board[:street+2]...)
+		b = strconv.Itoa(int(hand.New(cards).Ranking()))
+	}
+	h.deal.buckets[p][street] = b
+	return b
+}
@@ -0,0 +1,80 @@
+package cfr
+
+// Kuhn returns the root of Kuhn poker.  Each player antes one chip and is
+// dealt one card from a deck of a jack, queen, and king.  Player 0 acts
+// first and may check or bet one chip, and a player facing a bet may fold
+// or call.  The game value for player 0 is -1/18.
+func Kuhn() Node {
+	return kuhn{}
+}
+
+// kuhn actions
+const (
+	kuhnPass = iota
+	kuhnBet
+)
+
+var kuhnDeals = [][2]int{{0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}}
+
+type kuhn struct {
+	dealt   bool
+	cards   [2]int
+	history string
+}
+
+func (k kuhn) Terminal() bool {
+	switch k.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+	return false
+}
+
+func (k kuhn) Utility() float64 {
+	winner := 0.0
+	if k.cards[0] < k.cards[1] {
+		winner = -1
+	} else {
+		winner = 1
+	}
+	switch k.history {
+	case "pp":
+		return winner
+	case "bb", "pbb":
+		return 2 * winner
+	case "bp":
+		return 1
+	}
+	// pbp
+	return -1
+}
+
+func (k kuhn) Player() int {
+	if !k.dealt {
+		return Chance
+	}
+	return len(k.history) % 2
+}
+
+func (k kuhn) InfoSet() string {
+	return string("JQK"[k.cards[k.Player()]]) + ":" + k.history
+}
+
+func (k kuhn) NumActions() int {
+	if !k.dealt {
+		return len(kuhnDeals)
+	}
+	return 2
+}
+
+func (k kuhn) Probability(a int) float64 {
+	return 1 / float64(len(kuhnDeals))
+}
+
+func (k kuhn) Child(a int) Node {
+	if !k.dealt {
+		return kuhn{dealt: true, cards: kuhnDeals[a]}
+	}
+	k.history += string("pb"[a])
+	return k
+}
@@ -0,0 +1,139 @@
+package cfr
+
+import "strings"
+
+// Leduc returns the root of Leduc hold'em.  The deck has two jacks, two
+// queens, and two kings.  Each player antes one chip and is dealt a
+// private card, then there is a betting round, a public card, and a second
+// betting round.  Bets are two chips in the first round and four in the
+// second with at most two bets per round.  A player who pairs the public
+// card wins at showdown, otherwise the higher card wins.
+func Leduc() Node {
+	return leduc{public: -1, contributions: [2]int{1, 1}}
+}
+
+type leduc struct {
+	dealt         bool
+	private       [2]int
+	public        int
+	round         int
+	history       [2]string
+	contributions [2]int
+	bets          int
+	folded        bool
+}
+
+func (l leduc) Terminal() bool {
+	return l.folded || (l.round == 1 && l.roundOver())
+}
+
+func (l leduc) Utility() float64 {
+	if l.folded {
+		// the player who just acted folded
+		loser := l.lastPlayer()
+		if loser == 0 {
+			return -float64(l.contributions[0])
+		}
+		return float64(l.contributions[1])
+	}
+	r0, r1 := l.private[0]/2, l.private[1]/2
+	pub := l.public / 2
+	switch {
+	case r0 == pub:
+		return float64(l.contributions[1])
+	case r1 == pub:
+		return -float64(l.contributions[0])
+	case r0 > r1:
+		return float64(l.contributions[1])
+	case r0 < r1:
+		return -float64(l.contributions[0])
+	}
+	return 0
+}
+
+func (l leduc) Player() int {
+	if !l.dealt || (l.round == 0 && l.roundOver()) {
+		return Chance
+	}
+	return len(l.history[l.round]) % 2
+}
+
+func (l leduc) InfoSet() string {
+	s := string("JQK"[l.private[l.Player()]/2])
+	if l.public >= 0 {
+		s += string("JQK"[l.public/2])
+	}
+	return s + ":" + l.history[0] + "/" + l.history[1]
+}
+
+func (l leduc) NumActions() int {
+	switch {
+	case !l.dealt:
+		return 30
+	case l.Player() == Chance:
+		return 4
+	}
+	return len(l.actions())
+}
+
+func (l leduc) Probability(a int) float64 {
+	return 1 / float64(l.NumActions())
+}
+
+func (l leduc) Child(a int) Node {
+	switch {
+	case !l.dealt:
+		l.dealt = true
+		l.private = [2]int{a / 5, a % 5}
+		if l.private[1] >= l.private[0] {
+			l.private[1]++
+		}
+		return l
+	case l.Player() == Chance:
+		cards := []int{}
+		for c := 0; c < 6; c++ {
+			if c != l.private[0] && c != l.private[1] {
+				cards = append(cards, c)
+			}
+		}
+		l.public = cards[a]
+		l.round = 1
+		l.bets = 0
+		return l
+	}
+
+	p := l.Player()
+	action := l.actions()[a]
+	switch action {
+	case 'f':
+		l.folded = true
+	case 'c':
+		l.contributions[p] = l.contributions[1-p]
+	case 'r':
+		l.contributions[p] = l.contributions[1-p] + 2*(l.round+1)
+		l.bets++
+	}
+	l.history[l.round] += string(action)
+	return l
+}
+
+// actions returns the legal actions where 'f' is fold, 'c' is check or
+// call, and 'r' is bet or raise.
+func (l leduc) actions() string {
+	switch {
+	case l.contributions[0] == l.contributions[1]:
+		return "cr"
+	case l.bets < 2:
+		return "fcr"
+	}
+	return "fc"
+}
+
+func (l leduc) roundOver() bool {
+	h := l.history[l.round]
+	return len(h) >= 2 && strings.HasSuffix(h, "c")
+}
+
+func (l leduc) lastPlayer() int {
+	return (len(l.history[l.round]) - 1) % 2
+}
@@ -0,0 +1,163 @@
+package cfr
+
+// A Strategy maps info set keys to action probabilities.
+type Strategy map[string][]float64
+
+// Probabilities returns the action probabilities at the node, which are
+// uniform for info sets the strategy doesn't contain.
+func (s Strategy) Probabilities(n Node) []float64 {
+	if p, ok := s[n.InfoSet()]; ok && len(p) == n.NumActions() {
+		return p
+	}
+	return normalize(make([]float64, n.NumActions()))
+}
+
+// Value returns the expected utility of player 0 when both players play
+// the strategy.
+func Value(root Node, s Strategy) float64 {
+	if root.Terminal() {
+		return root.Utility()
+	}
+	var probs []float64
+	if root.Player() == Chance {
+		probs = make([]float64, root.NumActions())
+		for a := range probs {
+			probs[a] = root.Probability(a)
+		}
+	} else {
+		probs = s.Probabilities(root)
+	}
+	v := 0.0
+	for a, p := range probs {
+		if p > 0 {
+			v += p * Value(root.Child(a), s)
+		}
+	}
+	return v
+}
+
+// Exploitability returns how much a best responding opponent gains
+// against the strategy, averaged over both players.  It is zero for an
+// exact equilibrium.  Exploitability traverses the whole game tree so it's
+// only practical for small games such as Kuhn and Leduc poker.
+func Exploitability(root Node, s Strategy) float64 {
+	br0 := BestResponse(root, s, 0)
+	br1 := BestResponse(root, s, 1)
+	return (br0 + br1) / 2
+}
+
+// BestResponse returns the expected utility of the player when playing
+// a best response to the opponent's part of the strategy.
+func BestResponse(root Node, s Strategy, player int) float64 {
+	t := build(root, s, player)
+	br := &bestResponse{tree: t, actions: map[string]int{}}
+	return br.value(t.root)
+}
+
+// tree is an explicit copy of a game tree with the reach probability of
+// the opponent and chance at each node of the best responding player.
+type tree struct {
+	player   int
+	root     *treeNode
+	infoSets map[string][]*treeNode
+}
+
+type treeNode struct {
+	terminal bool
+	utility  float64
+	player   int
+	infoSet  string
+	probs    []float64
+	children []*treeNode
+	reach    float64
+}
+
+func build(root Node, s Strategy, player int) *tree {
+	t := &tree{player: player, infoSets: map[string][]*treeNode{}}
+	t.root = t.add(root, s, 1)
+	return t
+}
+
+func (t *tree) add(n Node, s Strategy, reach float64) *treeNode {
+	tn := &treeNode{terminal: n.Terminal(), reach: reach}
+	if tn.terminal {
+		tn.utility = n.Utility()
+		if t.player == 1 {
+			tn.utility = -tn.utility
+		}
+		return tn
+	}
+	tn.player = n.Player()
+	switch tn.player {
+	case Chance:
+		tn.probs = make([]float64, n.NumActions())
+		for a := range tn.probs {
+			tn.probs[a] = n.Probability(a)
+		}
+	case t.player:
+		tn.infoSet = n.InfoSet()
+		t.infoSets[tn.infoSet] = append(t.infoSets[tn.infoSet], tn)
+	default:
+		tn.probs = s.Probabilities(n)
+	}
+	for a := 0; a < n.NumActions(); a++ {
+		r := reach
+		if tn.probs != nil {
+			r *= tn.probs[a]
+		}
+		tn.children = append(tn.children, t.add(n.Child(a), s, r))
+	}
+	return tn
+}
+
+type bestResponse struct {
+	tree    *tree
+	actions map[string]int
+	values  map[*treeNode]float64
+}
+
+// value returns the utility of the best responding player at the node.
+func (br *bestResponse) value(tn *treeNode) float64 {
+	if br.values == nil {
+		br.values = map[*treeNode]float64{}
+	}
+	if v, ok := br.values[tn]; ok {
+		return v
+	}
+	v := 0.0
+	switch {
+	case tn.terminal:
+		v = tn.utility
+	case tn.player == br.tree.player:
+		v = br.value(tn.children[br.action(tn.infoSet)])
+	default:
+		for a, child := range tn.children {
+			if tn.probs[a] > 0 {
+				v += tn.probs[a] * br.value(child)
+			}
+		}
+	}
+	br.values[tn] = v
+	return v
+}
+
+// action returns the best action for the info set, which maximizes the
+// value summed over its nodes weighted by their reach probabilities.
+func (br *bestResponse) action(key string) int {
+	if a, ok := br.actions[key]; ok {
+		return a
+	}
+	nodes := br.tree.infoSets[key]
+	best, bestValue := 0, 0.0
+	for a := range nodes[0].children {
+		v := 0.0
+		for _, tn := range nodes {
+			v += tn.reach * br.value(tn.children[a])
+		}
+		if a == 0 || v > bestValue {
+			best, bestValue = a, v
+		}
+	}
+	br.actions[key] = best
+	return best
+}
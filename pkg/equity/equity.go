@@ -0,0 +1,320 @@
+// Package equity calculates the share of the pot each player in a hold'em
+// or Omaha hand expects to win, by enumerating the remaining board cards
+// when that is cheap and by Monte Carlo sampling otherwise.  EV values the
+// main and side pots of an all-in hand the same way, for luck-adjusted
+// winnings.
+package equity
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// Config represents the configuration options for a calculation.
+type Config struct {
+	iterations int
+	exactLimit int
+	r          *rand.Rand
+	dead       []hand.Card
+	rules      []func(*hand.Config)
+	omaha      bool
+}
+
+// Iterations sets the number of samples taken when the calculation isn't
+// exact.  The default is 1000.
+func Iterations(n int) func(*Config) {
+	return func(c *Config) {
+		c.iterations = n
+	}
+}
+
+// ExactLimit sets the most board runouts that are enumerated instead of
+// sampled when every player holds a single hand.  The default is 2000.
+func ExactLimit(n int) func(*Config) {
+	return func(c *Config) {
+		c.exactLimit = n
+	}
+}
+
+// WithRand sets the random source used for sampling.  A source seeded
+// from the time is used by default.
+func WithRand(r *rand.Rand) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+	}
+}
+
+// Dead removes cards from the deck, such as cards known to be folded.
+func Dead(cards ...hand.Card) func(*Config) {
+	return func(c *Config) {
+		c.dead = append(c.dead, cards...)
+	}
+}
+
+// Rules sets the hand options used to evaluate hands, such as hand.Low
+// for lowball where the lowest hand wins.
+func Rules(options ...func(*hand.Config)) func(*Config) {
+	return func(c *Config) {
+		c.rules = append(c.rules, options...)
+	}
+}
+
+// Omaha configures players to hold four cards and use exactly two of them.
+func Omaha(c *Config) {
+	c.omaha = true
+}
+
+// Result holds each player's share of the pot.  Equity counts split pots
+// by the share won, Win counts outright wins, and Tie counts split pots.
+type Result struct {
+	Equity  []float64 `json:"equity"`
+	Win     []float64 `json:"win"`
+	Tie     []float64 `json:"tie"`
+	Samples int       `json:"samples"`
+	Exact   bool      `json:"exact"`
+}
+
+// Hands returns the equities of the players' hole cards with a board of
+// zero to five cards.
+func Hands(hands [][]hand.Card, board []hand.Card, options ...func(*Config)) (*Result, error) {
+	ranges := make([][][]hand.Card, len(hands))
+	for i, h := range hands {
+		ranges[i] = [][]hand.Card{h}
+	}
+	return Ranges(ranges, board, options...)
+}
+
+// Ranges returns the equities of players holding hands from the given
+// ranges, with every combo in a range equally likely.
+func Ranges(ranges [][][]hand.Card, board []hand.Card, options ...func(*Config)) (*Result, error) {
+	c := &Config{iterations: 1000, exactLimit: 2000}
+	for _, option := range options {
+		option(c)
+	}
+	if len(ranges) < 2 {
+		return nil, errors.New("equity: need at least two players")
+	}
+	if len(board) > 5 {
+		return nil, errors.New("equity: board has more than five cards")
+	}
+	known := append(append([]hand.Card{}, board...), c.dead...)
+	if hasDuplicates(known) {
+		return nil, errors.New("equity: board and dead cards share cards")
+	}
+	holeCards := 2
+	if c.omaha {
+		holeCards = 4
+	}
+	fixed := true
+	compatible := make([][][]hand.Card, len(ranges))
+	for i, r := range ranges {
+		for _, combo := range r {
+			if len(combo) != holeCards || hasDuplicates(combo) {
+				return nil, errors.New("equity: hands must have " + strconv.Itoa(holeCards) + " different cards")
+			}
+			if !sharesCards(combo, known) {
+				compatible[i] = append(compatible[i], combo)
+			}
+		}
+		if len(compatible[i]) == 0 {
+			return nil, errors.New("equity: a player has no possible hands")
+		}
+		fixed = fixed && len(compatible[i]) == 1
+	}
+	t := newTally(len(ranges), c)
+	toCome := 5 - len(board)
+	if fixed {
+		var holes []hand.Card
+		for _, r := range compatible {
+			holes = append(holes, r[0]...)
+		}
+		if hasDuplicates(holes) {
+			return nil, errors.New("equity: hands share cards")
+		}
+		deck := remaining(append(known, holes...))
+		if len(deck) < toCome {
+			return nil, errors.New("equity: not enough cards left")
+		}
+		if binomial(len(deck), toCome) <= c.exactLimit {
+			hands := make([][]hand.Card, len(ranges))
+			for i, r := range compatible {
+				hands[i] = r[0]
+			}
+			if toCome == 0 {
+				t.add(hands, board)
+			}
+			for _, combo := range util.Combinations(len(deck), toCome) {
+				runout := make([]hand.Card, toCome)
+				for i, j := range combo {
+					runout[i] = deck[j]
+				}
+				t.add(hands, join(board, runout))
+			}
+			return t.result(true), nil
+		}
+	}
+	if c.iterations <= 0 {
+		return nil, errors.New("equity: iterations must be positive")
+	}
+	r := c.r
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	hands := make([][]hand.Card, len(ranges))
+	order := make([]int, len(ranges))
+	for i := range order {
+		order[i] = i
+	}
+	for n, misses := 0, 0; n < c.iterations; {
+		// hands are picked in a random order so that card removal doesn't
+		// favour the players picked first
+		r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		used := append([]hand.Card{}, known...)
+		ok := true
+		for _, i := range order {
+			combo := compatible[i][r.Intn(len(compatible[i]))]
+			if sharesCards(combo, used) {
+				ok = false
+				break
+			}
+			hands[i] = combo
+			used = append(used, combo...)
+		}
+		if !ok {
+			misses++
+			if misses > 100*c.iterations {
+				return nil, errors.New("equity: ranges don't fit together")
+			}
+			continue
+		}
+		deck := remaining(used)
+		for i := 0; i < toCome; i++ {
+			j := i + r.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		t.add(hands, join(board, deck[:toCome]))
+		n++
+	}
+	return t.result(false), nil
+}
+
+type tally struct {
+	rules   []func(*hand.Config)
+	sorting hand.Sorting
+	omaha   bool
+	equity  []float64
+	win     []float64
+	tie     []float64
+	n       int
+}
+
+func newTally(players int, c *Config) *tally {
+	cfg := &hand.Config{}
+	for _, option := range c.rules {
+		option(cfg)
+	}
+	return &tally{
+		rules:   c.rules,
+		sorting: cfg.Sorting(),
+		omaha:   c.omaha,
+		equity:  make([]float64, players),
+		win:     make([]float64, players),
+		tie:     make([]float64, players),
+	}
+}
+
+// add scores the hands on a complete board.
+func (t *tally) add(hands [][]hand.Card, board []hand.Card) {
+	var best *hand.Hand
+	var winners []int
+	for i, h := range hands {
+		var eval *hand.Hand
+		if t.omaha {
+			eval = hand.NewOmaha(h, board, t.rules...)
+		} else {
+			eval = hand.New(join(h, board), t.rules...)
+		}
+		c := 1
+		if best != nil {
+			c = eval.CompareTo(best)
+			if t.sorting == hand.SortingLow {
+				c = -c
+			}
+		}
+		switch {
+		case c > 0:
+			best = eval
+			winners = []int{i}
+		case c == 0:
+			winners = append(winners, i)
+		}
+	}
+	for _, w := range winners {
+		t.equity[w] += 1 / float64(len(winners))
+		if len(winners) == 1 {
+			t.win[w]++
+		} else {
+			t.tie[w]++
+		}
+	}
+	t.n++
+}
+
+func (t *tally) result(exact bool) *Result {
+	res := &Result{Samples: t.n, Exact: exact}
+	for _, s := range [][]float64{t.equity, t.win, t.tie} {
+		for i := range s {
+			s[i] /= float64(t.n)
+		}
+	}
+	res.Equity, res.Win, res.Tie = t.equity, t.win, t.tie
+	return res
+}
+
+func binomial(n, k int) int {
+	b := 1
+	for i := 0; i < k; i++ {
+		b = b * (n - i) / (i + 1)
+	}
+	return b
+}
+
+func remaining(known []hand.Card) []hand.Card {
+	cards := []hand.Card{}
+	for _, c := range hand.Cards() {
+		if !sharesCards([]hand.Card{c}, known) {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// join returns a new slice with the cards of a followed by the cards of b.
+func join(a, b []hand.Card) []hand.Card {
+	return append(append([]hand.Card{}, a...), b...)
+}
+
+func sharesCards(a, b []hand.Card) bool {
+	for _, c1 := range a {
+		for _, c2 := range b {
+			if c1 == c2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasDuplicates(cards []hand.Card) bool {
+	for i := range cards {
+		if sharesCards(cards[i:i+1], cards[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package equity_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestExact(t *testing.T) {
+	// a set against an overpair on the flop
+	res, err := equity.Hands([][]hand.Card{Cards("As", "Ad"), Cards("7h", "7c")}, Cards("7d", "Ks", "2c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Exact || res.Samples != 990 {
+		t.Fatalf("exact = %v samples = %d; want exact over 990 runouts", res.Exact, res.Samples)
+	}
+	// aces need one of the two aces left or runner runner help
+	if math.Abs(res.Equity[0]-0.0889) > 0.001 || math.Abs(res.Equity[0]+res.Equity[1]-1) > 1e-9 {
+		t.Fatalf("equity = %v", res.Equity)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	res, err := equity.Hands([][]hand.Card{Cards("As", "2d"), Cards("Ad", "3c")}, Cards("Kc", "Kh", "Qd", "Qc", "Jh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[0] != 0.5 || res.Tie[0] != 1 || res.Win[0] != 0 {
+		t.Fatalf("result = %+v", res)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	res, err := equity.Hands([][]hand.Card{Cards("As", "Ad"), Cards("Ks", "Kd")}, nil, equity.WithRand(r), equity.Iterations(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Exact || res.Samples != 1000 {
+		t.Fatalf("result = %+v", res)
+	}
+	if math.Abs(res.Equity[0]-0.82) > 0.04 {
+		t.Fatalf("AA vs KK equity = %v; want about 0.82", res.Equity[0])
+	}
+}
+
+func TestRanges(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	aces := [][]hand.Card{Cards("As", "Ad"), Cards("Ac", "Ah"), Cards("As", "Ac")}
+	kings := [][]hand.Card{Cards("Ks", "Kd"), Cards("Kc", "Kh")}
+	res, err := equity.Ranges([][][]hand.Card{aces, kings}, Cards("Qs", "Js", "2h"), equity.WithRand(r), equity.Iterations(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[0] < 0.75 || res.Equity[0] > 0.95 {
+		t.Fatalf("equity = %v", res.Equity)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		hands [][]hand.Card
+		board []hand.Card
+	}{
+		{[][]hand.Card{Cards("As", "Ad")}, nil},
+		{[][]hand.Card{Cards("As", "Ad"), Cards("As", "Kd")}, nil},
+		{[][]hand.Card{Cards("As", "Ad"), Cards("Ks", "Kd")}, Cards("As", "2c", "3d")},
+		{[][]hand.Card{Cards("As"), Cards("Ks", "Kd")}, nil},
+	}
+	for _, test := range tests {
+		if _, err := equity.Hands(test.hands, test.board); err == nil {
+			t.Fatalf("expected error for %v %v", test.hands, test.board)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	hands := [][]hand.Card{Cards("As", "2d"), Cards("Kd", "Kh")}
+	board := Cards("Kc", "Qd", "Jh", "3s", "4d")
+	res, err := equity.Hands(hands, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[1] != 1 {
+		t.Fatalf("high equity = %v", res.Equity)
+	}
+	res, err = equity.Hands(hands, board, equity.Rules(hand.AceToFiveLow))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Equity[0] != 1 {
+		t.Fatalf("low equity = %v", res.Equity)
+	}
+}
+
+func TestOmaha(t *testing.T) {
+	// the ace of spades alone doesn't make a flush
+	hands := [][]hand.Card{Cards("As", "Kh", "Qc", "Jd"), Cards("7h", "6h", "2c", "2d")}
+	res, err := equity.Hands(hands, Cards("Ts", "9s", "8s", "2s", "3d"), equity.Omaha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Exact || res.Equity[0] != 1 {
+		t.Fatalf("result = %+v", res)
+	}
+	res, err = equity.Hands(hands, Cards("Ts", "9s", "8s"), equity.Omaha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Exact || res.Samples != 820 || res.Equity[0] < 0.5 {
+		t.Fatalf("result = %+v", res)
+	}
+	if _, err := equity.Hands([][]hand.Card{Cards("As", "Ad"), Cards("Ks", "Kd")}, nil, equity.Omaha); err == nil {
+		t.Fatal("expected error for two card hands")
+	}
+}
+
+func TestEV(t *testing.T) {
+	// aces all in against kings on the turn, with two kings left
+	stakes := []equity.Stake{
+		{Seat: 0, Cards: Cards("As", "Ad"), Chips: 100},
+		{Seat: 1, Cards: Cards("Ks", "Kd"), Chips: 100},
+	}
+	res, err := equity.EV(stakes, 0, Cards("2c", "7d", "9h", "3s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Exact || res.Samples != 44 {
+		t.Fatalf("exact = %v samples = %d", res.Exact, res.Samples)
+	}
+	if math.Abs(res.Won[0]-200*42.0/44) > 1e-9 || math.Abs(res.Net[0]+res.Net[1]) > 1e-9 {
+		t.Fatalf("won = %v net = %v", res.Won, res.Net)
+	}
+}
+
+func TestEVSidePots(t *testing.T) {
+	// the short stack can only win the main pot of 150, and chips nobody
+	// called go back to the big stack
+	stakes := []equity.Stake{
+		{Seat: 0, Cards: Cards("As", "Ad"), Chips: 50},
+		{Seat: 1, Cards: Cards("Ks", "Kd"), Chips: 100},
+		{Seat: 2, Cards: Cards("Qs", "Qd"), Chips: 200},
+	}
+	board := Cards("7c", "2h", "3d")
+	res, err := equity.EV(stakes, 0, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Samples != 903 || res.Won[0] > 150 || res.Won[2] < 100 {
+		t.Fatalf("samples = %d won = %v", res.Samples, res.Won)
+	}
+	total := 0.0
+	for _, n := range res.Net {
+		total += n
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Fatalf("net sums to %v", total)
+	}
+	stakes[2].Chips = 100
+	called, err := equity.EV(stakes, 0, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range res.Net {
+		if math.Abs(res.Net[i]-called.Net[i]) > 1e-9 {
+			t.Fatalf("net = %v; want %v", res.Net, called.Net)
+		}
+	}
+}
+
+func TestEVOddChips(t *testing.T) {
+	// a split pot of 5 with a chip from a player who folded, the odd chip
+	// going to the first winner left of the button
+	stakes := []equity.Stake{
+		{Seat: 0, Chips: 1, Folded: true},
+		{Seat: 1, Cards: Cards("As", "2d"), Chips: 2},
+		{Seat: 3, Cards: Cards("Ad", "3c"), Chips: 2},
+	}
+	res, err := equity.EV(stakes, 2, Cards("Kc", "Kh", "Qd", "Qc", "Jh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Samples != 1 || res.Won[0] != 0 || res.Won[1] != 2 || res.Won[2] != 3 || res.Net[0] != -1 {
+		t.Fatalf("won = %v net = %v", res.Won, res.Net)
+	}
+}
+
+func TestEVSampled(t *testing.T) {
+	stakes := []equity.Stake{
+		{Seat: 0, Cards: Cards("As", "Ad"), Chips: 100},
+		{Seat: 1, Cards: Cards("Ks", "Kd"), Chips: 100},
+		{Seat: 2, Cards: Cards("7h", "2c"), Chips: 10, Folded: true},
+	}
+	res, err := equity.EV(stakes, 0, nil, equity.Iterations(2000), equity.WithRand(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// aces are about 82% against kings
+	if res.Exact || res.Samples != 2000 || math.Abs(res.Won[0]/210-0.82) > 0.03 || res.Won[0]+res.Won[1] != 210 {
+		t.Fatalf("exact = %v samples = %d won = %v", res.Exact, res.Samples, res.Won)
+	}
+}
+
+func TestEVInvalid(t *testing.T) {
+	aces := equity.Stake{Seat: 0, Cards: Cards("As", "Ad"), Chips: 10}
+	tests := []struct {
+		name   string
+		stakes []equity.Stake
+	}{
+		{"missing cards", []equity.Stake{aces, {Seat: 1, Chips: 10}}},
+		{"same seat", []equity.Stake{aces, {Seat: 0, Cards: Cards("Ks", "Kd"), Chips: 10}}},
+		{"shared card", []equity.Stake{aces, {Seat: 1, Cards: Cards("As", "Kd"), Chips: 10}}},
+		{"folded card", []equity.Stake{aces, {Seat: 1, Cards: Cards("Ad", "Kd"), Chips: 10, Folded: true}}},
+		{"negative chips", []equity.Stake{aces, {Seat: 1, Cards: Cards("Ks", "Kd"), Chips: -1}}},
+		{"everyone folded", []equity.Stake{{Seat: 1, Chips: 10, Folded: true}}},
+	}
+	for _, test := range tests {
+		if _, err := equity.EV(test.stakes, 0, Cards("2c", "7d", "9h")); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package equity
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// Stake is a player's part in the pots of a hand: their seat, their hole
+// cards, the chips they put in after any uncalled bet was returned, and
+// whether they folded.  The cards of players who folded may be left out,
+// and are removed from the deck when given.
+type Stake struct {
+	Seat   int         `json:"seat"`
+	Cards  []hand.Card `json:"cards,omitempty"`
+	Chips  int         `json:"chips"`
+	Folded bool        `json:"folded,omitempty"`
+}
+
+// EVResult holds what each player expects to collect from the pots and
+// their expected profit, in the order of the stakes.
+type EVResult struct {
+	Won     []float64 `json:"won"`
+	Net     []float64 `json:"net"`
+	Samples int       `json:"samples"`
+	Exact   bool      `json:"exact"`
+}
+
+// EV returns the expected value of a hand whose chips all went in before
+// the board was complete.  The chips are split into a main pot and side
+// pots, one for each all in amount, and every remaining board is dealt and
+// each pot awarded the way a table does: split between the best hands of
+// the players who put in enough to be eligible, with odd chips going to
+// the winners first to the left of the button.  As with Hands, the boards
+// are enumerated up to ExactLimit and sampled beyond it.
+func EV(stakes []Stake, button int, board []hand.Card, options ...func(*Config)) (*EVResult, error) {
+	c := &Config{iterations: 1000, exactLimit: 2000}
+	for _, option := range options {
+		option(c)
+	}
+	if len(board) > 5 {
+		return nil, errors.New("equity: board has more than five cards")
+	}
+	holeCards := 2
+	if c.omaha {
+		holeCards = 4
+	}
+	known := append(append([]hand.Card{}, board...), c.dead...)
+	var in []int
+	seats := map[int]bool{}
+	for i, s := range stakes {
+		if seats[s.Seat] {
+			return nil, errors.New("equity: seats must be different")
+		}
+		seats[s.Seat] = true
+		if s.Chips < 0 {
+			return nil, errors.New("equity: chips must not be negative")
+		}
+		if !s.Folded {
+			if len(s.Cards) != holeCards {
+				return nil, errors.New("equity: players who didn't fold need their hole cards")
+			}
+			in = append(in, i)
+		}
+		known = append(known, s.Cards...)
+	}
+	if len(in) == 0 {
+		return nil, errors.New("equity: everyone folded")
+	}
+	if hasDuplicates(known) {
+		return nil, errors.New("equity: cards are used more than once")
+	}
+	pots := makePots(stakes, in)
+	rank := fromButton(stakes, button)
+	t := &evTally{
+		stakes: stakes,
+		in:     in,
+		pots:   pots,
+		rank:   rank,
+		won:    make([]float64, len(stakes)),
+		rules:  c.rules,
+		omaha:  c.omaha,
+	}
+	cfg := &hand.Config{}
+	for _, option := range c.rules {
+		option(cfg)
+	}
+	t.low = cfg.Sorting() == hand.SortingLow
+
+	deck := remaining(known)
+	toCome := 5 - len(board)
+	if len(deck) < toCome {
+		return nil, errors.New("equity: not enough cards left")
+	}
+	runout := make([]hand.Card, toCome)
+	exact := binomial(len(deck), toCome) <= c.exactLimit
+	if exact {
+		combinations(len(deck), toCome, func(combo []int) {
+			for i, j := range combo {
+				runout[i] = deck[j]
+			}
+			t.add(join(board, runout))
+		})
+	} else {
+		if c.iterations <= 0 {
+			return nil, errors.New("equity: iterations must be positive")
+		}
+		r := c.r
+		if r == nil {
+			r = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		for n := 0; n < c.iterations; n++ {
+			for i := 0; i < toCome; i++ {
+				j := i + r.Intn(len(deck)-i)
+				deck[i], deck[j] = deck[j], deck[i]
+			}
+			t.add(join(board, deck[:toCome]))
+		}
+	}
+	res := &EVResult{Samples: t.n, Exact: exact, Won: t.won, Net: make([]float64, len(stakes))}
+	for i := range res.Won {
+		res.Won[i] /= float64(t.n)
+		res.Net[i] = res.Won[i] - float64(stakes[i].Chips)
+	}
+	return res, nil
+}
+
+// evPot is a main or side pot and the stakes eligible to win it.
+type evPot struct {
+	amount   int
+	eligible []int
+}
+
+// makePots splits the chips into a main pot and a side pot for each all in
+// amount of the players still in.  Chips folded above the biggest amount
+// still in go to the last pot.
+func makePots(stakes []Stake, in []int) []evPot {
+	var levels []int
+	for _, i := range in {
+		levels = append(levels, stakes[i].Chips)
+	}
+	sort.Ints(levels)
+	var pots []evPot
+	prev := 0
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		pot := evPot{}
+		for _, s := range stakes {
+			pot.amount += clamp(s.Chips, prev, level)
+		}
+		for _, i := range in {
+			if stakes[i].Chips >= level {
+				pot.eligible = append(pot.eligible, i)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+	if len(pots) == 0 {
+		pots = []evPot{{eligible: in}}
+	}
+	for _, s := range stakes {
+		if s.Chips > prev {
+			pots[len(pots)-1].amount += s.Chips - prev
+		}
+	}
+	return pots
+}
+
+func clamp(chips, lo, hi int) int {
+	switch {
+	case chips <= lo:
+		return 0
+	case chips >= hi:
+		return hi - lo
+	default:
+		return chips - lo
+	}
+}
+
+// fromButton returns the place of each stake in the order from the left of
+// the button, the order odd chips are handed out in.
+func fromButton(stakes []Stake, button int) []int {
+	order := make([]int, len(stakes))
+	for i := range order {
+		order[i] = i
+	}
+	after := func(seat int) bool { return seat > button }
+	sort.Slice(order, func(a, b int) bool {
+		sa, sb := stakes[order[a]].Seat, stakes[order[b]].Seat
+		if after(sa) != after(sb) {
+			return after(sa)
+		}
+		return sa < sb
+	})
+	rank := make([]int, len(stakes))
+	for r, i := range order {
+		rank[i] = r
+	}
+	return rank
+}
+
+type evTally struct {
+	stakes []Stake
+	in     []int
+	pots   []evPot
+	rank   []int
+	rules  []func(*hand.Config)
+	low    bool
+	omaha  bool
+	won    []float64
+	n      int
+}
+
+// add awards the pots on a complete board.
+func (t *evTally) add(board []hand.Card) {
+	hands := map[int]*hand.Hand{}
+	for _, i := range t.in {
+		if t.omaha {
+			hands[i] = hand.NewOmaha(t.stakes[i].Cards, board, t.rules...)
+		} else {
+			hands[i] = hand.New(join(t.stakes[i].Cards, board), t.rules...)
+		}
+	}
+	for _, pot := range t.pots {
+		var winners []int
+		for _, i := range pot.eligible {
+			if len(winners) == 0 {
+				winners = []int{i}
+				continue
+			}
+			c := hands[i].CompareTo(hands[winners[0]])
+			if t.low {
+				c = -c
+			}
+			switch {
+			case c > 0:
+				winners = []int{i}
+			case c == 0:
+				winners = append(winners, i)
+			}
+		}
+		sort.Slice(winners, func(a, b int) bool { return t.rank[winners[a]] < t.rank[winners[b]] })
+		share := pot.amount / len(winners)
+		odd := pot.amount % len(winners)
+		for k, w := range winners {
+			t.won[w] += float64(share)
+			if k < odd {
+				t.won[w]++
+			}
+		}
+	}
+	t.n++
+}
+
+// combinations calls f with every combination of k of n indexes, reusing
+// the slice passed.
+func combinations(n, k int, f func([]int)) {
+	combo := make([]int, k)
+	for i := range combo {
+		combo[i] = i
+	}
+	for {
+		f(combo)
+		i := k - 1
+		for i >= 0 && combo[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		combo[i]++
+		for j := i + 1; j < k; j++ {
+			combo[j] = combo[j-1] + 1
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package gamelog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Encoder appends events to a log.
+type Encoder interface {
+	Encode(e *Event) error
+}
+
+// Decoder reads the events of a log in order.  Decode returns io.EOF at
+// the end of the log.
+type Decoder interface {
+	Decode(e *Event) error
+}
+
+// NewJSONEncoder returns an encoder writing an event per line as JSON.
+func NewJSONEncoder(w io.Writer) Encoder {
+	return &jsonEncoder{e: json.NewEncoder(w)}
+}
+
+type jsonEncoder struct {
+	e *json.Encoder
+}
+
+func (enc *jsonEncoder) Encode(e *Event) error {
+	return enc.e.Encode(e)
+}
+
+// NewJSONDecoder returns a decoder reading JSON events.
+func NewJSONDecoder(r io.Reader) Decoder {
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	return &jsonDecoder{d: d}
+}
+
+type jsonDecoder struct {
+	d *json.Decoder
+}
+
+func (d *jsonDecoder) Decode(e *Event) error {
+	*e = Event{}
+	return d.d.Decode(e)
+}
+
+// The binary format writes each event as its length in bytes followed by
+// its fields, with integers as varints and cards as their index in
+// hand.Cards.
+const (
+	hasSettings = 1 << iota
+	hasDeck
+	hasAction
+)
+
+// NewBinaryEncoder returns an encoder writing the compact binary format.
+func NewBinaryEncoder(w io.Writer) Encoder {
+	return &binaryEncoder{w: w}
+}
+
+type binaryEncoder struct {
+	w io.Writer
+}
+
+func (enc *binaryEncoder) Encode(e *Event) error {
+	b := &buffer{}
+	b.uvarint(uint64(e.Type))
+	b.uvarint(uint64(e.Seq))
+	b.varint(int64(e.Seat))
+	b.uvarint(uint64(len(e.Name)))
+	b.b = append(b.b, e.Name...)
+	b.varint(int64(e.Chips))
+	b.varint(e.Seed)
+	flags := byte(0)
+	if e.Settings != nil {
+		flags |= hasSettings
+	}
+	if e.Deck != nil {
+		flags |= hasDeck
+	}
+	if e.Action != nil {
+		flags |= hasAction
+	}
+	b.b = append(b.b, flags)
+	if s := e.Settings; s != nil {
+		for _, n := range []int{s.Seats, s.SmallBlind, s.BigBlind, s.Ante, s.Button} {
+			b.varint(int64(n))
+		}
+	}
+	if e.Deck != nil {
+		b.uvarint(uint64(len(e.Deck.Cards)))
+		for _, c := range e.Deck.Cards {
+			i, ok := cardIndex[c]
+			if !ok {
+				return errors.New("gamelog: invalid card in deck")
+			}
+			b.b = append(b.b, byte(i))
+		}
+	}
+	if a := e.Action; a != nil {
+		b.uvarint(uint64(a.Type))
+		b.varint(int64(a.Amount))
+	}
+	b.uvarint(uint64(len(e.Pots)))
+	for _, p := range e.Pots {
+		b.varint(int64(p.Amount))
+		b.ints(p.Seats)
+		b.ints(p.Winners)
+	}
+	b.uvarint(uint64(len(e.Results)))
+	for _, r := range e.Results {
+		b.varint(int64(r.Seat))
+		b.varint(int64(r.Won))
+	}
+	head := &buffer{}
+	head.uvarint(uint64(len(b.b)))
+	_, err := enc.w.Write(append(head.b, b.b...))
+	return err
+}
+
+// NewBinaryDecoder returns a decoder reading the compact binary format.
+func NewBinaryDecoder(r io.Reader) Decoder {
+	return &binaryDecoder{r: bufio.NewReader(r)}
+}
+
+type binaryDecoder struct {
+	r *bufio.Reader
+}
+
+func (dec *binaryDecoder) Decode(e *Event) error {
+	n, err := binary.ReadUvarint(dec.r)
+	if err != nil {
+		return err
+	}
+	if n > 1<<20 {
+		return errors.New("gamelog: event too long")
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(dec.r, data); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	r := &reader{b: data}
+	*e = Event{
+		Type:  EventType(r.uvarint()),
+		Seq:   int(r.uvarint()),
+		Seat:  int(r.varint()),
+		Name:  r.string(),
+		Chips: int(r.varint()),
+		Seed:  r.varint(),
+	}
+	flags := r.byte()
+	if flags&hasSettings != 0 {
+		e.Settings = &Settings{
+			Seats:      int(r.varint()),
+			SmallBlind: int(r.varint()),
+			BigBlind:   int(r.varint()),
+			Ante:       int(r.varint()),
+			Button:     int(r.varint()),
+		}
+	}
+	if flags&hasDeck != 0 {
+		cards := hand.Cards()
+		e.Deck = &hand.Deck{Cards: []hand.Card{}}
+		for i, n := 0, int(r.uvarint()); i < n && r.err == nil; i++ {
+			c := int(r.byte())
+			if c >= len(cards) {
+				return errors.New("gamelog: invalid card in deck")
+			}
+			e.Deck.Cards = append(e.Deck.Cards, cards[c])
+		}
+	}
+	if flags&hasAction != 0 {
+		e.Action = &table.Action{Type: table.ActionType(r.uvarint()), Amount: int(r.varint())}
+	}
+	for i, n := 0, int(r.uvarint()); i < n && r.err == nil; i++ {
+		e.Pots = append(e.Pots, table.Pot{Amount: int(r.varint()), Seats: r.ints(), Winners: r.ints()})
+	}
+	for i, n := 0, int(r.uvarint()); i < n && r.err == nil; i++ {
+		e.Results = append(e.Results, Result{Seat: int(r.varint()), Won: int(r.varint())})
+	}
+	if r.err == nil && len(r.b) > 0 {
+		r.err = errors.New("gamelog: trailing bytes in event")
+	}
+	return r.err
+}
+
+var cardIndex = func() map[hand.Card]int {
+	m := map[hand.Card]int{}
+	for i, c := range hand.Cards() {
+		m[c] = i
+	}
+	return m
+}()
+
+type buffer struct {
+	b []byte
+}
+
+func (b *buffer) uvarint(n uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	b.b = append(b.b, tmp[:binary.PutUvarint(tmp[:], n)]...)
+}
+
+func (b *buffer) varint(n int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	b.b = append(b.b, tmp[:binary.PutVarint(tmp[:], n)]...)
+}
+
+func (b *buffer) ints(s []int) {
+	b.uvarint(uint64(len(s)))
+	for _, n := range s {
+		b.varint(int64(n))
+	}
+}
+
+// reader reads the fields of an event, keeping the first error.
+type reader struct {
+	b   []byte
+	err error
+}
+
+var errCorrupt = errors.New("gamelog: corrupt event")
+
+func (r *reader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	n, k := binary.Uvarint(r.b)
+	if k <= 0 {
+		r.err = errCorrupt
+		return 0
+	}
+	r.b = r.b[k:]
+	return n
+}
+
+func (r *reader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	n, k := binary.Varint(r.b)
+	if k <= 0 {
+		r.err = errCorrupt
+		return 0
+	}
+	r.b = r.b[k:]
+	return n
+}
+
+func (r *reader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.b) == 0 {
+		r.err = errCorrupt
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *reader) string() string {
+	n := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if n > uint64(len(r.b)) {
+		r.err = errCorrupt
+		return ""
+	}
+	s := string(r.b[:n])
+	r.b = r.b[n:]
+	return s
+}
+
+func (r *reader) ints() []int {
+	n := r.uvarint()
+	if r.err != nil || n > uint64(len(r.b)) {
+		r.err = errCorrupt
+		return nil
+	}
+	var s []int
+	for i := uint64(0); i < n; i++ {
+		s = append(s, int(r.varint()))
+	}
+	return s
+}
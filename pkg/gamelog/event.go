@@ -0,0 +1,104 @@
+// Package gamelog keeps an append-only log of everything that happens at a
+// table: the players sitting and leaving, every shuffle with its seed and
+// deck order, every action, and the pots awarded at the end of each hand.
+// Any table state can be rebuilt from the log, and Verify plays the log
+// again to check that it is consistent and that every showdown was paid
+// to the best hands.
+//
+// Logs are written as JSON lines or in a compact binary format.
+package gamelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+//go:generate stringer -type=EventType -output=stringer_autogen.go
+
+// EventType is the kind of an event.
+type EventType int
+
+const (
+	// Open opens a table.  It is the first event of every log.
+	Open EventType = iota + 1
+
+	// Sit seats a player with a stack of chips.
+	Sit
+
+	// Leave takes a player out of their seat.
+	Leave
+
+	// AddChips adds chips to a player's stack.
+	AddChips
+
+	// Deal starts a hand with a shuffled deck.
+	Deal
+
+	// Act is an action by the player to act.
+	Act
+
+	// Finish ends a hand, recording the pots and what each player won.
+	Finish
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.  Event
+// types are written as "open", "addChips", and so on.
+func (e EventType) MarshalText() ([]byte, error) {
+	return []byte(lowerFirst(e.String())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *EventType) UnmarshalText(text []byte) error {
+	for t := Open; t <= Finish; t++ {
+		if lowerFirst(t.String()) == string(text) {
+			*e = t
+			return nil
+		}
+	}
+	return fmt.Errorf("gamelog: unknown event type %q", text)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// Settings are the settings a table is opened with.  Button is the seat
+// the button starts from.
+type Settings struct {
+	Seats      int `json:"seats"`
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+	Ante       int `json:"ante,omitempty"`
+	Button     int `json:"button"`
+}
+
+// Result is what a player won in a hand.
+type Result struct {
+	Seat int `json:"seat"`
+	Won  int `json:"won"`
+}
+
+// Event is an entry in the log.  Seq numbers events from 1, and the
+// fields set depend on the type: Settings for Open; Seat, Name, and
+// Chips for Sit, Leave, and AddChips; Seed and Deck for Deal, with the
+// deck dealt from the end; Seat and Action for Act; and Pots and Results
+// for Finish.
+type Event struct {
+	Seq      int           `json:"seq"`
+	Type     EventType     `json:"type"`
+	Settings *Settings     `json:"settings,omitempty"`
+	Seat     int           `json:"seat"`
+	Name     string        `json:"name,omitempty"`
+	Chips    int           `json:"chips,omitempty"`
+	Seed     int64         `json:"seed,omitempty"`
+	Deck     *hand.Deck    `json:"deck,omitempty"`
+	Action   *table.Action `json:"action,omitempty"`
+	Pots     []table.Pot   `json:"pots,omitempty"`
+	Results  []Result      `json:"results,omitempty"`
+}
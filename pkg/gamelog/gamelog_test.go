@@ -0,0 +1,201 @@
+package gamelog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/pkg/gamelog"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// play logs hands of random actions, with players sitting, leaving, and
+// adding chips along the way, and returns the table seen after each
+// event that changed a hand.
+func play(t *testing.T, enc gamelog.Encoder) map[int]table.View {
+	tbl, err := gamelog.NewTable(enc, 4, gamelog.Stakes(1, 2), gamelog.WithRand(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := tbl.Sit(i, fmt.Sprint("p", i), 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	views := map[int]table.View{}
+	r := rand.New(rand.NewSource(2))
+	left := false
+	for h := 0; h < 40; h++ {
+		switch h {
+		case 5:
+			if err := tbl.Sit(3, "p3", 500); err != nil {
+				t.Fatal(err)
+			}
+		case 10:
+			if err := tbl.AddChips(0, 100); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tbl.Deal(); err != nil {
+			break
+		}
+		views[tbl.Seq()] = tbl.View(-1)
+		for tbl.Active() {
+			if h == 12 && !left && tbl.ToAct() != 3 {
+				// seat 3 leaves during the hand and folds in turn
+				if err := tbl.Leave(3); err != nil {
+					t.Fatal(err)
+				}
+				left = true
+			} else {
+				o := tbl.View(tbl.ToAct()).Options
+				a := table.Action{Type: table.Call}
+				switch n := r.Intn(10); {
+				case o.ToCall == 0 && (n > 6 || o.MinRaise == 0):
+					a.Type = table.Check
+				case n == 0 || (n < 5 && o.ToCall > 50):
+					a.Type = table.Fold
+				case n < 3 && o.MinRaise > 0:
+					a = table.Action{Type: o.Actions[len(o.Actions)-1], Amount: o.MinRaise}
+				case n == 3 && o.MaxRaise > 0 && r.Intn(5) == 0:
+					a = table.Action{Type: o.Actions[len(o.Actions)-1], Amount: o.MaxRaise}
+				case o.ToCall == 0:
+					a.Type = table.Check
+				}
+				if err := tbl.Act(tbl.ToAct(), a); err != nil {
+					t.Fatalf("hand %d %v: %v", h, a, err)
+				}
+			}
+			views[tbl.Seq()] = tbl.View(-1)
+		}
+	}
+	return views
+}
+
+func decodeAll(t *testing.T, d gamelog.Decoder) []*gamelog.Event {
+	var events []*gamelog.Event
+	for {
+		e := &gamelog.Event{}
+		if err := d.Decode(e); err == io.EOF {
+			return events
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+}
+
+func TestLog(t *testing.T) {
+	j, b := &bytes.Buffer{}, &bytes.Buffer{}
+	play(t, gamelog.NewJSONEncoder(j))
+	play(t, gamelog.NewBinaryEncoder(b))
+	if err := gamelog.Verify(gamelog.NewJSONDecoder(bytes.NewReader(j.Bytes()))); err != nil {
+		t.Fatal(err)
+	}
+	if err := gamelog.Verify(gamelog.NewBinaryDecoder(bytes.NewReader(b.Bytes()))); err != nil {
+		t.Fatal(err)
+	}
+	// both formats hold the same events
+	fromJSON := decodeAll(t, gamelog.NewJSONDecoder(bytes.NewReader(j.Bytes())))
+	fromBinary := decodeAll(t, gamelog.NewBinaryDecoder(bytes.NewReader(b.Bytes())))
+	if len(fromJSON) < 100 || len(fromJSON) != len(fromBinary) {
+		t.Fatalf("%d JSON events and %d binary events", len(fromJSON), len(fromBinary))
+	}
+	types := map[gamelog.EventType]bool{}
+	for i := range fromJSON {
+		types[fromJSON[i].Type] = true
+		x, _ := json.Marshal(fromJSON[i])
+		y, _ := json.Marshal(fromBinary[i])
+		if !bytes.Equal(x, y) {
+			t.Fatalf("event %d is %s in JSON and %s in binary", i+1, x, y)
+		}
+	}
+	if len(types) != 7 {
+		t.Fatalf("event types = %v", types)
+	}
+	if b.Len()*4 > j.Len() {
+		t.Fatalf("binary log is %d bytes and JSON log %d", b.Len(), j.Len())
+	}
+	// shuffles are logged as deck text
+	if !strings.Contains(j.String(), `"type":"deal"`) || !strings.Contains(j.String(), `"deck":"`) {
+		t.Fatalf("log = %.500s", j.String())
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	b := &bytes.Buffer{}
+	views := play(t, gamelog.NewBinaryEncoder(b))
+	n := 0
+	for seq, want := range views {
+		if n++; n > 20 {
+			break
+		}
+		tbl, err := gamelog.Rebuild(gamelog.NewBinaryDecoder(bytes.NewReader(b.Bytes())), seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tbl.View(-1); !reflect.DeepEqual(got, want) {
+			t.Fatalf("event %d: rebuilt %+v; want %+v", seq, got, want)
+		}
+	}
+	if _, err := gamelog.Rebuild(gamelog.NewBinaryDecoder(bytes.NewReader(b.Bytes())), 1<<20); err == nil {
+		t.Fatal("expected error rebuilding past the end of the log")
+	}
+}
+
+func TestTampered(t *testing.T) {
+	j := &bytes.Buffer{}
+	play(t, gamelog.NewJSONEncoder(j))
+	lines := strings.Split(strings.TrimSpace(j.String()), "\n")
+	find := func(typ string, n int) int {
+		for i, l := range lines {
+			if strings.Contains(l, `"type":"`+typ+`"`) {
+				if n == 0 {
+					return i
+				}
+				n--
+			}
+		}
+		t.Fatalf("no %s event", typ)
+		return 0
+	}
+	tests := []struct {
+		name   string
+		change func(e *gamelog.Event)
+		line   int
+	}{
+		{"stacked deck", func(e *gamelog.Event) {
+			e.Deck.Cards[0], e.Deck.Cards[51] = e.Deck.Cards[51], e.Deck.Cards[0]
+		}, find("deal", 3)},
+		{"other seed", func(e *gamelog.Event) { e.Seed++ }, find("deal", 0)},
+		{"wrong winner", func(e *gamelog.Event) { e.Results[0].Won, e.Results[1].Won = e.Results[1].Won+1, e.Results[0].Won-1 }, find("finish", 2)},
+		{"wrong pot", func(e *gamelog.Event) { e.Pots[0].Amount++ }, find("finish", 4)},
+		{"illegal action", func(e *gamelog.Event) { e.Action = &table.Action{Type: table.Bet, Amount: 1} }, find("act", 10)},
+		{"out of order", func(e *gamelog.Event) { e.Seq++ }, find("sit", 1)},
+	}
+	for _, test := range tests {
+		e := &gamelog.Event{}
+		if err := json.Unmarshal([]byte(lines[test.line]), e); err != nil {
+			t.Fatal(err)
+		}
+		test.change(e)
+		changed, _ := json.Marshal(e)
+		log := append(append(append([]string{}, lines[:test.line]...), string(changed)), lines[test.line+1:]...)
+		if err := gamelog.Verify(gamelog.NewJSONDecoder(strings.NewReader(strings.Join(log, "\n")))); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+	// a log cut off between the end of a hand and its result
+	cut := strings.Join(lines[:find("finish", 0)], "\n")
+	if err := gamelog.Verify(gamelog.NewJSONDecoder(strings.NewReader(cut))); err == nil {
+		t.Fatal("expected error for a log missing a finish event")
+	}
+	if err := gamelog.Verify(gamelog.NewBinaryDecoder(bytes.NewReader([]byte{5, 1, 2}))); err == nil {
+		t.Fatal("expected error for a truncated binary log")
+	}
+}
@@ -0,0 +1,204 @@
+package gamelog
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Config represents the configuration options for a logged table.
+type Config struct {
+	smallBlind int
+	bigBlind   int
+	ante       int
+	button     int
+	r          *rand.Rand
+}
+
+// Stakes sets the blinds.  The default blinds are 1 and 2.
+func Stakes(small, big int) func(*Config) {
+	return func(c *Config) {
+		c.smallBlind = small
+		c.bigBlind = big
+	}
+}
+
+// Ante sets an ante paid by every player dealt in.
+func Ante(ante int) func(*Config) {
+	return func(c *Config) {
+		c.ante = ante
+	}
+}
+
+// Button sets the seat the button starts from.
+func Button(seat int) func(*Config) {
+	return func(c *Config) {
+		c.button = seat
+	}
+}
+
+// WithRand sets the random source the seed of every shuffle is drawn
+// from.  A source seeded from the time is used by default.
+func WithRand(r *rand.Rand) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+	}
+}
+
+// Table is a table that logs every change to it.  Each hand is dealt from
+// a deck shuffled with its own seed, and both the seed and the deck are
+// logged.  Changes are only logged once the table accepts them, and a
+// change that can't be logged returns the encoder's error after being
+// made.  A Table isn't safe for concurrent use.
+type Table struct {
+	t      *table.Table
+	enc    Encoder
+	r      *rand.Rand
+	dealer *logDealer
+	seq    int
+}
+
+// NewTable opens a table with the given number of seats, logging to enc.
+func NewTable(enc Encoder, seats int, options ...func(*Config)) (*Table, error) {
+	c := Config{smallBlind: 1, bigBlind: 2}
+	for _, option := range options {
+		option(&c)
+	}
+	if c.r == nil {
+		c.r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	s := Settings{Seats: seats, SmallBlind: c.smallBlind, BigBlind: c.bigBlind, Ante: c.ante, Button: c.button}
+	t, d, err := open(s)
+	if err != nil {
+		return nil, err
+	}
+	lt := &Table{t: t, enc: enc, r: c.r, dealer: d}
+	if err := lt.log(&Event{Type: Open, Settings: &s}); err != nil {
+		return nil, err
+	}
+	return lt, nil
+}
+
+// open returns a table with the settings and the dealer it deals from.
+func open(s Settings) (*table.Table, *logDealer, error) {
+	d := &logDealer{}
+	t, err := table.New(s.Seats,
+		table.Stakes(s.SmallBlind, s.BigBlind), table.Ante(s.Ante),
+		table.Button(s.Button), table.WithDealer(d))
+	return t, d, err
+}
+
+// Sit seats a player with a stack of chips.
+func (t *Table) Sit(seat int, name string, chips int) error {
+	if err := t.t.Sit(seat, name, chips); err != nil {
+		return err
+	}
+	return t.log(&Event{Type: Sit, Seat: seat, Name: name, Chips: chips})
+}
+
+// Leave takes a player out of their seat.
+func (t *Table) Leave(seat int) error {
+	return t.change(&Event{Type: Leave, Seat: seat}, func() error {
+		return t.t.Leave(seat)
+	})
+}
+
+// AddChips adds chips to a player's stack between hands.
+func (t *Table) AddChips(seat, chips int) error {
+	if err := t.t.AddChips(seat, chips); err != nil {
+		return err
+	}
+	return t.log(&Event{Type: AddChips, Seat: seat, Chips: chips})
+}
+
+// Deal shuffles a deck and starts a new hand.
+func (t *Table) Deal() error {
+	seed := t.r.Int63()
+	deck := hand.NewDealer(rand.New(rand.NewSource(seed))).Deck()
+	logged := &hand.Deck{Cards: append([]hand.Card{}, deck.Cards...)}
+	t.dealer.next = deck
+	return t.change(&Event{Type: Deal, Seed: seed, Deck: logged}, t.t.Deal)
+}
+
+// Act takes an action for the player to act.
+func (t *Table) Act(seat int, a table.Action) error {
+	return t.change(&Event{Type: Act, Seat: seat, Action: &a}, func() error {
+		return t.t.Act(seat, a)
+	})
+}
+
+// View returns the table as seen from a seat.
+func (t *Table) View(seat int) table.View {
+	return t.t.View(seat)
+}
+
+// Active returns whether a hand is in progress.
+func (t *Table) Active() bool {
+	return t.t.Active()
+}
+
+// ToAct returns the seat to act or -1 if no hand is in progress.
+func (t *Table) ToAct() int {
+	return t.t.ToAct()
+}
+
+// Seq returns the sequence number of the last event logged.
+func (t *Table) Seq() int {
+	return t.seq
+}
+
+// Record returns the record of the hand in progress or the last hand
+// played.
+func (t *Table) Record() *table.Record {
+	return t.t.Record()
+}
+
+// change makes a change that may end a hand and logs it, followed by the
+// end of the hand if it did.  A hand ends during the deal when the blinds
+// put everyone all in.
+func (t *Table) change(e *Event, f func() error) error {
+	active := t.t.Active()
+	if err := f(); err != nil {
+		return err
+	}
+	if err := t.log(e); err != nil {
+		return err
+	}
+	if ended(active, e.Type, t.t) {
+		return t.log(finish(t.t.Record()))
+	}
+	return nil
+}
+
+func (t *Table) log(e *Event) error {
+	t.seq++
+	e.Seq = t.seq
+	return t.enc.Encode(e)
+}
+
+// ended returns whether an event ended a hand.
+func ended(active bool, typ EventType, t *table.Table) bool {
+	return (active || typ == Deal) && !t.Active()
+}
+
+// finish returns the Finish event of a hand.
+func finish(r *table.Record) *Event {
+	e := &Event{Type: Finish, Pots: r.Pots}
+	for _, rs := range r.Seats {
+		e.Results = append(e.Results, Result{Seat: rs.Seat, Won: rs.Won})
+	}
+	return e
+}
+
+// logDealer deals the deck it is given next.
+type logDealer struct {
+	next *hand.Deck
+}
+
+func (d *logDealer) Deck() *hand.Deck {
+	deck := d.next
+	d.next = nil
+	return deck
+}
@@ -0,0 +1,235 @@
+package gamelog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Rebuilder rebuilds a table by applying the events of its log in order.
+// Every hand is checked as it finishes: the pots and winnings must match
+// the table's, and the winners of every pot that went to showdown must
+// hold the best hands.
+type Rebuilder struct {
+	t       *table.Table
+	dealer  *logDealer
+	seq     int
+	pending bool
+}
+
+// NewRebuilder returns a rebuilder for a new log.
+func NewRebuilder() *Rebuilder {
+	return &Rebuilder{}
+}
+
+// Table returns the rebuilt table, or nil before the Open event.
+func (r *Rebuilder) Table() *table.Table {
+	return r.t
+}
+
+// Seq returns the sequence number of the last event applied.
+func (r *Rebuilder) Seq() int {
+	return r.seq
+}
+
+// Apply applies the next event of the log.
+func (r *Rebuilder) Apply(e *Event) error {
+	if err := r.apply(e); err != nil {
+		return fmt.Errorf("gamelog: event %d: %v", e.Seq, err)
+	}
+	r.seq = e.Seq
+	return nil
+}
+
+func (r *Rebuilder) apply(e *Event) error {
+	if e.Seq != r.seq+1 {
+		return fmt.Errorf("expected event %d", r.seq+1)
+	}
+	if r.t == nil && e.Type != Open {
+		return errors.New("the log must start by opening a table")
+	}
+	if r.pending && e.Type != Finish {
+		return errors.New("the hand ended without a finish event")
+	}
+	active := r.t != nil && r.t.Active()
+	var err error
+	switch e.Type {
+	case Open:
+		if r.t != nil {
+			return errors.New("the table is already open")
+		}
+		if e.Settings == nil {
+			return errors.New("missing settings")
+		}
+		r.t, r.dealer, err = open(*e.Settings)
+		return err
+	case Sit:
+		return r.t.Sit(e.Seat, e.Name, e.Chips)
+	case Leave:
+		err = r.t.Leave(e.Seat)
+	case AddChips:
+		return r.t.AddChips(e.Seat, e.Chips)
+	case Deal:
+		if err := checkDeck(e); err != nil {
+			return err
+		}
+		r.dealer.next = &hand.Deck{Cards: append([]hand.Card{}, e.Deck.Cards...)}
+		err = r.t.Deal()
+	case Act:
+		if e.Action == nil {
+			return errors.New("missing action")
+		}
+		err = r.t.Act(e.Seat, *e.Action)
+	case Finish:
+		if !r.pending {
+			return errors.New("no hand has ended")
+		}
+		r.pending = false
+		return checkFinish(e, r.t.Record())
+	default:
+		return fmt.Errorf("unknown event type %v", e.Type)
+	}
+	if err != nil {
+		return err
+	}
+	r.pending = ended(active, e.Type, r.t)
+	return nil
+}
+
+// checkDeck returns an error unless a deck has every card once and is
+// the shuffle of its seed.
+func checkDeck(e *Event) error {
+	if e.Deck == nil || len(e.Deck.Cards) != 52 || hasDuplicates(e.Deck.Cards) {
+		return errors.New("the deck must have every card once")
+	}
+	shuffled := hand.NewDealer(rand.New(rand.NewSource(e.Seed))).Deck()
+	if !reflect.DeepEqual(shuffled.Cards, e.Deck.Cards) {
+		return errors.New("the deck isn't the shuffle of its seed")
+	}
+	return nil
+}
+
+func hasDuplicates(cards []hand.Card) bool {
+	seen := map[hand.Card]bool{}
+	for _, c := range cards {
+		if seen[c] {
+			return true
+		}
+		seen[c] = true
+	}
+	return false
+}
+
+// checkFinish returns an error unless the logged end of a hand matches the
+// rebuilt one and every pot went to the best hands among its players.
+func checkFinish(e *Event, rec *table.Record) error {
+	want := finish(rec)
+	if !samePots(e.Pots, want.Pots) || !reflect.DeepEqual(e.Results, want.Results) {
+		return errors.New("the pots awarded don't match the hand")
+	}
+	total, won := 0, 0
+	for _, pot := range e.Pots {
+		total += pot.Amount
+		if len(pot.Seats) < 2 {
+			continue
+		}
+		if len(rec.Board) != 5 {
+			return errors.New("a pot was shown down before the river")
+		}
+		var best *hand.Hand
+		winners := map[int]bool{}
+		for _, seat := range pot.Seats {
+			rs, _ := rec.Seat(seat)
+			h := hand.New(append(append([]hand.Card{}, rs.Cards...), rec.Board...))
+			c := 1
+			if best != nil {
+				c = h.CompareTo(best)
+			}
+			if c > 0 {
+				best = h
+				winners = map[int]bool{}
+			}
+			if c >= 0 {
+				winners[seat] = true
+			}
+		}
+		if len(winners) != len(pot.Winners) {
+			return fmt.Errorf("the pot of %d wasn't won by the best hands", pot.Amount)
+		}
+		for _, w := range pot.Winners {
+			if !winners[w] {
+				return fmt.Errorf("the pot of %d wasn't won by the best hands", pot.Amount)
+			}
+		}
+	}
+	for _, r := range e.Results {
+		won += r.Won
+	}
+	if won != total {
+		return errors.New("the winnings don't add up to the pots")
+	}
+	return nil
+}
+
+// samePots compares pots, treating missing and empty lists alike.
+func samePots(a, b []table.Pot) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Amount != b[i].Amount || fmt.Sprint(a[i].Seats) != fmt.Sprint(b[i].Seats) || fmt.Sprint(a[i].Winners) != fmt.Sprint(b[i].Winners) {
+			return false
+		}
+	}
+	return true
+}
+
+// Rebuild reads a log up to and including the event with sequence number
+// seq and returns the table as it was then.  A seq of 0 reads the whole
+// log.
+func Rebuild(d Decoder, seq int) (*table.Table, error) {
+	r := NewRebuilder()
+	if err := r.read(d, seq); err != nil {
+		return nil, err
+	}
+	if seq > r.Seq() {
+		return nil, fmt.Errorf("gamelog: the log ends at event %d", r.Seq())
+	}
+	return r.Table(), nil
+}
+
+// Verify reads a whole log, checking every event and every hand.
+func Verify(d Decoder) error {
+	r := NewRebuilder()
+	if err := r.read(d, 0); err != nil {
+		return err
+	}
+	if r.pending {
+		return errors.New("gamelog: the log ends before the last hand is finished")
+	}
+	return nil
+}
+
+// read applies events up to seq, or to the end of the log if seq is 0.
+func (r *Rebuilder) read(d Decoder, seq int) error {
+	for seq == 0 || r.seq < seq {
+		e := &Event{}
+		if err := d.Decode(e); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := r.Apply(e); err != nil {
+			return err
+		}
+	}
+	if r.t == nil {
+		return errors.New("gamelog: empty log")
+	}
+	return nil
+}
@@ -48,14 +48,16 @@ func (d *Deck) MarshalText() (text []byte, err error) {
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface
 func (d *Deck) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		d.Cards = nil
+		return nil
+	}
 	strs := strings.Split(string(text), ",")
 	cards := make([]Card, len(strs))
 	for i, s := range strs {
-		var card *Card
-		if err := card.UnmarshalText([]byte(s)); err != nil {
+		if err := cards[i].UnmarshalText([]byte(s)); err != nil {
 			return err
 		}
-		cards[i] = *card
 	}
 	d.Cards = cards
 	return nil
@@ -241,6 +241,27 @@ func TestDeck(t *testing.T) {
 	}
 }
 
+func TestDeckText(t *testing.T) {
+	deck := hand.NewDealer(rand.New(rand.NewSource(0))).Deck()
+	text, err := deck.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &hand.Deck{}
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != deck.String() || len(got.Cards) != 52 {
+		t.Fatalf("deck = %v; want %v", got, deck)
+	}
+	if err := got.UnmarshalText([]byte("A♠,X♠")); err == nil {
+		t.Fatal("expected error for an invalid card")
+	}
+	if err := got.UnmarshalText(nil); err != nil || len(got.Cards) != 0 {
+		t.Fatalf("empty deck = %v, %v", got, err)
+	}
+}
+
 func TestHandJSON(t *testing.T) {
 	jsonStr := `{"ranking":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],"description":"royal flush","config":{"sorting":1,"ignoreStraights":false,"ignoreFlushes":false,"aceIsLow":false}}`
 	h := &hand.Hand{}
@@ -264,3 +285,45 @@ func BenchmarkHandCreation(b *testing.B) {
 		hand.New(cards)
 	}
 }
+
+func TestOmaha(t *testing.T) {
+	tests := []struct {
+		hole        []hand.Card
+		board       []hand.Card
+		options     []func(*hand.Config)
+		description string
+	}{
+		// four spades on board isn't a flush with one spade in hand
+		{Cards("As", "Kd", "Qc", "Jh"), Cards("2s", "5s", "8s", "9s", "Ts"), nil, "straight queen high"},
+		// quads in hand plays as a pair
+		{Cards("Ah", "Ad", "Ac", "As"), Cards("2c", "7d", "9h", "Jc", "Ks"), nil, "pair of aces"},
+		{Cards("Ah", "2d", "Kc", "Ks"), Cards("3c", "4d", "5h", "Kd", "Qs"), []func(*hand.Config){hand.AceToFiveLow}, "high card five high"},
+		{Cards("Ah", "Kd", "Kc", "Ks"), Cards("3c", "4d", "Kh"), nil, "three of a kind kings"},
+	}
+	for _, test := range tests {
+		h := hand.NewOmaha(test.hole, test.board, test.options...)
+		if h.Description() != test.description {
+			t.Fatalf("NewOmaha(%v, %v) = %v; want %s", test.hole, test.board, h, test.description)
+		}
+	}
+}
+
+func TestEnumStrings(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{hand.HighCard.String(), "HighCard"},
+		{hand.RoyalFlush.String(), "RoyalFlush"},
+		{hand.Ranking(0).String(), "Ranking(0)"},
+		{hand.Ranking(11).String(), "Ranking(11)"},
+		{hand.SortingHigh.String(), "SortingHigh"},
+		{hand.SortingLow.String(), "SortingLow"},
+		{hand.ASC.String(), "ASC"},
+		{hand.DESC.String(), "DESC"},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Fatalf("String() = %q; want %q", test.got, test.want)
+		}
+	}
+}
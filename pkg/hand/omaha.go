@@ -0,0 +1,38 @@
+package hand
+
+import "github.com/notnil/joker/util"
+
+// NewOmaha forms the best hand that uses exactly two of the hole cards and
+// three of the board cards, as in Omaha.  NewOmaha panics if there are
+// fewer than two hole cards or three board cards.
+func NewOmaha(hole, board []Card, options ...func(*Config)) *Hand {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	if len(hole) < 2 || len(board) < 3 {
+		panic("hand: omaha needs two hole cards and three board cards")
+	}
+	hands := []*Hand{}
+	for _, h := range util.Combinations(len(hole), 2) {
+		for _, b := range util.Combinations(len(board), 3) {
+			cards := []Card{hole[h[0]], hole[h[1]]}
+			for _, i := range b {
+				cards = append(cards, board[i])
+			}
+			hands = append(hands, handForFiveCards(cards, *c))
+		}
+	}
+	hands = Sort(c.sorting, DESC, hands...)
+	hands[0].config = c
+	return hands[0]
+}
+
+// Sorting returns the sorting of the configuration, which is SortingHigh
+// unless it was set to SortingLow.
+func (c *Config) Sorting() Sorting {
+	if c.sorting == SortingLow {
+		return SortingLow
+	}
+	return SortingHigh
+}
@@ -0,0 +1,56 @@
+package icm
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Approximate estimates Malmuth-Harville equities by sampling finishing
+// orders, which takes time linear in the number of trials regardless of
+// how many places are paid.  Each trial gives every player with chips an
+// exponentially distributed finishing time with a rate equal to their
+// stack and ranks players by it, which draws orders with exactly the
+// Malmuth-Harville probabilities.  Results are deterministic for a given
+// seed and the standard error shrinks with the square root of trials.
+func Approximate(stacks, payouts []float64, trials int, seed int64) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if trials <= 0 {
+		return nil, errors.New("icm: trials must be positive")
+	}
+	r := rand.New(rand.NewSource(seed))
+	eq := make([]float64, len(stacks))
+	times := make([]float64, len(stacks))
+	var live, busted []int
+	for i, s := range stacks {
+		if s > 0 {
+			live = append(live, i)
+		} else {
+			busted = append(busted, i)
+		}
+	}
+	order := make([]int, 0, len(stacks))
+	for t := 0; t < trials; t++ {
+		for _, i := range live {
+			times[i] = r.ExpFloat64() / stacks[i]
+		}
+		order = append(order[:0], live...)
+		sort.Slice(order, func(a, b int) bool {
+			return times[order[a]] < times[order[b]]
+		})
+		// players without chips finish last in a random order
+		r.Shuffle(len(busted), func(a, b int) {
+			busted[a], busted[b] = busted[b], busted[a]
+		})
+		order = append(order, busted...)
+		for place := 0; place < len(payouts) && place < len(order); place++ {
+			eq[order[place]] += payouts[place]
+		}
+	}
+	for i := range eq {
+		eq[i] /= float64(trials)
+	}
+	return eq, nil
+}
@@ -0,0 +1,67 @@
+package icm
+
+import "errors"
+
+// ChipChop returns a chip chop of the remaining prize pool.  Every player
+// is guaranteed the smallest payout still on offer to the players left and
+// the rest of the pool is split in proportion to chips.  Chip chops favour
+// big stacks and can pay the chip leader more than first place.
+func ChipChop(stacks, payouts []float64) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	n := len(stacks)
+	floor := 0.0
+	if len(payouts) >= n {
+		floor = payouts[n-1]
+	}
+	pool, chips := 0.0, 0.0
+	for i := 0; i < n && i < len(payouts); i++ {
+		pool += payouts[i]
+	}
+	for _, s := range stacks {
+		chips += s
+	}
+	if chips == 0 {
+		return nil, errors.New("icm: no chips in play")
+	}
+	rest := pool - floor*float64(n)
+	deal := make([]float64, n)
+	for i, s := range stacks {
+		deal[i] = floor + rest*s/chips
+	}
+	return deal, nil
+}
+
+// ICMChop returns an ICM chop of the remaining prize pool, paying every
+// player their Malmuth-Harville equity.
+func ICMChop(stacks, payouts []float64) ([]float64, error) {
+	return Equities(stacks, payouts)
+}
+
+// Custom returns the amounts paid to each player by an ICM deal that
+// leaves an amount in the pool for the players to keep playing for, with
+// the winner taking it.  Each player is paid their ICM equity less their
+// chance of winning times the amount left, so that every player's equity
+// is unchanged by the deal.  An error is returned if the amount left
+// would leave a player with a negative payment.
+func Custom(stacks, payouts []float64, leave float64) ([]float64, error) {
+	if leave < 0 {
+		return nil, errors.New("icm: negative amount left to play for")
+	}
+	eq, err := Equities(stacks, payouts)
+	if err != nil {
+		return nil, err
+	}
+	win, err := Equities(stacks, []float64{1})
+	if err != nil {
+		return nil, err
+	}
+	for i := range eq {
+		eq[i] -= win[i] * leave
+		if eq[i] < -1e-9 {
+			return nil, errors.New("icm: amount left to play for exceeds a player's equity")
+		}
+	}
+	return eq, nil
+}
@@ -0,0 +1,138 @@
+// Package icm calculates tournament equity with the Independent Chip
+// Model.
+package icm
+
+import "errors"
+
+// Equities returns each player's share of the prize pool under the
+// Malmuth-Harville model, in which the probability of a player finishing
+// in the next best remaining place is their share of the remaining chips.
+// Payouts are ordered from first place down and players beyond the paid
+// places receive nothing.  The work grows with the number of players
+// raised to the number of paid places, so large fields with deep payouts
+// should use Approximate.
+func Equities(stacks, payouts []float64) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if len(stacks) > 64 {
+		return nil, errors.New("icm: too many players")
+	}
+	m := &harville{stacks: stacks, payouts: payouts, memo: map[uint64][]float64{}}
+	all := uint64(1)<<uint(len(stacks)) - 1
+	return m.equities(all, 0), nil
+}
+
+// FinishProbabilities returns the probability of each player finishing
+// in each of the first places places under the Malmuth-Harville model.
+// The result is indexed by player and then by place.
+func FinishProbabilities(stacks []float64, places int) ([][]float64, error) {
+	if places < 0 || places > len(stacks) {
+		return nil, errors.New("icm: invalid number of places")
+	}
+	if err := validate(stacks, nil); err != nil {
+		return nil, err
+	}
+	if len(stacks) > 64 {
+		return nil, errors.New("icm: too many players")
+	}
+	all := uint64(1)<<uint(len(stacks)) - 1
+	probs := make([][]float64, len(stacks))
+	for i := range probs {
+		probs[i] = make([]float64, places)
+	}
+	for place := 0; place < places; place++ {
+		payouts := make([]float64, place+1)
+		payouts[place] = 1
+		m := &harville{stacks: stacks, payouts: payouts, memo: map[uint64][]float64{}}
+		for i, p := range m.equities(all, 0) {
+			probs[i][place] = p
+		}
+	}
+	return probs, nil
+}
+
+// Payouts splits pool by the given percentages of the prize pool, ordered
+// from first place down.  The percentages must not increase and must not
+// sum to more than one hundred.
+func Payouts(pool float64, percents ...float64) ([]float64, error) {
+	if pool < 0 {
+		return nil, errors.New("icm: negative prize pool")
+	}
+	payouts := make([]float64, len(percents))
+	total := 0.0
+	for i, p := range percents {
+		if p < 0 || (i > 0 && p > percents[i-1]) {
+			return nil, errors.New("icm: percentages must be positive and non-increasing")
+		}
+		total += p
+		payouts[i] = pool * p / 100
+	}
+	if total > 100+1e-9 {
+		return nil, errors.New("icm: percentages sum to more than 100")
+	}
+	return payouts, nil
+}
+
+func validate(stacks, payouts []float64) error {
+	if len(stacks) == 0 {
+		return errors.New("icm: no stacks")
+	}
+	for _, s := range stacks {
+		if s < 0 {
+			return errors.New("icm: negative stack")
+		}
+	}
+	for i, p := range payouts {
+		if p < 0 || (i > 0 && p > payouts[i-1]) {
+			return errors.New("icm: payouts must be positive and non-increasing")
+		}
+	}
+	return nil
+}
+
+type harville struct {
+	stacks  []float64
+	payouts []float64
+	memo    map[uint64][]float64
+}
+
+// equities returns the equities of the players in the set when they play
+// for the places starting at place.
+func (h *harville) equities(set uint64, place int) []float64 {
+	eq := make([]float64, len(h.stacks))
+	if place >= len(h.payouts) || set == 0 {
+		return eq
+	}
+	if cached, ok := h.memo[set]; ok {
+		return cached
+	}
+	total := 0.0
+	n := 0
+	for i, s := range h.stacks {
+		if set&(1<<uint(i)) != 0 {
+			total += s
+			n++
+		}
+	}
+	for i, s := range h.stacks {
+		if set&(1<<uint(i)) == 0 {
+			continue
+		}
+		// players without chips finish last and share the places
+		// left once only they remain
+		p := s / total
+		if total == 0 {
+			p = 1 / float64(n)
+		}
+		if p == 0 {
+			continue
+		}
+		eq[i] += p * h.payouts[place]
+		for j, v := range h.equities(set&^(1<<uint(i)), place+1) {
+			eq[j] += p * v
+		}
+	}
+	h.memo[set] = eq
+	return eq
+}
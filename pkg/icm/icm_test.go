@@ -0,0 +1,194 @@
+package icm_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/notnil/joker/pkg/icm"
+)
+
+type equityTest struct {
+	stacks   []float64
+	payouts  []float64
+	equities []float64
+}
+
+var equityTests = []equityTest{
+	{
+		// Malmuth's example from Gambling Theory and Other Topics
+		stacks:   []float64{5000, 3000, 2000},
+		payouts:  []float64{50, 30, 20},
+		equities: []float64{38.393, 32.750, 28.857},
+	},
+	{
+		stacks:   []float64{1000, 1000},
+		payouts:  []float64{70, 30},
+		equities: []float64{50, 50},
+	},
+	{
+		stacks:   []float64{3000, 0, 1000},
+		payouts:  []float64{50, 30, 20},
+		equities: []float64{45, 20, 35},
+	},
+}
+
+func TestEquities(t *testing.T) {
+	for _, test := range equityTests {
+		eq, err := icm.Equities(test.stacks, test.payouts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range eq {
+			if math.Abs(eq[i]-test.equities[i]) > 0.001 {
+				t.Fatalf("Equities(%v, %v) = %v; want %v", test.stacks, test.payouts, eq, test.equities)
+			}
+		}
+	}
+	if _, err := icm.Equities([]float64{-1, 10}, []float64{1}); err == nil {
+		t.Fatal("expected error for negative stack")
+	}
+}
+
+func TestFinishProbabilities(t *testing.T) {
+	probs, err := icm.FinishProbabilities([]float64{5000, 3000, 2000}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3000 finishes second behind 5000 with probability 0.5*0.6 and
+	// behind 2000 with probability 0.2*0.375
+	if math.Abs(probs[1][1]-0.375) > 1e-9 {
+		t.Fatalf("second place probability = %v; want 0.375", probs[1][1])
+	}
+	for place := 0; place < 3; place++ {
+		sum := 0.0
+		for i := range probs {
+			sum += probs[i][place]
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Fatalf("place %d probabilities sum to %v", place, sum)
+		}
+	}
+}
+
+func TestApproximate(t *testing.T) {
+	stacks := []float64{4000, 2500, 2500, 1500, 1000, 500, 0}
+	payouts := []float64{40, 25, 15, 10, 6, 4}
+	exact, err := icm.Equities(stacks, payouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	approx, err := icm.Approximate(stacks, payouts, 100000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := icm.Approximate(stacks, payouts, 100000, 1)
+	for i := range exact {
+		if math.Abs(exact[i]-approx[i]) > 0.3 {
+			t.Fatalf("Approximate = %v; want about %v", approx, exact)
+		}
+		if approx[i] != again[i] {
+			t.Fatal("Approximate is not deterministic for a seed")
+		}
+	}
+	if approx[6] != 0 {
+		t.Fatalf("player without chips has equity %v", approx[6])
+	}
+}
+
+func TestApproximateLargeField(t *testing.T) {
+	stacks := make([]float64, 200)
+	for i := range stacks {
+		stacks[i] = 1000
+	}
+	payouts := make([]float64, 30)
+	for i := range payouts {
+		payouts[i] = float64(30 - i)
+	}
+	eq, err := icm.Approximate(stacks, payouts, 2000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := 0.0
+	for _, e := range eq {
+		sum += e
+	}
+	// equal stacks have equal equity and the whole pool is paid out
+	if math.Abs(sum-465) > 1e-6 || math.Abs(eq[0]-465.0/200) > 1 {
+		t.Fatalf("unexpected equities %v", eq)
+	}
+}
+
+func TestPayouts(t *testing.T) {
+	p, err := icm.Payouts(10000, 50, 30, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p[0] != 5000 || p[1] != 3000 || p[2] != 2000 {
+		t.Fatalf("Payouts = %v", p)
+	}
+	if _, err := icm.Payouts(10000, 60, 30, 20); err == nil {
+		t.Fatal("expected error for percentages over 100")
+	}
+	if _, err := icm.Payouts(10000, 20, 30); err == nil {
+		t.Fatal("expected error for increasing percentages")
+	}
+}
+
+type dealTest struct {
+	name    string
+	deal    func(stacks, payouts []float64) ([]float64, error)
+	stacks  []float64
+	payouts []float64
+	want    []float64
+}
+
+var dealTests = []dealTest{
+	{
+		name:    "chip chop",
+		deal:    icm.ChipChop,
+		stacks:  []float64{5000, 3000, 2000},
+		payouts: []float64{50, 30, 20},
+		want:    []float64{40, 32, 28},
+	},
+	{
+		// a chip chop can pay the chip leader more than first place
+		name:    "chip chop leader",
+		deal:    icm.ChipChop,
+		stacks:  []float64{9000, 500, 500},
+		payouts: []float64{50, 30, 20},
+		want:    []float64{56, 22, 22},
+	},
+	{
+		name:    "icm chop",
+		deal:    icm.ICMChop,
+		stacks:  []float64{5000, 3000, 2000},
+		payouts: []float64{50, 30, 20},
+		want:    []float64{38.393, 32.750, 28.857},
+	},
+	{
+		name: "custom",
+		deal: func(stacks, payouts []float64) ([]float64, error) {
+			return icm.Custom(stacks, payouts, 10)
+		},
+		stacks:  []float64{5000, 3000, 2000},
+		payouts: []float64{50, 30, 20},
+		want:    []float64{33.393, 29.750, 26.857},
+	},
+}
+
+func TestDeals(t *testing.T) {
+	for _, test := range dealTests {
+		got, err := test.deal(test.stacks, test.payouts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range got {
+			if math.Abs(got[i]-test.want[i]) > 0.001 {
+				t.Fatalf("%s = %v; want %v", test.name, got, test.want)
+			}
+		}
+	}
+	if _, err := icm.Custom([]float64{9000, 500, 500}, []float64{50, 30, 20}, 200); err == nil {
+		t.Fatal("expected error when the amount left exceeds a player's equity")
+	}
+}
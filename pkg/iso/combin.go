@@ -0,0 +1,97 @@
+package iso
+
+import "math/bits"
+
+// choose returns the binomial coefficient n choose k.
+func choose(n, k uint64) uint64 {
+	if k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	c := uint64(1)
+	for i := uint64(0); i < k; i++ {
+		// c*(n-i) may overflow even when the result doesn't
+		hi, lo := bits.Mul64(c, n-i)
+		c, _ = bits.Div64(hi, lo, i+1)
+	}
+	return c
+}
+
+// colex returns the colexicographic index of the set of bits among all
+// sets of the same size.
+func colex(mask uint16) uint64 {
+	idx := uint64(0)
+	for i := uint64(1); mask != 0; i++ {
+		pos := uint64(bits.TrailingZeros16(mask))
+		idx += choose(pos, i)
+		mask &= mask - 1
+	}
+	return idx
+}
+
+// colexMask returns the set of k bits with the given colexicographic index.
+func colexMask(idx uint64, k int) uint16 {
+	var mask uint16
+	for i := k; i > 0; i-- {
+		pos := largest(idx, uint64(i))
+		mask |= 1 << pos
+		idx -= choose(pos, uint64(i))
+	}
+	return mask
+}
+
+// largest returns the largest n such that n choose k <= v.
+func largest(v, k uint64) uint64 {
+	lo, hi := k-1, k
+	for choose(hi, k) <= v {
+		lo, hi = hi, hi*2
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if choose(mid, k) <= v {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// compress removes the used bits from the mask and shifts the remaining
+// bits down to fill their places.
+func compress(mask, used uint16) uint16 {
+	var out uint16
+	pos := uint(0)
+	for i := uint(0); i < 13; i++ {
+		if used&(1<<i) != 0 {
+			continue
+		}
+		if mask&(1<<i) != 0 {
+			out |= 1 << pos
+		}
+		pos++
+	}
+	return out
+}
+
+// expand is the inverse of compress.
+func expand(mask, used uint16) uint16 {
+	var out uint16
+	pos := uint(0)
+	for i := uint(0); i < 13; i++ {
+		if used&(1<<i) != 0 {
+			continue
+		}
+		if mask&(1<<pos) != 0 {
+			out |= 1 << i
+		}
+		pos++
+	}
+	return out
+}
+
+func popcount(mask uint16) int {
+	return bits.OnesCount16(mask)
+}
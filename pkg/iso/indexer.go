@@ -0,0 +1,277 @@
+package iso
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// An Indexer maps the canonical situations of a sequence of rounds to
+// dense indexes from zero to Size()-1.  Every situation in the same
+// isomorphism class has the same index.  The indexing scheme follows
+// Waugh's "A Fast and Optimal Hand Isomorphism Algorithm": suits are
+// grouped by how many cards they have in each round, and suits with the
+// same counts are indexed as a multiset since they can be swapped freely.
+type Indexer struct {
+	rounds  []int
+	configs []config
+	offsets []uint64
+	lookup  map[string]int
+}
+
+// config is one way the cards of each round can be distributed among
+// the suits, with suits ordered by descending counts.
+type config struct {
+	counts [4][]int
+	groups []group
+}
+
+// group is a run of suits in a config with the same counts.
+type group struct {
+	start, n int
+	usages   uint64
+	size     uint64
+}
+
+// NewIndexer returns an indexer for rounds with the given number of
+// cards each, such as 2, 3, 1, 1 for hold'em hole cards, flop, turn, and
+// river.
+func NewIndexer(rounds ...int) (*Indexer, error) {
+	total := 0
+	for _, n := range rounds {
+		if n < 1 {
+			return nil, errors.New("iso: rounds must have at least one card")
+		}
+		total += n
+	}
+	if len(rounds) == 0 || total > 52 {
+		return nil, errors.New("iso: rounds must have between one and 52 cards")
+	}
+	idx := &Indexer{rounds: append([]int{}, rounds...), lookup: map[string]int{}}
+	idx.enumerate(0, make([]int, len(rounds)), [4][]int{}, nil)
+	idx.offsets = make([]uint64, len(idx.configs)+1)
+	for i, c := range idx.configs {
+		size := uint64(1)
+		for _, g := range c.groups {
+			size *= g.size
+		}
+		idx.offsets[i+1] = idx.offsets[i] + size
+	}
+	return idx, nil
+}
+
+// ForStreet returns an indexer for hold'em hole cards and a board of
+// zero, three, four, or five cards with each street as its own round.
+func ForStreet(board int) (*Indexer, error) {
+	switch board {
+	case 0:
+		return NewIndexer(2)
+	case 3:
+		return NewIndexer(2, 3)
+	case 4:
+		return NewIndexer(2, 3, 1)
+	case 5:
+		return NewIndexer(2, 3, 1, 1)
+	}
+	return nil, errors.New("iso: board must have zero, three, four, or five cards")
+}
+
+// Size returns the number of canonical situations.
+func (idx *Indexer) Size() uint64 {
+	return idx.offsets[len(idx.offsets)-1]
+}
+
+// Rounds returns the number of cards in each round.
+func (idx *Indexer) Rounds() []int {
+	return append([]int{}, idx.rounds...)
+}
+
+// Index returns the index of the cards, which are given round by round.
+func (idx *Indexer) Index(cards []hand.Card) (uint64, error) {
+	rounds, err := idx.split(cards)
+	if err != nil {
+		return 0, err
+	}
+	suits := describeSuits(rounds)
+	order := sortSuits(suits)
+
+	counts := [4][]int{}
+	for i, s := range order {
+		counts[i] = suits[s].counts()
+	}
+	ci := idx.lookup[countsKey(counts)]
+	c := idx.configs[ci]
+
+	index, mult := uint64(0), uint64(1)
+	for _, g := range c.groups {
+		usages := make([]uint64, g.n)
+		for i := range usages {
+			usages[i] = suits[order[g.start+i]].index()
+		}
+		index += mult * multisetIndex(usages)
+		mult *= g.size
+	}
+	return idx.offsets[ci] + index, nil
+}
+
+// Unindex returns the canonical cards for the index round by round.
+// Unindex panics if the index isn't less than Size().
+func (idx *Indexer) Unindex(index uint64) []hand.Card {
+	if index >= idx.Size() {
+		panic("iso: index out of range")
+	}
+	ci := sort.Search(len(idx.configs), func(i int) bool {
+		return idx.offsets[i+1] > index
+	})
+	c := idx.configs[ci]
+	index -= idx.offsets[ci]
+
+	var suits [4]suitUsage
+	for _, g := range c.groups {
+		usages := multisetUsages(index%g.size, g.n)
+		index /= g.size
+		for i, u := range usages {
+			suits[g.start+i] = usageFor(c.counts[g.start+i], u)
+		}
+	}
+
+	cards := []hand.Card{}
+	for r := range idx.rounds {
+		round := []hand.Card{}
+		for s, u := range suits {
+			for rank := hand.Two; rank <= hand.Ace; rank++ {
+				if u[r]&(1<<uint(rank)) != 0 {
+					round = append(round, cardFor(rank, hand.Suit(s)))
+				}
+			}
+		}
+		sortCards(round)
+		cards = append(cards, round...)
+	}
+	return cards
+}
+
+func (idx *Indexer) split(cards []hand.Card) ([][]hand.Card, error) {
+	total := 0
+	for _, n := range idx.rounds {
+		total += n
+	}
+	if len(cards) != total {
+		return nil, errors.New("iso: wrong number of cards for indexer")
+	}
+	seen := map[hand.Card]bool{}
+	for _, c := range cards {
+		if seen[c] {
+			return nil, errors.New("iso: duplicate card " + c.String())
+		}
+		seen[c] = true
+	}
+	rounds := [][]hand.Card{}
+	for _, n := range idx.rounds {
+		rounds = append(rounds, cards[:n])
+		cards = cards[n:]
+	}
+	return rounds, nil
+}
+
+// enumerate adds every config by giving each suit in turn counts no
+// greater than the previous suit's.
+func (idx *Indexer) enumerate(suit int, used []int, counts [4][]int, prev []int) {
+	if suit == 4 {
+		for r, n := range used {
+			if n != idx.rounds[r] {
+				return
+			}
+		}
+		idx.addConfig(counts)
+		return
+	}
+	cur := make([]int, len(idx.rounds))
+	var rec func(r, total int)
+	rec = func(r, total int) {
+		if r == len(idx.rounds) {
+			if prev != nil && compareCounts(cur, prev) > 0 {
+				return
+			}
+			next := counts
+			next[suit] = append([]int{}, cur...)
+			for i := range used {
+				used[i] += cur[i]
+			}
+			idx.enumerate(suit+1, used, next, next[suit])
+			for i := range used {
+				used[i] -= cur[i]
+			}
+			return
+		}
+		for n := 0; n <= idx.rounds[r]-used[r] && total+n <= 13; n++ {
+			cur[r] = n
+			rec(r+1, total+n)
+		}
+		cur[r] = 0
+	}
+	rec(0, 0)
+}
+
+func (idx *Indexer) addConfig(counts [4][]int) {
+	c := config{counts: counts}
+	for i := 0; i < 4; {
+		j := i + 1
+		for j < 4 && compareCounts(counts[j], counts[i]) == 0 {
+			j++
+		}
+		usages := uint64(1)
+		used := 0
+		for _, k := range counts[i] {
+			usages *= choose(uint64(13-used), uint64(k))
+			used += k
+		}
+		c.groups = append(c.groups, group{
+			start:  i,
+			n:      j - i,
+			usages: usages,
+			size:   choose(usages+uint64(j-i)-1, uint64(j-i)),
+		})
+		i = j
+	}
+	idx.lookup[countsKey(counts)] = len(idx.configs)
+	idx.configs = append(idx.configs, c)
+}
+
+func countsKey(counts [4][]int) string {
+	b := []byte{}
+	for _, c := range counts {
+		for _, n := range c {
+			b = append(b, byte(n))
+		}
+	}
+	return string(b)
+}
+
+// multisetIndex returns the index of the multiset of usage indexes among
+// all multisets of the same size.
+func multisetIndex(usages []uint64) uint64 {
+	sorted := append([]uint64{}, usages...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+	g := uint64(len(sorted))
+	idx := uint64(0)
+	for i, u := range sorted {
+		k := g - uint64(i)
+		idx += choose(u+k-1, k)
+	}
+	return idx
+}
+
+// multisetUsages is the inverse of multisetIndex and returns the usage
+// indexes in descending order.
+func multisetUsages(idx uint64, n int) []uint64 {
+	usages := make([]uint64, n)
+	for i := range usages {
+		k := uint64(n - i)
+		b := largest(idx, k)
+		usages[i] = b - (k - 1)
+		idx -= choose(b, k)
+	}
+	return usages
+}
@@ -0,0 +1,170 @@
+// Package iso canonicalizes suit-isomorphic hold'em situations and maps
+// them to dense indexes.
+//
+// Two situations are isomorphic if one can be turned into the other by
+// renaming suits, such as A♠ K♠ on Q♠ J♠ 7♦ and A♥ K♥ on Q♥ J♥ 7♣.
+// Situations are described as rounds of cards where the order of cards
+// within a round doesn't matter, such as the hole cards and the flop.
+package iso
+
+import (
+	"sort"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// Canonicalize returns the canonical form of the hole cards and board
+// along with the number of distinct situations that share it.  The
+// canonical form assigns suits in the order spades, hearts, diamonds,
+// and clubs to the suits of the situation ordered by how they're used.
+// Cards within the hole and board are sorted by suit and then by
+// descending rank.
+func Canonicalize(hole, board []hand.Card) (canonicalHole, canonicalBoard []hand.Card, multiplicity int) {
+	rounds := [][]hand.Card{hole, board}
+	suits := describeSuits(rounds)
+	order := sortSuits(suits)
+
+	mapping := map[hand.Suit]hand.Suit{}
+	for i, s := range order {
+		mapping[s] = hand.Suit(i)
+	}
+	canonical := make([][]hand.Card, len(rounds))
+	for i, round := range rounds {
+		canonical[i] = make([]hand.Card, len(round))
+		for j, c := range round {
+			canonical[i][j] = cardFor(c.Rank(), mapping[c.Suit()])
+		}
+		sortCards(canonical[i])
+	}
+
+	// suits with identical usage can be swapped without changing the
+	// situation, so the multiplicity is 4! over the ways to permute them
+	multiplicity = 24
+	for i := 0; i < 4; {
+		j := i + 1
+		for j < 4 && suits[order[j]].equal(suits[order[i]]) {
+			j++
+		}
+		multiplicity /= factorial(j - i)
+		i = j
+	}
+	return canonical[0], canonical[1], multiplicity
+}
+
+// suitUsage is the set of ranks a suit has in each round.
+type suitUsage []uint16
+
+func describeSuits(rounds [][]hand.Card) [4]suitUsage {
+	var suits [4]suitUsage
+	for s := range suits {
+		suits[s] = make(suitUsage, len(rounds))
+	}
+	for r, round := range rounds {
+		for _, c := range round {
+			suits[c.Suit()][r] |= 1 << uint(c.Rank())
+		}
+	}
+	return suits
+}
+
+func (u suitUsage) counts() []int {
+	counts := make([]int, len(u))
+	for r, mask := range u {
+		counts[r] = popcount(mask)
+	}
+	return counts
+}
+
+func (u suitUsage) equal(o suitUsage) bool {
+	for r := range u {
+		if u[r] != o[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// less orders usages by their counts in each round and then by their
+// rank index so that it matches the order used by an Indexer.
+func (u suitUsage) less(o suitUsage) bool {
+	if c := compareCounts(u.counts(), o.counts()); c != 0 {
+		return c < 0
+	}
+	return u.index() < o.index()
+}
+
+// index returns the index of the ranks among all usages with the same
+// counts.  Each round's ranks are indexed colexicographically among the
+// ranks unused by earlier rounds.
+func (u suitUsage) index() uint64 {
+	var used uint16
+	idx, mult := uint64(0), uint64(1)
+	for _, mask := range u {
+		n, k := 13-popcount(used), popcount(mask)
+		idx += mult * colex(compress(mask, used))
+		mult *= choose(uint64(n), uint64(k))
+		used |= mask
+	}
+	return idx
+}
+
+// usageFor returns the usage with the given counts and index.
+func usageFor(counts []int, idx uint64) suitUsage {
+	u := make(suitUsage, len(counts))
+	var used uint16
+	for r, k := range counts {
+		n := 13 - popcount(used)
+		size := choose(uint64(n), uint64(k))
+		u[r] = expand(colexMask(idx%size, k), used)
+		idx /= size
+		used |= u[r]
+	}
+	return u
+}
+
+// sortSuits returns the suits ordered by descending usage.
+func sortSuits(suits [4]suitUsage) []hand.Suit {
+	order := []hand.Suit{hand.Spades, hand.Hearts, hand.Diamonds, hand.Clubs}
+	sort.SliceStable(order, func(i, j int) bool {
+		return suits[order[j]].less(suits[order[i]])
+	})
+	return order
+}
+
+func compareCounts(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+func sortCards(cards []hand.Card) {
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Suit() != cards[j].Suit() {
+			return cards[i].Suit() < cards[j].Suit()
+		}
+		return cards[i].Rank() > cards[j].Rank()
+	})
+}
+
+var cardTable [4][13]hand.Card
+
+func init() {
+	for _, c := range hand.Cards() {
+		cardTable[c.Suit()][c.Rank()] = c
+	}
+}
+
+func cardFor(r hand.Rank, s hand.Suit) hand.Card {
+	return cardTable[s][r]
+}
+
+func factorial(n int) int {
+	f := 1
+	for i := 2; i <= n; i++ {
+		f *= i
+	}
+	return f
+}
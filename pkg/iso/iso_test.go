@@ -0,0 +1,168 @@
+package iso_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/iso"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestCanonicalize(t *testing.T) {
+	h1, b1, m1 := iso.Canonicalize(Cards("As", "Ks"), Cards("Qs", "Js", "7d"))
+	h2, b2, m2 := iso.Canonicalize(Cards("Kh", "Ah"), Cards("7c", "Jh", "Qh"))
+	if !equal(h1, h2) || !equal(b1, b2) || m1 != m2 {
+		t.Fatalf("expected %v %v (%d) to equal %v %v (%d)", h1, b1, m1, h2, b2, m2)
+	}
+	if m1 != 12 {
+		t.Fatalf("multiplicity = %d; want %d", m1, 12)
+	}
+	if !equal(h1, Cards("As", "Ks")) || !equal(b1, Cards("Qs", "Js", "7h")) {
+		t.Fatalf("canonical form = %v %v; want %v %v", h1, b1, Cards("As", "Ks"), Cards("Qs", "Js", "7h"))
+	}
+}
+
+func TestMultiplicity(t *testing.T) {
+	// every situation of two hole cards and one board card is counted by
+	// the multiplicity of its canonical form
+	counts := map[string]int{}
+	multiplicities := map[string]int{}
+	cards := hand.Cards()
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			for k := range cards {
+				if k == i || k == j {
+					continue
+				}
+				h, b, m := iso.Canonicalize([]hand.Card{cards[i], cards[j]}, []hand.Card{cards[k]})
+				key := (&hand.Deck{Cards: append(h, b...)}).String()
+				counts[key]++
+				multiplicities[key] = m
+			}
+		}
+	}
+	for key, n := range counts {
+		if multiplicities[key] != n {
+			t.Fatalf("%s has multiplicity %d but occurs %d times", key, multiplicities[key], n)
+		}
+	}
+}
+
+var sizes = []uint64{169, 1286792, 55190538, 2428287420}
+
+func TestSizes(t *testing.T) {
+	for i, board := range []int{0, 3, 4, 5} {
+		idx, err := iso.ForStreet(board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx.Size() != sizes[i] {
+			t.Fatalf("size with %d board cards = %d; want %d", board, idx.Size(), sizes[i])
+		}
+	}
+	if _, err := iso.ForStreet(2); err == nil {
+		t.Fatal("expected error for two board cards")
+	}
+}
+
+func TestPreflopIndex(t *testing.T) {
+	idx, err := iso.ForStreet(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[uint64]int{}
+	cards := hand.Cards()
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			n, err := idx.Index([]hand.Card{cards[i], cards[j]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen[n]++
+		}
+	}
+	if len(seen) != 169 {
+		t.Fatalf("distinct indexes = %d; want %d", len(seen), 169)
+	}
+	for n := uint64(0); n < idx.Size(); n++ {
+		m, err := idx.Index(idx.Unindex(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m != n {
+			t.Fatalf("Index(Unindex(%d)) = %d", n, m)
+		}
+	}
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	perms := [][]hand.Suit{{hand.Clubs, hand.Spades, hand.Diamonds, hand.Hearts}, {hand.Hearts, hand.Clubs, hand.Spades, hand.Diamonds}}
+	for _, board := range []int{3, 4, 5} {
+		idx, err := iso.ForStreet(board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000; i++ {
+			cards := hand.NewDealer(r).Deck().PopMulti(2 + board)
+			n, err := idx.Index(cards)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n >= idx.Size() {
+				t.Fatalf("index %d out of range %d", n, idx.Size())
+			}
+			if m, _ := idx.Index(idx.Unindex(n)); m != n {
+				t.Fatalf("Index(Unindex(%d)) = %d for %v", n, m, cards)
+			}
+			for _, perm := range perms {
+				if m, _ := idx.Index(permute(cards, perm)); m != n {
+					t.Fatalf("suit permutation of %v has index %d; want %d", cards, m, n)
+				}
+			}
+
+			n = uint64(r.Int63n(int64(idx.Size())))
+			if m, _ := idx.Index(idx.Unindex(n)); m != n {
+				t.Fatalf("Index(Unindex(%d)) = %d", n, m)
+			}
+		}
+	}
+}
+
+func TestIndexInvalid(t *testing.T) {
+	idx, err := iso.ForStreet(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.Index(Cards("As", "Ks", "Qs")); err == nil {
+		t.Fatal("expected error for wrong number of cards")
+	}
+	if _, err := idx.Index(Cards("As", "Ks", "Qs", "Js", "As")); err == nil {
+		t.Fatal("expected error for duplicate cards")
+	}
+}
+
+func permute(cards []hand.Card, perm []hand.Suit) []hand.Card {
+	out := []hand.Card{}
+	for _, c := range cards {
+		for _, o := range hand.Cards() {
+			if o.Rank() == c.Rank() && o.Suit() == perm[c.Suit()] {
+				out = append(out, o)
+			}
+		}
+	}
+	return out
+}
+
+func equal(a, b []hand.Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package match
+
+import (
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// streets are the rounds that start with board cards dealt and the size
+// of the board before and after the deal.
+var streets = []struct {
+	round         table.Round
+	before, after int
+}{
+	{table.Flop, 0, 3},
+	{table.Turn, 3, 4},
+	{table.River, 4, 5},
+}
+
+// luck returns how much of each seat's result was down to the board cards.
+// It is the chance node term of AIVAT with each player's share of the pot
+// valued at their equity: whenever board cards are dealt to two or more
+// players, a player's luck is the pot times the change in their equity.
+// The equity before a deal is the expected equity after it, so luck
+// averages to zero and subtracting it leaves an unbiased estimate of the
+// result with much less variance.  Agents' decisions aren't corrected for,
+// as that needs their strategies.
+//
+// The cards of players who folded are out of the deck, as they were when
+// the board was dealt.  Equities that can't be enumerated cheaply are
+// sampled, which keeps the correction unbiased but adds a little noise.
+func luck(rec *table.Record, r *rand.Rand, samples int) (map[int]float64, error) {
+	l := map[int]float64{}
+	// equities by board size, reused as the equity after one deal is the
+	// equity before the next
+	equities := map[int][]float64{}
+	for _, st := range streets {
+		if len(rec.Board) < st.after {
+			break
+		}
+		var seats []int
+		var hands [][]hand.Card
+		var dead []hand.Card
+		for _, s := range rec.Seats {
+			if folded(rec, s.Seat, st.round) {
+				dead = append(dead, s.Cards...)
+			} else {
+				seats = append(seats, s.Seat)
+				hands = append(hands, s.Cards)
+			}
+		}
+		if len(seats) < 2 {
+			break
+		}
+		if len(equities[st.before]) != len(seats) {
+			// the players still in changed
+			delete(equities, st.before)
+		}
+		for _, n := range []int{st.before, st.after} {
+			if _, ok := equities[n]; ok {
+				continue
+			}
+			res, err := equity.Hands(hands, rec.Board[:n], equity.Dead(dead...),
+				equity.WithRand(r), equity.Iterations(samples), equity.ExactLimit(50))
+			if err != nil {
+				return nil, err
+			}
+			equities[n] = res.Equity
+		}
+		pot := float64(pot(rec, st.round))
+		for i, s := range seats {
+			l[s] += pot * (equities[st.after][i] - equities[st.before][i])
+		}
+	}
+	return l, nil
+}
+
+// folded reports whether a seat folded before a round.
+func folded(rec *table.Record, seat int, round table.Round) bool {
+	for _, a := range rec.Actions {
+		if a.Seat == seat && a.Type == table.Fold && a.Round < round {
+			return true
+		}
+	}
+	return false
+}
+
+// pot returns the chips put in before a round, leaving out any part of a
+// bet nobody could match.
+func pot(rec *table.Record, round table.Round) int {
+	total := map[int]int{}
+	bet := map[int]int{}
+	current := table.Preflop
+	for _, a := range rec.Actions {
+		if a.Round >= round {
+			break
+		}
+		if a.Round != current {
+			current = a.Round
+			bet = map[int]int{}
+		}
+		switch a.Type {
+		case table.PostSmallBlind, table.PostBigBlind, table.Call:
+			total[a.Seat] += a.Amount
+			bet[a.Seat] += a.Amount
+		case table.PostAnte:
+			total[a.Seat] += a.Amount
+		case table.Bet, table.Raise:
+			total[a.Seat] += a.Amount - bet[a.Seat]
+			bet[a.Seat] = a.Amount
+		}
+	}
+	sum := 0
+	for seat, t := range total {
+		most := 0
+		for other, u := range total {
+			if other != seat && u > most {
+				most = u
+			}
+		}
+		if t > most {
+			t = most
+		}
+		sum += t
+	}
+	return sum
+}
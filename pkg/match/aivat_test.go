@@ -0,0 +1,61 @@
+package match
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/table"
+)
+
+func TestLuckFolded(t *testing.T) {
+	// the button folds the other two queens, so queens are drawing dead
+	// against kings from the flop on
+	cards := Cards("Qs", "Qh", "Kd", "Kc", "Qd", "Qc", "2s", "3h", "7d", "9c", "Jh")
+	tbl, err := table.New(3, table.WithDealer(Dealer(cards)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for seat := 0; seat < 3; seat++ {
+		if err := tbl.Sit(seat, string(rune('a'+seat)), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []table.ActionType{table.Fold, table.Call, table.Check} {
+		if err := tbl.Act(tbl.ToAct(), table.Action{Type: a}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for tbl.Active() {
+		if err := tbl.Act(tbl.ToAct(), table.Action{Type: table.Check}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec := tbl.Record()
+	if len(rec.Board) != 5 || rec.Seats[2].Cards[0] != cards[4] {
+		t.Fatalf("unexpected deal %+v", rec)
+	}
+
+	l, err := luck(rec, rand.New(rand.NewSource(1)), 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the pot doesn't change after the flop, so the luck adds up to the
+	// pot times the queens' final equity of zero less their equity before
+	// the flop with the folded queens out of the deck
+	res, err := equity.Hands([][]hand.Card{cards[4:6], cards[2:4]}, nil,
+		equity.Dead(cards[:2]...), equity.WithRand(rand.New(rand.NewSource(2))), equity.Iterations(5000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := -float64(pot(rec, table.Flop)) * res.Equity[0]
+	if _, ok := l[0]; ok || math.Abs(l[2]-want) > 0.1 || math.Abs(l[1]+l[2]) > 1e-9 {
+		t.Fatalf("luck = %v; want %v for the queens", l, want)
+	}
+}
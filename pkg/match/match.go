@@ -0,0 +1,256 @@
+// Package match plays agents against each other to measure how much they
+// win.  Poker results are noisy, so matches can be played in duplicate,
+// where every deck is dealt once for each rotation of the agents around
+// the table, and scored with an AIVAT style correction that removes the
+// luck of the board cards.
+package match
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Config represents the configuration options for a match.
+type Config struct {
+	deals      int
+	seed       int64
+	smallBlind int
+	bigBlind   int
+	stack      int
+	duplicate  bool
+	samples    int
+	progress   func(deals int)
+}
+
+// Deals sets the number of decks dealt.  Each deck is played once, or once
+// for every seat rotation in a duplicate match.  The default is 1000.
+func Deals(n int) func(*Config) {
+	return func(c *Config) {
+		c.deals = n
+	}
+}
+
+// Seed sets the seed the decks are shuffled from, so that the same seed
+// deals the same cards.  The default is 1.
+func Seed(seed int64) func(*Config) {
+	return func(c *Config) {
+		c.seed = seed
+	}
+}
+
+// Stakes sets the blinds.  The default is 1 and 2.
+func Stakes(small, big int) func(*Config) {
+	return func(c *Config) {
+		c.smallBlind = small
+		c.bigBlind = big
+	}
+}
+
+// Stack sets the chips every player starts each hand with.  The default is
+// 100 big blinds.
+func Stack(chips int) func(*Config) {
+	return func(c *Config) {
+		c.stack = chips
+	}
+}
+
+// Duplicate plays every deck once for each rotation of the agents around
+// the table, so that each agent is dealt each seat's cards.
+func Duplicate(c *Config) {
+	c.duplicate = true
+}
+
+// AIVAT scores hands with the luck of the board cards removed, estimating
+// the equities the correction needs with the given number of samples where
+// they can't be enumerated cheaply.
+func AIVAT(samples int) func(*Config) {
+	return func(c *Config) {
+		c.samples = samples
+	}
+}
+
+// Progress sets a function called after each deck is played with the
+// number of decks played so far.
+func Progress(f func(deals int)) func(*Config) {
+	return func(c *Config) {
+		c.progress = f
+	}
+}
+
+// Result holds the outcome of a match for each agent, in the order the
+// agents were given.
+type Result struct {
+	Hands   int      `json:"hands"`
+	Players []Player `json:"players"`
+}
+
+// Player is an agent's results.  Winnings are in milli big blinds per hand
+// with the half width of a 95% confidence interval.  Adjusted is the same
+// after the AIVAT correction and is only set for matches scored with it.
+type Player struct {
+	Hands    int       `json:"hands"`
+	Net      int       `json:"net"`
+	Winnings Estimate  `json:"winnings"`
+	Adjusted *Estimate `json:"adjusted,omitempty"`
+}
+
+// Estimate is a mean with the half width of its 95% confidence interval.
+type Estimate struct {
+	Mean float64 `json:"mean"`
+	CI95 float64 `json:"ci95"`
+}
+
+// String returns the estimate as "mean ± ci95".
+func (e Estimate) String() string {
+	return fmt.Sprintf("%.1f ± %.1f", e.Mean, e.CI95)
+}
+
+// Play plays a match between two to ten agents, one in each seat, with
+// stacks reset before every hand.  The button is always seat 0, and the
+// agents are rotated around the table from one deal to the next, or
+// through every rotation for each deal of a duplicate match.
+func Play(agents []agent.Agent, options ...func(*Config)) (*Result, error) {
+	c := &Config{deals: 1000, seed: 1, smallBlind: 1, bigBlind: 2}
+	for _, option := range options {
+		option(c)
+	}
+	if c.stack == 0 {
+		c.stack = 100 * c.bigBlind
+	}
+	n := len(agents)
+	switch {
+	case n < 2 || n > 10:
+		return nil, errors.New("match: need two to ten agents")
+	case c.deals <= 0:
+		return nil, errors.New("match: deals must be positive")
+	case c.smallBlind <= 0 || c.bigBlind < c.smallBlind:
+		return nil, errors.New("match: invalid stakes")
+	case c.stack < c.bigBlind:
+		return nil, errors.New("match: stacks must cover the big blind")
+	}
+	dealer := hand.NewDealer(rand.New(rand.NewSource(c.seed)))
+	// the correction samples from its own source so that it doesn't change
+	// the decks dealt
+	r := rand.New(rand.NewSource(c.seed))
+	res := &Result{Players: make([]Player, n)}
+	won := make([]stats, n)
+	adjusted := make([]stats, n)
+	for d := 0; d < c.deals; d++ {
+		deck := dealer.Deck()
+		rotations := []int{d % n}
+		if c.duplicate {
+			rotations = rotations[:0]
+			for i := 0; i < n; i++ {
+				rotations = append(rotations, i)
+			}
+		}
+		// a deal's results are averaged over its rotations, which are
+		// correlated, and the averages are the samples
+		net := make([]float64, n)
+		adj := make([]float64, n)
+		for _, rot := range rotations {
+			rec, err := c.play(agents, rot, deck)
+			if err != nil {
+				return nil, err
+			}
+			var l map[int]float64
+			if c.samples > 0 {
+				if l, err = luck(rec, r, c.samples); err != nil {
+					return nil, err
+				}
+			}
+			for _, s := range rec.Seats {
+				i := (s.Seat + rot) % n
+				res.Players[i].Hands++
+				res.Players[i].Net += s.Net
+				net[i] += float64(s.Net)
+				adj[i] += float64(s.Net) - l[s.Seat]
+			}
+			res.Hands++
+		}
+		for i := range agents {
+			won[i].add(net[i] / float64(len(rotations)))
+			adjusted[i].add(adj[i] / float64(len(rotations)))
+		}
+		if c.progress != nil {
+			c.progress(d + 1)
+		}
+	}
+	mbb := 1000 / float64(c.bigBlind)
+	for i := range res.Players {
+		p := &res.Players[i]
+		p.Winnings = won[i].estimate(mbb)
+		if c.samples > 0 {
+			e := adjusted[i].estimate(mbb)
+			p.Adjusted = &e
+		}
+	}
+	return res, nil
+}
+
+// play deals a hand from a copy of deck with agent (seat+rot)%n in each
+// seat and returns its record.
+func (c *Config) play(agents []agent.Agent, rot int, deck *hand.Deck) (*table.Record, error) {
+	n := len(agents)
+	tbl, err := table.New(n, table.Stakes(c.smallBlind, c.bigBlind), table.WithDealer(fixed{deck}))
+	if err != nil {
+		return nil, err
+	}
+	for seat := 0; seat < n; seat++ {
+		if err := tbl.Sit(seat, fmt.Sprint("agent", (seat+rot)%n), c.stack); err != nil {
+			return nil, err
+		}
+	}
+	if err := tbl.Deal(); err != nil {
+		return nil, err
+	}
+	for tbl.Active() {
+		seat := tbl.ToAct()
+		i := (seat + rot) % n
+		a := agents[i].Act(tbl.View(seat))
+		if err := tbl.Act(seat, a); err != nil {
+			return nil, fmt.Errorf("match: agent %d took an invalid action %v: %w", i, a, err)
+		}
+	}
+	return tbl.Record(), nil
+}
+
+// fixed deals copies of the same deck.
+type fixed struct {
+	deck *hand.Deck
+}
+
+func (f fixed) Deck() *hand.Deck {
+	return &hand.Deck{Cards: append([]hand.Card{}, f.deck.Cards...)}
+}
+
+// stats accumulates the mean and variance of samples with Welford's
+// algorithm.
+type stats struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (s *stats) add(x float64) {
+	s.n++
+	d := x - s.mean
+	s.mean += d / float64(s.n)
+	s.m2 += d * (x - s.mean)
+}
+
+// estimate returns the mean and its confidence interval multiplied by
+// scale.
+func (s *stats) estimate(scale float64) Estimate {
+	e := Estimate{Mean: s.mean * scale}
+	if s.n > 1 {
+		e.CI95 = 1.96 * math.Sqrt(s.m2/float64(s.n-1)/float64(s.n)) * scale
+	}
+	return e
+}
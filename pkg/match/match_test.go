@@ -0,0 +1,95 @@
+package match_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/agent"
+	"github.com/notnil/joker/pkg/match"
+	"github.com/notnil/joker/pkg/table"
+)
+
+func TestDuplicate(t *testing.T) {
+	// identical agents dealt each other's cards break even exactly
+	for _, seats := range []int{2, 3} {
+		agents := []agent.Agent{}
+		for i := 0; i < seats; i++ {
+			agents = append(agents, agent.TAG())
+		}
+		res, err := match.Play(agents, match.Deals(50), match.Duplicate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Hands != 50*seats {
+			t.Fatalf("hands = %d; want %d", res.Hands, 50*seats)
+		}
+		for i, p := range res.Players {
+			if p.Hands != res.Hands || p.Net != 0 || p.Winnings.Mean != 0 {
+				t.Fatalf("%d seats: player %d = %+v", seats, i, p)
+			}
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	play := func(seed int64) *match.Result {
+		agents := []agent.Agent{agent.TAG(), agent.CallingStation()}
+		res, err := match.Play(agents, match.Deals(100), match.Seed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	a, b, c := play(1), play(1), play(2)
+	if a.Players[0].Net != b.Players[0].Net {
+		t.Fatalf("same seed won %d and %d", a.Players[0].Net, b.Players[0].Net)
+	}
+	if a.Players[0].Net == c.Players[0].Net {
+		t.Fatal("expected different seeds to deal different cards")
+	}
+	if a.Players[0].Net+a.Players[1].Net != 0 {
+		t.Fatalf("nets %d and %d don't sum to zero", a.Players[0].Net, a.Players[1].Net)
+	}
+}
+
+func TestAIVAT(t *testing.T) {
+	// calling stations check every hand down, so their results are almost
+	// all luck
+	agents := []agent.Agent{agent.CallingStation(), agent.CallingStation()}
+	res, err := match.Play(agents, match.Deals(100), match.AIVAT(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, q := res.Players[0], res.Players[1]
+	if p.Adjusted == nil {
+		t.Fatal("expected adjusted winnings")
+	}
+	if p.Adjusted.CI95 > p.Winnings.CI95/2 {
+		t.Fatalf("adjusted %v isn't much tighter than %v", p.Adjusted, p.Winnings)
+	}
+	if math.Abs(p.Adjusted.Mean+q.Adjusted.Mean) > 1e-6 {
+		t.Fatalf("adjusted winnings %v and %v don't sum to zero", p.Adjusted, q.Adjusted)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := []struct {
+		agents  []agent.Agent
+		options []func(*match.Config)
+	}{
+		{[]agent.Agent{agent.Random(r)}, nil},
+		{[]agent.Agent{agent.Random(r), agent.Random(r)}, []func(*match.Config){match.Deals(0)}},
+		{[]agent.Agent{agent.Random(r), agent.Random(r)}, []func(*match.Config){match.Stakes(2, 1)}},
+		{[]agent.Agent{agent.Random(r), agent.Random(r)}, []func(*match.Config){match.Stack(1)}},
+		{[]agent.Agent{agent.Random(r), agent.AgentFunc(func(v table.View) table.Action {
+			return table.Action{Type: table.Bet, Amount: 1}
+		})}, nil},
+	}
+	for i, test := range tests {
+		if _, err := match.Play(test.agents, test.options...); err == nil {
+			t.Fatalf("%d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package outs calculates the cards that improve a hold'em hand on the
+// flop or turn.
+package outs
+
+import (
+	"errors"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// An Out is a card that improves the player's hand or makes them the
+// winner.  When an opponent range is given, a condition holds for the
+// range when it holds for more than half of the range's combos that
+// don't contain the card.
+type Out struct {
+	// Card is the card that comes on the next street.
+	Card hand.Card
+
+	// Hand is the player's hand after the card.
+	Hand *hand.Hand
+
+	// Improves is true if the card improves the player's ranking.
+	Improves bool
+
+	// Wins is true if the player wasn't winning before the card and
+	// beats the opponent after it.  Wins is always false without an
+	// opponent.
+	Wins bool
+
+	// Clean is false if the card also improves the opponent's ranking.
+	// Clean is always true without an opponent.
+	Clean bool
+}
+
+// Result is the result of an outs calculation.
+type Result struct {
+	// Hand is the player's current hand.
+	Hand *hand.Hand
+
+	// Nuts is true if no other hole cards beat the player's current hand.
+	Nuts bool
+
+	// Winning is true if the player currently beats the opponent.
+	// Winning is always false without an opponent.
+	Winning bool
+
+	// Outs are the outs in the order of hand.Cards.
+	Outs []Out
+}
+
+// ByRanking groups the outs by the ranking the player has after each out.
+func (r *Result) ByRanking() map[hand.Ranking][]Out {
+	m := map[hand.Ranking][]Out{}
+	for _, o := range r.Outs {
+		m[o.Hand.Ranking()] = append(m[o.Hand.Ranking()], o)
+	}
+	return m
+}
+
+// Cards returns the cards of all the outs.
+func (r *Result) Cards() []hand.Card {
+	cards := []hand.Card{}
+	for _, o := range r.Outs {
+		cards = append(cards, o.Card)
+	}
+	return cards
+}
+
+// Calculate returns the outs for the two hole cards on a flop or turn
+// board.  The opponent is either a specific hand or a range given as a
+// list of two card combos.  Opponent combos that share cards with the hole
+// cards or board are ignored, and cards held in every opponent combo are
+// never outs.  Without an opponent the outs are the cards
+// that improve the player's ranking.
+func Calculate(hole, board []hand.Card, opponent ...[]hand.Card) (*Result, error) {
+	if len(hole) != 2 {
+		return nil, errors.New("outs: hole must have two cards")
+	}
+	if len(board) != 3 && len(board) != 4 {
+		return nil, errors.New("outs: board must have three or four cards")
+	}
+	known := join(hole, board)
+	if hasDuplicates(known) {
+		return nil, errors.New("outs: hole and board share cards")
+	}
+	opps := [][]hand.Card{}
+	for _, combo := range opponent {
+		if len(combo) != 2 {
+			return nil, errors.New("outs: opponent combos must have two cards")
+		}
+		if !sharesCards(combo, known) {
+			opps = append(opps, combo)
+		}
+	}
+	if len(opponent) > 0 && len(opps) == 0 {
+		return nil, errors.New("outs: every opponent combo shares cards with the hole cards or board")
+	}
+
+	current := hand.New(known)
+	r := &Result{
+		Hand: current,
+		Nuts: isNuts(current, board, known),
+		Winning: len(opps) > 0 && majority(opps, nil, func(o []hand.Card) bool {
+			return current.CompareTo(hand.New(join(o, board))) > 0
+		}),
+	}
+
+	// a card held in every opponent combo can't come
+	for _, c := range remaining(join(known, held(opps))) {
+		next := join(board, []hand.Card{c})
+		h := hand.New(join(hole, next))
+		o := Out{
+			Card:     c,
+			Hand:     h,
+			Improves: h.Ranking() > current.Ranking(),
+			Clean:    true,
+		}
+		if len(opps) > 0 {
+			o.Wins = !r.Winning && majority(opps, []hand.Card{c}, func(opp []hand.Card) bool {
+				return h.CompareTo(hand.New(join(opp, next))) > 0
+			})
+			o.Clean = !majority(opps, []hand.Card{c}, func(opp []hand.Card) bool {
+				before := hand.New(join(opp, board))
+				after := hand.New(join(opp, next))
+				return after.Ranking() > before.Ranking()
+			})
+		}
+		if o.Improves || o.Wins {
+			r.Outs = append(r.Outs, o)
+		}
+	}
+	return r, nil
+}
+
+// majority returns true if f is true for more than half of the combos
+// that don't contain any of the dead cards.
+func majority(combos [][]hand.Card, dead []hand.Card, f func([]hand.Card) bool) bool {
+	yes, total := 0, 0
+	for _, combo := range combos {
+		if sharesCards(combo, dead) {
+			continue
+		}
+		total++
+		if f(combo) {
+			yes++
+		}
+	}
+	return total > 0 && yes*2 > total
+}
+
+// isNuts returns true if no hole cards drawn from the unknown cards make
+// a better hand with the board than h.
+func isNuts(h *hand.Hand, board, known []hand.Card) bool {
+	unknown := remaining(known)
+	for _, combo := range util.Combinations(len(unknown), 2) {
+		hole := []hand.Card{unknown[combo[0]], unknown[combo[1]]}
+		if hand.New(join(hole, board)).CompareTo(h) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// held returns the cards in every combo.
+func held(combos [][]hand.Card) []hand.Card {
+	cards := []hand.Card{}
+	if len(combos) == 0 {
+		return cards
+	}
+	for _, c := range combos[0] {
+		in := true
+		for _, combo := range combos[1:] {
+			in = in && sharesCards([]hand.Card{c}, combo)
+		}
+		if in {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+func remaining(known []hand.Card) []hand.Card {
+	cards := []hand.Card{}
+	for _, c := range hand.Cards() {
+		if !sharesCards([]hand.Card{c}, known) {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// join returns a new slice with the cards of a followed by the cards of b.
+func join(a, b []hand.Card) []hand.Card {
+	return append(append([]hand.Card{}, a...), b...)
+}
+
+func sharesCards(a, b []hand.Card) bool {
+	for _, c1 := range a {
+		for _, c2 := range b {
+			if c1 == c2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasDuplicates(cards []hand.Card) bool {
+	for i := range cards {
+		if sharesCards(cards[i:i+1], cards[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package outs_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/outs"
+)
+
+func TestImprovingOuts(t *testing.T) {
+	r, err := outs.Calculate(Cards("Ah", "Kh"), Cards("2h", "7h", "Qc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Nuts {
+		t.Fatal("expected ace high not to be the nuts")
+	}
+	// 9 hearts, 6 aces and kings, and 8 cards that pair the board
+	if len(r.Outs) != 23 {
+		t.Fatalf("outs = %d; want %d", len(r.Outs), 23)
+	}
+	if n := len(r.ByRanking()[hand.Flush]); n != 9 {
+		t.Fatalf("flush outs = %d; want %d", n, 9)
+	}
+	for _, o := range r.Outs {
+		if o.Wins || !o.Clean {
+			t.Fatalf("out %v without opponent should not win and should be clean", o.Card)
+		}
+	}
+}
+
+func TestOutsAgainstHand(t *testing.T) {
+	r, err := outs.Calculate(Cards("Ah", "Kh"), Cards("2h", "7h", "Qc"), Cards("Qs", "Qd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Winning {
+		t.Fatal("expected ace high to be losing to a set")
+	}
+	// the opponent holds Q♠ and Q♦, so neither can come
+	if len(r.Outs) != 21 {
+		t.Fatalf("outs = %d; want %d", len(r.Outs), 21)
+	}
+	winners := 0
+	for _, o := range r.Outs {
+		if o.Card == hand.QueenSpades || o.Card == hand.QueenDiamonds {
+			t.Fatalf("out %v is held by the opponent", o.Card)
+		}
+		if o.Wins {
+			winners++
+			if !o.Clean {
+				t.Fatalf("winning out %v should be clean", o.Card)
+			}
+		}
+		if o.Card == hand.QueenHearts && (o.Wins || o.Clean) {
+			t.Fatal("Q♥ gives the opponent quads and should be a dirty losing out")
+		}
+	}
+	// hearts that don't pair the board
+	if winners != 8 {
+		t.Fatalf("winning outs = %d; want %d", winners, 8)
+	}
+}
+
+func TestOutsAgainstRange(t *testing.T) {
+	opp := [][]hand.Card{Cards("Qs", "Qd"), Cards("Jc", "Tc"), Cards("Ah", "Kd")}
+	r, err := outs.Calculate(Cards("9s", "8s"), Cards("Ts", "7d", "2s", "Kc"), opp...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range r.Outs {
+		if o.Card == hand.SixHearts && !o.Wins {
+			t.Fatal("expected a six to make a winning straight")
+		}
+		if o.Card == hand.TwoDiamonds && o.Wins {
+			t.Fatal("expected pairing the two not to win")
+		}
+	}
+}
+
+func TestNuts(t *testing.T) {
+	r, err := outs.Calculate(Cards("As", "Ks"), Cards("Qs", "Js", "Ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Nuts {
+		t.Fatal("expected royal flush to be the nuts")
+	}
+	if len(r.Outs) != 0 {
+		t.Fatalf("outs = %v; want none", r.Cards())
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	if _, err := outs.Calculate(Cards("As"), Cards("Qs", "Js", "Ts")); err == nil {
+		t.Fatal("expected error for one hole card")
+	}
+	if _, err := outs.Calculate(Cards("As", "Ks"), Cards("Qs", "Js")); err == nil {
+		t.Fatal("expected error for two board cards")
+	}
+	if _, err := outs.Calculate(Cards("As", "Ks"), Cards("As", "Js", "Ts")); err == nil {
+		t.Fatal("expected error for duplicate cards")
+	}
+	if _, err := outs.Calculate(Cards("As", "Ks"), Cards("Qs", "Js", "Ts"), Cards("Qs", "Qh")); err == nil {
+		t.Fatal("expected error for impossible opponent")
+	}
+}
@@ -0,0 +1,167 @@
+// Package preflop provides the 169 hold'em starting hand classes and
+// precomputed all-in equities between them.
+//
+// The embedded table is generated by cmd/preflopgen from every five card
+// board.  Heads up equities are exact up to the table's precision of
+// 1/65535.  Equities against more than one random hand sample a single
+// set of opponents per hand and board, which leaves a standard error of
+// less than 0.001.
+package preflop
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// NumClasses is the number of starting hand classes.
+const NumClasses = 169
+
+// A Class is one of the 169 strategically distinct hold'em starting hands
+// such as "AA", "AKs", or "72o".  Classes are laid out in the usual 13x13
+// grid with aces first where pairs are on the diagonal, suited hands above
+// it and offsuit hands below it.  The zero value is "AA".
+type Class int
+
+// Classes returns all 169 classes in grid order.
+func Classes() []Class {
+	classes := make([]Class, NumClasses)
+	for i := range classes {
+		classes[i] = Class(i)
+	}
+	return classes
+}
+
+// ClassOf returns the class of the two hole cards.
+func ClassOf(c1, c2 hand.Card) Class {
+	r1, r2 := c1.Rank(), c2.Rank()
+	if r1 < r2 {
+		r1, r2 = r2, r1
+	}
+	row, col := gridIndex(r1), gridIndex(r2)
+	if c1.Suit() != c2.Suit() {
+		row, col = col, row
+	}
+	return Class(row*13 + col)
+}
+
+// ParseClass parses a class in the format "AKs", "AKo", or "QQ".
+func ParseClass(s string) (Class, error) {
+	invalid := errors.New("preflop: invalid class " + s)
+	if len(s) < 2 || len(s) > 3 {
+		return 0, invalid
+	}
+	r1, ok1 := parseRank(s[0])
+	r2, ok2 := parseRank(s[1])
+	if !ok1 || !ok2 {
+		return 0, invalid
+	}
+	if r1 < r2 {
+		r1, r2 = r2, r1
+	}
+	row, col := gridIndex(r1), gridIndex(r2)
+	switch {
+	case r1 == r2 && len(s) == 2:
+	case r1 != r2 && len(s) == 3 && s[2] == 's':
+	case r1 != r2 && len(s) == 3 && s[2] == 'o':
+		row, col = col, row
+	default:
+		return 0, invalid
+	}
+	return Class(row*13 + col), nil
+}
+
+// Ranks returns the ranks of the class with the higher rank first.
+func (c Class) Ranks() (hand.Rank, hand.Rank) {
+	row, col := int(c)/13, int(c)%13
+	if row > col {
+		row, col = col, row
+	}
+	return gridRank(row), gridRank(col)
+}
+
+// Pair returns true if the class is a pocket pair.
+func (c Class) Pair() bool {
+	return int(c)/13 == int(c)%13
+}
+
+// Suited returns true if the class is suited.
+func (c Class) Suited() bool {
+	return int(c)/13 < int(c)%13
+}
+
+// Combos returns the hole card combinations of the class.  There are six
+// combos for pairs, four for suited hands, and twelve for offsuit hands.
+func (c Class) Combos() [][]hand.Card {
+	r1, r2 := c.Ranks()
+	combos := [][]hand.Card{}
+	for _, s1 := range allSuits {
+		for _, s2 := range allSuits {
+			switch {
+			case c.Pair() && s1 >= s2:
+				continue
+			case c.Suited() && s1 != s2:
+				continue
+			case !c.Pair() && !c.Suited() && s1 == s2:
+				continue
+			}
+			combos = append(combos, []hand.Card{card(r1, s1), card(r2, s2)})
+		}
+	}
+	return combos
+}
+
+// String returns the class in the format "AKs", "AKo", or "QQ".
+func (c Class) String() string {
+	r1, r2 := c.Ranks()
+	s := r1.String() + r2.String()
+	switch {
+	case c.Suited():
+		s += "s"
+	case !c.Pair():
+		s += "o"
+	}
+	return s
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Class) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *Class) UnmarshalText(text []byte) error {
+	class, err := ParseClass(string(text))
+	if err != nil {
+		return err
+	}
+	*c = class
+	return nil
+}
+
+const ranksStr = "23456789TJQKA"
+
+var allSuits = []hand.Suit{hand.Spades, hand.Hearts, hand.Diamonds, hand.Clubs}
+
+func parseRank(b byte) (hand.Rank, bool) {
+	i := strings.IndexByte(ranksStr, b)
+	return hand.Rank(i), i != -1
+}
+
+func gridIndex(r hand.Rank) int {
+	return int(hand.Ace - r)
+}
+
+func gridRank(i int) hand.Rank {
+	return hand.Ace - hand.Rank(i)
+}
+
+func card(r hand.Rank, s hand.Suit) hand.Card {
+	for _, c := range hand.Cards() {
+		if c.Rank() == r && c.Suit() == s {
+			return c
+		}
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,308 @@
+package preflop
+
+import (
+	"math/bits"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// A Generator computes a Table from boards that are either sampled at
+// random or added with weights, such as every board up to suit
+// isomorphism.  For each board every possible pair of hole cards is
+// ranked once, so heads up equities are exact for the board and multiway
+// equities sample random opponents from the ranked hands.
+type Generator struct {
+	r       *rand.Rand
+	classes []Class
+	masks   []uint64
+	index   [52][52]int
+	headsUp [NumClasses][NumClasses]stat
+	multi   [NumClasses][MaxPlayers - 1]stat
+	boards  int
+
+	// per board counts of half pots won and of matchups
+	wins  [NumClasses][NumClasses]int32
+	games [NumClasses][NumClasses]int32
+}
+
+type stat struct {
+	share float64
+	n     float64
+}
+
+func (s stat) equity() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return s.share / s.n
+}
+
+// NewGenerator returns a generator that samples boards and multiway
+// opponents with the given random source.
+func NewGenerator(r *rand.Rand) *Generator {
+	g := &Generator{r: r}
+	cards := hand.Cards()
+	for i := 0; i < len(cards); i++ {
+		for j := i + 1; j < len(cards); j++ {
+			g.index[cards[i]][cards[j]] = len(g.masks)
+			g.index[cards[j]][cards[i]] = len(g.masks)
+			g.classes = append(g.classes, ClassOf(cards[i], cards[j]))
+			g.masks = append(g.masks, cardMask(cards[i])|cardMask(cards[j]))
+		}
+	}
+	return g
+}
+
+// Boards returns the total weight of the boards added so far.
+func (g *Generator) Boards() int {
+	return g.boards
+}
+
+// AddBoard samples a board and adds it with a weight of one.
+func (g *Generator) AddBoard() {
+	deck := hand.Cards()
+	g.r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	g.Add(deck[:5], 1)
+}
+
+// Add adds the equities of every pair of hole cards on the five card
+// board as if the board were added weight times.  Adding each canonical
+// board from pkg/iso weighted by its multiplicity makes the heads up
+// equities exact.
+func (g *Generator) Add(board []hand.Card, weight int) {
+	var boardMask uint64
+	for _, c := range board {
+		boardMask |= cardMask(c)
+	}
+	valid := []int{}
+	strength := make([]uint32, len(g.masks))
+	for i, mask := range g.masks {
+		if mask&boardMask == 0 {
+			valid = append(valid, i)
+			strength[i] = rank7(mask | boardMask)
+		}
+	}
+
+	// heads up against every other hand
+	vsAll := make([]stat, len(g.masks))
+	for x, i := range valid {
+		mi, si, a := g.masks[i], strength[i], g.classes[i]
+		for _, j := range valid[x+1:] {
+			if mi&g.masks[j] != 0 {
+				continue
+			}
+			b := g.classes[j]
+			switch sj := strength[j]; {
+			case si > sj:
+				g.wins[a][b] += 2
+				vsAll[i].share++
+			case si < sj:
+				g.wins[b][a] += 2
+				vsAll[j].share++
+			default:
+				g.wins[a][b]++
+				g.wins[b][a]++
+				vsAll[i].share += 0.5
+				vsAll[j].share += 0.5
+			}
+			g.games[a][b]++
+			g.games[b][a]++
+			vsAll[i].n++
+			vsAll[j].n++
+		}
+	}
+	w := float64(weight)
+	for a := range g.wins {
+		for b := range g.wins[a] {
+			if g.games[a][b] == 0 {
+				continue
+			}
+			g.headsUp[a][b].share += w * float64(g.wins[a][b]) / 2
+			g.headsUp[a][b].n += w * float64(g.games[a][b])
+			g.wins[a][b], g.games[a][b] = 0, 0
+		}
+	}
+
+	// multiway against sampled opponents
+	rest := make([]hand.Card, 0, 52)
+	for _, c := range hand.Cards() {
+		if cardMask(c)&boardMask == 0 {
+			rest = append(rest, c)
+		}
+	}
+	for _, i := range valid {
+		c := g.classes[i]
+		g.multi[c][0].share += w * vsAll[i].share
+		g.multi[c][0].n += w * vsAll[i].n
+
+		cards := make([]hand.Card, 0, len(rest)-2)
+		for _, card := range rest {
+			if cardMask(card)&g.masks[i] == 0 {
+				cards = append(cards, card)
+			}
+		}
+		n := 2 * (MaxPlayers - 1)
+		for k := 0; k < n; k++ {
+			l := k + g.r.Intn(len(cards)-k)
+			cards[k], cards[l] = cards[l], cards[k]
+		}
+		best, ties := strength[i], 1
+		for p := 2; p <= MaxPlayers; p++ {
+			opp := g.index[cards[2*p-4]][cards[2*p-3]]
+			switch {
+			case strength[opp] > best:
+				best, ties = strength[opp], 1
+			case strength[opp] == best:
+				ties++
+			}
+			if p == 2 {
+				// heads up is already exact
+				continue
+			}
+			share := 0.0
+			if best == strength[i] {
+				share = 1 / float64(ties)
+			}
+			g.multi[c][p-2].share += w * share
+			g.multi[c][p-2].n += w
+		}
+	}
+	g.boards += weight
+}
+
+// Table returns the equities accumulated so far.
+func (g *Generator) Table() *Table {
+	t := &Table{}
+	for a := range g.headsUp {
+		for b := range g.headsUp[a] {
+			t.headsUp[a][b] = g.headsUp[a][b].equity()
+		}
+		for p := range g.multi[a] {
+			t.vsRandom[a][p] = g.multi[a][p].equity()
+		}
+	}
+	return t
+}
+
+func cardMask(c hand.Card) uint64 {
+	return 1 << uint(c)
+}
+
+// Hand categories for rank7 in the order of hand.Ranking, with royal
+// flushes counted as straight flushes.
+const (
+	highCard = iota
+	pair
+	twoPair
+	threeOfAKind
+	straight
+	flush
+	fullHouse
+	fourOfAKind
+	straightFlush
+)
+
+// rank7 ranks the best five card high hand in the set of seven cards so
+// that better hands have larger values and hands that hand.Hand.CompareTo
+// finds equal have equal values.  It is far faster than hand.New, which
+// makes enumerating every board practical.
+func rank7(mask uint64) uint32 {
+	var all uint16
+	var suits [4]uint16
+	for s := range suits {
+		suits[s] = uint16(mask>>(13*uint(s))) & 0x1fff
+		all |= suits[s]
+	}
+	for _, s := range suits {
+		if bits.OnesCount16(s) >= 5 {
+			if high, ok := straightHigh(s); ok {
+				return value(straightFlush, high)
+			}
+			return value(flush, top(s, 5)...)
+		}
+	}
+
+	var quads, trips, pairs []int
+	for r := 12; r >= 0; r-- {
+		n := 0
+		for _, s := range suits {
+			n += int(s>>uint(r)) & 1
+		}
+		switch n {
+		case 4:
+			quads = append(quads, r)
+		case 3:
+			trips = append(trips, r)
+		case 2:
+			pairs = append(pairs, r)
+		}
+	}
+	without := func(ranks ...int) uint16 {
+		m := all
+		for _, r := range ranks {
+			m &^= 1 << uint(r)
+		}
+		return m
+	}
+	switch {
+	case len(quads) > 0:
+		return value(fourOfAKind, append(quads[:1], top(without(quads[0]), 1)...)...)
+	case len(trips) > 0 && len(trips)+len(pairs) > 1:
+		second := -1
+		if len(trips) > 1 {
+			second = trips[1]
+		}
+		if len(pairs) > 0 && pairs[0] > second {
+			second = pairs[0]
+		}
+		return value(fullHouse, trips[0], second)
+	}
+	if high, ok := straightHigh(all); ok {
+		return value(straight, high)
+	}
+	switch {
+	case len(trips) > 0:
+		return value(threeOfAKind, append(trips[:1], top(without(trips[0]), 2)...)...)
+	case len(pairs) > 1:
+		return value(twoPair, pairs[0], pairs[1], top(without(pairs[0], pairs[1]), 1)[0])
+	case len(pairs) > 0:
+		return value(pair, append(pairs[:1], top(without(pairs[0]), 3)...)...)
+	}
+	return value(highCard, top(all, 5)...)
+}
+
+// straightHigh returns the rank of the highest card of the best straight
+// in the set of ranks, where the five high straight includes the ace.
+func straightHigh(ranks uint16) (int, bool) {
+	wide := uint32(ranks)<<1 | uint32(ranks>>12)&1
+	for high := 12; high >= 3; high-- {
+		if five := uint32(0x1f) << uint(high-3); wide&five == five {
+			return high, true
+		}
+	}
+	return 0, false
+}
+
+// top returns the n highest ranks in the set in descending order.
+func top(ranks uint16, n int) []int {
+	var rs []int
+	for r := 12; r >= 0 && len(rs) < n; r-- {
+		if ranks&(1<<uint(r)) != 0 {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// value packs the category and up to five ranks, most significant first.
+func value(category int, ranks ...int) uint32 {
+	v := uint32(category)
+	for i := 0; i < 5; i++ {
+		v <<= 4
+		if i < len(ranks) {
+			v |= uint32(ranks[i])
+		}
+	}
+	return v
+}
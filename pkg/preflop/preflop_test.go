@@ -0,0 +1,230 @@
+package preflop_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+func TestClassStrings(t *testing.T) {
+	combos := 0
+	for _, c := range preflop.Classes() {
+		parsed, err := preflop.ParseClass(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != c {
+			t.Fatalf("ParseClass(%q) = %v; want %v", c.String(), parsed, c)
+		}
+		for _, combo := range c.Combos() {
+			if class := preflop.ClassOf(combo[0], combo[1]); class != c {
+				t.Fatalf("ClassOf(%v) = %v; want %v", combo, class, c)
+			}
+		}
+		combos += len(c.Combos())
+	}
+	if combos != 1326 {
+		t.Fatalf("combos = %d; want %d", combos, 1326)
+	}
+}
+
+type classTest struct {
+	cards []hand.Card
+	class string
+}
+
+var classTests = []classTest{
+	{Cards("As", "Ah"), "AA"},
+	{Cards("Ks", "As"), "AKs"},
+	{Cards("Ad", "Ks"), "AKo"},
+	{Cards("2c", "7h"), "72o"},
+	{Cards("Tc", "9c"), "T9s"},
+}
+
+func TestClassOf(t *testing.T) {
+	for _, test := range classTests {
+		c := preflop.ClassOf(test.cards[0], test.cards[1])
+		if c.String() != test.class {
+			t.Fatalf("ClassOf(%v) = %v; want %v", test.cards, c, test.class)
+		}
+	}
+	for _, s := range []string{"", "A", "AAs", "AKx", "1K", "AKsx"} {
+		if _, err := preflop.ParseClass(s); err == nil {
+			t.Fatalf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestDefaultTable(t *testing.T) {
+	aa := preflop.Default().HeadsUp(class(t, "AA"), class(t, "KK"))
+	if aa < 0.8 || aa > 0.84 {
+		t.Fatalf("AA vs KK = %v; want about 0.82", aa)
+	}
+	for _, a := range preflop.Classes() {
+		for _, b := range preflop.Classes() {
+			sum := preflop.Default().HeadsUp(a, b) + preflop.Default().HeadsUp(b, a)
+			if math.Abs(sum-1) > 0.001 {
+				t.Fatalf("%v vs %v and %v vs %v sum to %v", a, b, b, a, sum)
+			}
+		}
+	}
+	last := 1.0
+	for players := 2; players <= preflop.MaxPlayers; players++ {
+		eq := preflop.VsRandom(Cards("Ah", "Ad"), players)
+		if eq >= last {
+			t.Fatalf("AA equity with %d players %v should be less than %v", players, eq, last)
+		}
+		last = eq
+	}
+	if eq := preflop.HeadsUp(Cards("Ah", "Kh"), Cards("2c", "2d")); eq < 0.45 || eq > 0.55 {
+		t.Fatalf("AKs vs 22 = %v; want about 0.5", eq)
+	}
+}
+
+func TestTableBinary(t *testing.T) {
+	g := preflop.NewGenerator(rand.New(rand.NewSource(0)))
+	g.AddBoard()
+	table := g.Table()
+	b, err := table.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &preflop.Table{}
+	if err := cp.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range preflop.Classes() {
+		for _, b := range preflop.Classes() {
+			if math.Abs(table.HeadsUp(a, b)-cp.HeadsUp(a, b)) > 1e-4 {
+				t.Fatalf("%v vs %v = %v after round trip; want %v", a, b, cp.HeadsUp(a, b), table.HeadsUp(a, b))
+			}
+		}
+	}
+	if err := cp.UnmarshalBinary(b[:10]); err == nil {
+		t.Fatal("expected error for truncated table")
+	}
+}
+
+func TestGeneratorAdd(t *testing.T) {
+	boards := [][]hand.Card{
+		Cards("As", "2d", "3c", "4h", "9s"),
+		Cards("Ks", "Qs", "7s", "2s", "2h"),
+		Cards("Th", "Td", "Tc", "5s", "5h"),
+		Cards("Jc", "9d", "8h", "7c", "6c"),
+	}
+	for _, board := range boards {
+		g := preflop.NewGenerator(rand.New(rand.NewSource(0)))
+		g.Add(board, 3)
+		if g.Boards() != 3 {
+			t.Fatalf("boards = %d; want 3", g.Boards())
+		}
+
+		// rank every pair of hole cards with hand.New
+		var combos [][]hand.Card
+		var hands []*hand.Hand
+		for _, a := range preflop.Classes() {
+			for _, c := range a.Combos() {
+				if !overlaps(c, board) {
+					combos = append(combos, c)
+					hands = append(hands, hand.New(append(append([]hand.Card{}, c...), board...)))
+				}
+			}
+		}
+		var share, n [preflop.NumClasses][preflop.NumClasses]float64
+		for i := range combos {
+			for j := range combos {
+				if overlaps(combos[i], combos[j]) {
+					continue
+				}
+				a := preflop.ClassOf(combos[i][0], combos[i][1])
+				b := preflop.ClassOf(combos[j][0], combos[j][1])
+				switch cmp := hands[i].CompareTo(hands[j]); {
+				case cmp > 0:
+					share[a][b]++
+				case cmp == 0:
+					share[a][b] += 0.5
+				}
+				n[a][b]++
+			}
+		}
+		table := g.Table()
+		for _, a := range preflop.Classes() {
+			for _, b := range preflop.Classes() {
+				want := 0.0
+				if n[a][b] > 0 {
+					want = share[a][b] / n[a][b]
+				}
+				if got := table.HeadsUp(a, b); math.Abs(got-want) > 1e-9 {
+					t.Fatalf("on %v %v vs %v = %v; want %v", board, a, b, got, want)
+				}
+			}
+		}
+	}
+}
+
+func overlaps(a, b []hand.Card) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func class(t *testing.T, s string) preflop.Class {
+	c, err := preflop.ParseClass(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+type rangeTest struct {
+	s      string
+	combos int
+	str    string
+}
+
+var rangeTests = []rangeTest{
+	{"QQ+", 18, "QQ+"},
+	{"AKs", 4, "AKs"},
+	{"AK", 16, "AKs,AKo"},
+	{"22-55", 24, "55-22"},
+	{"A2s+", 48, "A2s+"},
+	{"KTo+", 36, "KTo+"},
+	{"A5s-A2s, KQo", 28, "A5s-A2s,KQo"},
+	{"QQ+,AKs,AKo:0.5", 28, "QQ+,AKs,AKo:0.5"},
+}
+
+func TestParseRange(t *testing.T) {
+	for _, test := range rangeTests {
+		r, err := preflop.ParseRange(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Size() != float64(test.combos) {
+			t.Fatalf("ParseRange(%q) size = %v; want %d", test.s, r.Size(), test.combos)
+		}
+		if r.String() != test.str {
+			t.Fatalf("ParseRange(%q) = %q; want %q", test.s, r.String(), test.str)
+		}
+		again, err := preflop.ParseRange(r.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again != r {
+			t.Fatalf("ParseRange(%q) doesn't round trip", r.String())
+		}
+	}
+	for _, s := range []string{"QQ-AKs", "AKs-QJs", "XX", "AK:2", "AKs+s"} {
+		if _, err := preflop.ParseRange(s); err == nil {
+			t.Fatalf("expected error parsing %q", s)
+		}
+	}
+}
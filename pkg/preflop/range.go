@@ -0,0 +1,224 @@
+package preflop
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// A Range is a set of starting hands where each class has a weight from
+// zero to one, which is the fraction of its combos that are in the range.
+type Range [NumClasses]float64
+
+// ParseRange parses a comma separated range in the usual notation such as
+// "QQ+,AKs,ATs-A8s,KQo".  A token is a class, a class followed by "+" to
+// include better pairs or better kickers, or two classes joined by "-"
+// with everything between.  A class without "s" or "o" such as "AK"
+// includes both.  A token may end in ":weight" to give its classes a
+// weight less than one.
+func ParseRange(s string) (Range, error) {
+	r := Range{}
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		weight := 1.0
+		if i := strings.Index(token, ":"); i != -1 {
+			w, err := strconv.ParseFloat(token[i+1:], 64)
+			if err != nil || w < 0 || w > 1 {
+				return Range{}, errors.New("preflop: invalid weight in " + token)
+			}
+			token, weight = token[:i], w
+		}
+		classes, err := parseToken(token)
+		if err != nil {
+			return Range{}, err
+		}
+		for _, c := range classes {
+			r[c] = weight
+		}
+	}
+	return r, nil
+}
+
+// Contains returns true if the class has a weight greater than zero.
+func (r Range) Contains(c Class) bool {
+	return r[c] > 0
+}
+
+// Combos returns the hole card combinations of the classes in the range.
+func (r Range) Combos() [][]hand.Card {
+	combos := [][]hand.Card{}
+	for _, c := range Classes() {
+		if r.Contains(c) {
+			combos = append(combos, c.Combos()...)
+		}
+	}
+	return combos
+}
+
+// Size returns the weighted number of combos in the range.
+func (r Range) Size() float64 {
+	n := 0.0
+	for _, c := range Classes() {
+		n += r[c] * float64(len(c.Combos()))
+	}
+	return n
+}
+
+// Fraction returns the fraction of all 1326 combos in the range.
+func (r Range) Fraction() float64 {
+	return r.Size() / 1326
+}
+
+// String returns the range in the notation read by ParseRange with runs
+// of classes of the same weight combined.
+func (r Range) String() string {
+	tokens := []string{}
+	// pairs from aces down
+	tokens = append(tokens, r.runs(func(i int) Class { return Class(i*13 + i) }, 13)...)
+	// suited and offsuit hands by their high card
+	for row := 0; row < 12; row++ {
+		n := 12 - row
+		suited := func(i int) Class { return Class(row*13 + row + 1 + i) }
+		offsuit := func(i int) Class { return Class((row+1+i)*13 + row) }
+		tokens = append(tokens, r.runs(suited, n)...)
+		tokens = append(tokens, r.runs(offsuit, n)...)
+	}
+	return strings.Join(tokens, ",")
+}
+
+// runs returns the tokens for n classes ordered from best to worst where
+// a run that starts with the best class is written with a plus.
+func (r Range) runs(class func(i int) Class, n int) []string {
+	tokens := []string{}
+	for i := 0; i < n; {
+		w := r[class(i)]
+		j := i + 1
+		for j < n && r[class(j)] == w {
+			j++
+		}
+		if w > 0 {
+			first, last := class(i), class(j-1)
+			var token string
+			switch {
+			case i == j-1:
+				token = first.String()
+			case i == 0:
+				token = last.String() + "+"
+			default:
+				token = first.String() + "-" + last.String()
+			}
+			if w != 1 {
+				token += ":" + strconv.FormatFloat(w, 'g', -1, 64)
+			}
+			tokens = append(tokens, token)
+		}
+		i = j
+	}
+	return tokens
+}
+
+func parseToken(token string) ([]Class, error) {
+	invalid := errors.New("preflop: invalid range token " + token)
+	if i := strings.Index(token, "-"); i != -1 {
+		from, err := parseClasses(token[:i])
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseClasses(token[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		if len(from) != len(to) {
+			return nil, invalid
+		}
+		classes := []Class{}
+		for k := range from {
+			between, ok := classesBetween(from[k], to[k])
+			if !ok {
+				return nil, invalid
+			}
+			classes = append(classes, between...)
+		}
+		return classes, nil
+	}
+	if strings.HasSuffix(token, "+") {
+		base, err := parseClasses(strings.TrimSuffix(token, "+"))
+		if err != nil {
+			return nil, err
+		}
+		classes := []Class{}
+		for _, c := range base {
+			r1, _ := c.Ranks()
+			top := classFor(r1, r1-1, c.Suited())
+			if c.Pair() {
+				top = classFor(hand.Ace, hand.Ace, false)
+			}
+			between, _ := classesBetween(top, c)
+			classes = append(classes, between...)
+		}
+		return classes, nil
+	}
+	return parseClasses(token)
+}
+
+// parseClasses parses a class, or both the suited and offsuit classes if
+// the suitedness is omitted.
+func parseClasses(s string) ([]Class, error) {
+	if len(s) == 2 && s[0] != s[1] {
+		suited, err := ParseClass(s + "s")
+		if err != nil {
+			return nil, err
+		}
+		return []Class{suited, suited.flip()}, nil
+	}
+	c, err := ParseClass(s)
+	if err != nil {
+		return nil, err
+	}
+	return []Class{c}, nil
+}
+
+// classesBetween returns the classes from a to b inclusive if they are
+// both pairs or share a high card and suitedness.
+func classesBetween(a, b Class) ([]Class, bool) {
+	a1, a2 := a.Ranks()
+	b1, b2 := b.Ranks()
+	classes := []Class{}
+	switch {
+	case a.Pair() && b.Pair():
+		if a1 < b1 {
+			a1, b1 = b1, a1
+		}
+		for r := a1; r >= b1; r-- {
+			classes = append(classes, classFor(r, r, false))
+		}
+	case !a.Pair() && !b.Pair() && a1 == b1 && a.Suited() == b.Suited():
+		if a2 < b2 {
+			a2, b2 = b2, a2
+		}
+		for r := a2; r >= b2; r-- {
+			classes = append(classes, classFor(a1, r, a.Suited()))
+		}
+	default:
+		return nil, false
+	}
+	return classes, true
+}
+
+// flip returns the offsuit class of a suited class and vice versa.
+func (c Class) flip() Class {
+	return Class(int(c)%13*13 + int(c)/13)
+}
+
+func classFor(r1, r2 hand.Rank, suited bool) Class {
+	row, col := gridIndex(r1), gridIndex(r2)
+	if !suited {
+		row, col = col, row
+	}
+	return Class(row*13 + col)
+}
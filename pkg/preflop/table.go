@@ -0,0 +1,134 @@
+package preflop
+
+import (
+	_ "embed" // for the equity table
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+//go:generate go run ../../cmd/preflopgen -o equity.dat
+
+// MaxPlayers is the largest number of players with equities against
+// random hands in a Table.
+const MaxPlayers = 9
+
+// Table holds all-in preflop equities by class.  Equities are the share
+// of the pot won including split pots and range from 0 to 1.
+type Table struct {
+	headsUp  [NumClasses][NumClasses]float64
+	vsRandom [NumClasses][MaxPlayers - 1]float64
+}
+
+// HeadsUp returns the equity of class a against class b.
+func (t *Table) HeadsUp(a, b Class) float64 {
+	return t.headsUp[a][b]
+}
+
+// VsRandom returns the equity of the class against players-1 random hands.
+// VsRandom panics if players isn't between 2 and MaxPlayers.
+func (t *Table) VsRandom(c Class, players int) float64 {
+	if players < 2 || players > MaxPlayers {
+		panic("preflop: players must be between 2 and 9")
+	}
+	return t.vsRandom[c][players-2]
+}
+
+const (
+	tableMagic   = "JKPF"
+	tableVersion = 1
+	tableSize    = len(tableMagic) + 2 + 2*NumClasses*NumClasses + 2*NumClasses*(MaxPlayers-1)
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.  The
+// format is the magic "JKPF", a version byte, the maximum number of
+// players, the heads up matrix in row major order, and then the equities
+// against random hands for each class.  Equities are stored as little
+// endian uint16s scaled to 65535.
+func (t *Table) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, tableSize)
+	b = append(b, tableMagic...)
+	b = append(b, tableVersion, MaxPlayers)
+	for i := range t.headsUp {
+		for _, eq := range t.headsUp[i] {
+			b = appendEquity(b, eq)
+		}
+	}
+	for i := range t.vsRandom {
+		for _, eq := range t.vsRandom[i] {
+			b = appendEquity(b, eq)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (t *Table) UnmarshalBinary(b []byte) error {
+	if len(b) != tableSize || string(b[:len(tableMagic)]) != tableMagic {
+		return errors.New("preflop: invalid equity table")
+	}
+	b = b[len(tableMagic):]
+	if b[0] != tableVersion || b[1] != MaxPlayers {
+		return errors.New("preflop: unsupported equity table version")
+	}
+	b = b[2:]
+	for i := range t.headsUp {
+		for j := range t.headsUp[i] {
+			t.headsUp[i][j] = readEquity(b)
+			b = b[2:]
+		}
+	}
+	for i := range t.vsRandom {
+		for j := range t.vsRandom[i] {
+			t.vsRandom[i][j] = readEquity(b)
+			b = b[2:]
+		}
+	}
+	return nil
+}
+
+func appendEquity(b []byte, eq float64) []byte {
+	v := uint16(math.Round(eq * math.MaxUint16))
+	return append(b, byte(v), byte(v>>8))
+}
+
+func readEquity(b []byte) float64 {
+	return float64(binary.LittleEndian.Uint16(b)) / math.MaxUint16
+}
+
+//go:embed equity.dat
+var equityData []byte
+
+var (
+	defaultOnce  sync.Once
+	defaultTable *Table
+)
+
+// Default returns the table embedded in the package.  It was generated
+// by cmd/preflopgen and can be regenerated with go generate.
+func Default() *Table {
+	defaultOnce.Do(func() {
+		defaultTable = &Table{}
+		if err := defaultTable.UnmarshalBinary(equityData); err != nil {
+			panic(err)
+		}
+	})
+	return defaultTable
+}
+
+// HeadsUp returns the all-in preflop equity of the hero's hole cards
+// against the villain's from the default table.  The equity is that of
+// the hole cards' classes, so card removal between the two hands is
+// averaged over.
+func HeadsUp(hero, villain []hand.Card) float64 {
+	return Default().HeadsUp(ClassOf(hero[0], hero[1]), ClassOf(villain[0], villain[1]))
+}
+
+// VsRandom returns the all-in preflop equity of the hole cards against
+// players-1 random hands from the default table.
+func VsRandom(hole []hand.Card, players int) float64 {
+	return Default().VsRandom(ClassOf(hole[0], hole[1]), players)
+}
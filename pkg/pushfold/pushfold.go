@@ -0,0 +1,375 @@
+// Package pushfold solves preflop push or fold games for short stacks.
+//
+// Players act in turn and may only move all-in or fold.  Once a player
+// moves all-in the players after them may call or fold, and the first
+// caller plays the all-in alone since the remaining players fold.  Hand
+// equities come from the preflop equity table computed with pkg/hand, and
+// card removal between the two players in a pot is accounted for.
+// Strategies are found with fictitious play over the 169 starting hand
+// classes.
+package pushfold
+
+import (
+	"errors"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/icm"
+	"github.com/notnil/joker/pkg/preflop"
+)
+
+// Mode is how the outcome of a hand is valued.
+type Mode int
+
+const (
+	// ChipEV values outcomes by the player's chips.
+	ChipEV Mode = iota + 1
+
+	// ICM values outcomes by the player's tournament equity under the
+	// Independent Chip Model.
+	ICM
+)
+
+// Game describes a push or fold situation.
+type Game struct {
+	// Stacks are the players' stacks before posting, in preflop action
+	// order.  The last two players are the small and big blind, so heads
+	// up the first player is the small blind.
+	Stacks []float64
+
+	// SmallBlind is the small blind.
+	SmallBlind float64
+
+	// BigBlind is the big blind.
+	BigBlind float64
+
+	// Ante is the ante posted by every player.
+	Ante float64
+
+	// Mode is how outcomes are valued.
+	Mode Mode
+
+	// Payouts are the prizes from first place down used by ICM.
+	Payouts []float64
+}
+
+// Solution is the strategy found for a game.
+type Solution struct {
+	// Push are the ranges each player moves all-in with when the players
+	// before them fold.  The big blind's range is always empty.
+	Push []preflop.Range
+
+	// Call are the ranges each player calls with, indexed by the player
+	// who moved all-in and then by the caller.  Ranges for callers that
+	// act before the player who moved all-in are empty.
+	Call [][]preflop.Range
+}
+
+// Solve returns the strategy after the given number of iterations of
+// fictitious play.
+func Solve(g Game, iterations int) (*Solution, error) {
+	s, err := newSolver(g)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i <= iterations; i++ {
+		s.iterate(i)
+	}
+	return &Solution{Push: s.push, Call: s.call}, nil
+}
+
+// outcome indexes of a player's utility
+const (
+	walk = iota
+	steal
+	win
+	lose
+)
+
+type solver struct {
+	n        int
+	table    *preflop.Table
+	compat   [preflop.NumClasses][preflop.NumClasses]float64
+	combos   [preflop.NumClasses]float64
+	push     []preflop.Range
+	call     [][]preflop.Range
+	allIns   [][]allIn
+	walk     []float64
+	steal    [][]float64
+	showdown [][][2][]float64
+}
+
+// allIn is how often a caller calls an all-in and the equity of the
+// player who moved all-in when they do.
+type allIn struct {
+	calls, equity float64
+}
+
+func newSolver(g Game) (*solver, error) {
+	n := len(g.Stacks)
+	if n < 2 {
+		return nil, errors.New("pushfold: must have at least two players")
+	}
+	if g.BigBlind <= 0 || g.SmallBlind < 0 || g.Ante < 0 {
+		return nil, errors.New("pushfold: invalid blinds or ante")
+	}
+	for _, stack := range g.Stacks {
+		if stack <= 0 {
+			return nil, errors.New("pushfold: stacks must be positive")
+		}
+	}
+	var value func([]float64) []float64
+	switch g.Mode {
+	case ChipEV:
+		value = func(stacks []float64) []float64 { return stacks }
+	case ICM:
+		if len(g.Payouts) == 0 {
+			return nil, errors.New("pushfold: ICM requires payouts")
+		}
+		value = func(stacks []float64) []float64 {
+			eq, _ := icm.Equities(stacks, g.Payouts)
+			return eq
+		}
+	default:
+		return nil, errors.New("pushfold: invalid mode")
+	}
+
+	s := &solver{n: n, table: preflop.Default()}
+	s.initCombos()
+	s.push = make([]preflop.Range, n)
+	s.call = make([][]preflop.Range, n)
+	for i := range s.push {
+		s.call[i] = make([]preflop.Range, n)
+		if i == n-1 {
+			continue
+		}
+		for c := range s.push[i] {
+			s.push[i][c] = 0.5
+		}
+		for j := i + 1; j < n; j++ {
+			for c := range s.call[i][j] {
+				s.call[i][j][c] = 0.5
+			}
+		}
+	}
+
+	// posted amounts
+	posts := make([]float64, n)
+	for i, stack := range g.Stacks {
+		blind := 0.0
+		switch i {
+		case n - 1:
+			blind = g.BigBlind
+		case n - 2:
+			blind = g.SmallBlind
+		}
+		posts[i] = min(stack, blind+g.Ante)
+	}
+	pot := 0.0
+	for _, p := range posts {
+		pot += p
+	}
+	after := func(f func(stacks []float64)) []float64 {
+		stacks := make([]float64, n)
+		for i := range stacks {
+			stacks[i] = g.Stacks[i] - posts[i]
+		}
+		f(stacks)
+		return value(stacks)
+	}
+
+	s.walk = after(func(stacks []float64) { stacks[n-1] += pot })
+	s.steal = make([][]float64, n)
+	s.showdown = make([][][2][]float64, n)
+	for k := 0; k < n-1; k++ {
+		k := k
+		s.steal[k] = after(func(stacks []float64) { stacks[k] += pot })
+		s.showdown[k] = make([][2][]float64, n)
+		for j := k + 1; j < n; j++ {
+			j := j
+			risk := min(g.Stacks[k], g.Stacks[j])
+			// the winner takes the other player's risk and the dead money
+			dead := pot - posts[k] - posts[j]
+			s.showdown[k][j][0] = after(func(stacks []float64) {
+				stacks[k] = g.Stacks[k] + risk + dead
+				stacks[j] = g.Stacks[j] - risk
+			})
+			s.showdown[k][j][1] = after(func(stacks []float64) {
+				stacks[j] = g.Stacks[j] + risk + dead
+				stacks[k] = g.Stacks[k] - risk
+			})
+		}
+	}
+	return s, nil
+}
+
+// initCombos computes the number of combos of each class and the average
+// number of combos of one class that don't share cards with a combo of
+// another.
+func (s *solver) initCombos() {
+	classes := preflop.Classes()
+	combos := make([][][]hand.Card, len(classes))
+	for _, c := range classes {
+		combos[c] = c.Combos()
+		s.combos[c] = float64(len(combos[c]))
+	}
+	for _, a := range classes {
+		for _, b := range classes {
+			n := 0
+			for _, ca := range combos[a] {
+				for _, cb := range combos[b] {
+					if ca[0] != cb[0] && ca[0] != cb[1] && ca[1] != cb[0] && ca[1] != cb[1] {
+						n++
+					}
+				}
+			}
+			s.compat[a][b] = float64(n) / s.combos[a]
+		}
+	}
+}
+
+func (s *solver) iterate(t int) {
+	s.allIns = make([][]allIn, s.n)
+	for k := 0; k < s.n-1; k++ {
+		s.allIns[k] = make([]allIn, s.n)
+		for j := k + 1; j < s.n; j++ {
+			calls, eq := s.rangeVersus(s.push[k], s.call[k][j])
+			s.allIns[k][j] = allIn{calls: calls, equity: eq}
+		}
+	}
+
+	// compute every best response against the current average strategy
+	// before updating any of them
+	push := make([]preflop.Range, s.n)
+	call := make([][]preflop.Range, s.n)
+	for i := 0; i < s.n-1; i++ {
+		fold := s.foldValue(i)
+		for c := range push[i] {
+			if s.pushValue(i, preflop.Class(c)) > fold {
+				push[i][c] = 1
+			}
+		}
+	}
+	for k := 0; k < s.n-1; k++ {
+		call[k] = make([]preflop.Range, s.n)
+		for j := k + 1; j < s.n; j++ {
+			fold := s.callFoldValue(k, j)
+			for c := range call[k][j] {
+				if s.callValue(k, j, preflop.Class(c)) > fold {
+					call[k][j][c] = 1
+				}
+			}
+		}
+	}
+	w := 1 / float64(t+1)
+	for i := 0; i < s.n-1; i++ {
+		for c := range push[i] {
+			s.push[i][c] += w * (push[i][c] - s.push[i][c])
+		}
+		for j := i + 1; j < s.n; j++ {
+			for c := range call[i][j] {
+				s.call[i][j][c] += w * (call[i][j][c] - s.call[i][j][c])
+			}
+		}
+	}
+}
+
+// pushValue returns the value for player i of moving all-in with the
+// class when folded to.
+func (s *solver) pushValue(i int, c preflop.Class) float64 {
+	v := 0.0
+	reach := 1.0
+	for j := i + 1; j < s.n; j++ {
+		calls, eq := s.versus(c, s.call[i][j])
+		p := reach * calls
+		v += p * (eq*s.showdown[i][j][0][i] + (1-eq)*s.showdown[i][j][1][i])
+		reach *= 1 - calls
+	}
+	return v + reach*s.steal[i][i]
+}
+
+// callValue returns the value for player j of calling player k's all-in
+// with the class.
+func (s *solver) callValue(k, j int, c preflop.Class) float64 {
+	_, eq := s.versus(c, s.push[k])
+	return eq*s.showdown[k][j][1][j] + (1-eq)*s.showdown[k][j][0][j]
+}
+
+// foldValue returns the value for player i of folding when folded to,
+// which depends on how the players after them play.
+func (s *solver) foldValue(i int) float64 {
+	v := 0.0
+	reach := 1.0
+	for k := i + 1; k < s.n-1; k++ {
+		pushes := s.push[k].Fraction()
+		v += reach * pushes * s.allInValue(k, k+1, i)
+		reach *= 1 - pushes
+	}
+	return v + reach*s.walk[i]
+}
+
+// callFoldValue returns the value for player j of folding to player k's
+// all-in, which depends on whether a later player calls.
+func (s *solver) callFoldValue(k, j int) float64 {
+	return s.allInValue(k, j+1, j)
+}
+
+// allInValue returns the value for player i after player k moved all-in
+// and the players before first have folded.
+func (s *solver) allInValue(k, first, i int) float64 {
+	v := 0.0
+	reach := 1.0
+	for j := first; j < s.n; j++ {
+		a := s.allIns[k][j]
+		p := reach * a.calls
+		v += p * (a.equity*s.showdown[k][j][0][i] + (1-a.equity)*s.showdown[k][j][1][i])
+		reach *= 1 - a.calls
+	}
+	return v + reach*s.steal[k][i]
+}
+
+// versus returns the probability that a player with the range holds a
+// hand in it given the other player holds the class, and the equity of
+// the class against those hands.
+func (s *solver) versus(c preflop.Class, r preflop.Range) (float64, float64) {
+	total, in, eq := 0.0, 0.0, 0.0
+	for o := range r {
+		w := s.compat[c][o]
+		total += w
+		in += w * r[o]
+		eq += w * r[o] * s.table.HeadsUp(c, preflop.Class(o))
+	}
+	if in == 0 {
+		return 0, 0
+	}
+	return in / total, eq / in
+}
+
+// rangeVersus returns the probability that a player with range b holds a
+// hand in it given the other player holds a hand in range a, and the
+// equity of range a against those hands.
+func (s *solver) rangeVersus(a, b preflop.Range) (float64, float64) {
+	total, in, eq := 0.0, 0.0, 0.0
+	for c := range a {
+		if a[c] == 0 {
+			continue
+		}
+		wc := s.combos[c] * a[c]
+		for o := range b {
+			w := wc * s.compat[c][o]
+			total += w
+			in += w * b[o]
+			eq += w * b[o] * s.table.HeadsUp(preflop.Class(c), preflop.Class(o))
+		}
+	}
+	if in == 0 {
+		return 0, 0
+	}
+	return in / total, eq / in
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
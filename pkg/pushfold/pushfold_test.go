@@ -0,0 +1,105 @@
+package pushfold_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/preflop"
+	"github.com/notnil/joker/pkg/pushfold"
+)
+
+func TestHeadsUp(t *testing.T) {
+	g := pushfold.Game{
+		Stacks:     []float64{10, 10},
+		SmallBlind: 0.5,
+		BigBlind:   1,
+		Mode:       pushfold.ChipEV,
+	}
+	s, err := pushfold.Solve(g, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	push, call := s.Push[0], s.Call[0][1]
+	// the Nash equilibrium at ten big blinds pushes about 58% of hands
+	// and calls with about 37%
+	if f := push.Fraction(); f < 0.5 || f > 0.65 {
+		t.Fatalf("push fraction = %v; want about 0.58", f)
+	}
+	if f := call.Fraction(); f < 0.3 || f > 0.45 {
+		t.Fatalf("call fraction = %v; want about 0.37", f)
+	}
+	for _, c := range []string{"AA", "KK", "AKo", "A2s"} {
+		if push[class(t, c)] < 0.99 || call[class(t, c)] < 0.99 {
+			t.Fatalf("expected %s to be pushed and called", c)
+		}
+	}
+	if call[class(t, "72o")] > 0.01 {
+		t.Fatal("expected 72o to fold to a push")
+	}
+}
+
+func TestShortStackPushesEverything(t *testing.T) {
+	g := pushfold.Game{
+		Stacks:     []float64{1.5, 20},
+		SmallBlind: 0.5,
+		BigBlind:   1,
+		Mode:       pushfold.ChipEV,
+	}
+	s, err := pushfold.Solve(g, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := s.Push[0].Fraction(); f < 0.95 {
+		t.Fatalf("push fraction = %v; want about 1", f)
+	}
+}
+
+func TestICM(t *testing.T) {
+	g := pushfold.Game{
+		Stacks:     []float64{10, 10, 10},
+		SmallBlind: 0.5,
+		BigBlind:   1,
+		Ante:       0.1,
+		Mode:       pushfold.ChipEV,
+	}
+	chip, err := pushfold.Solve(g, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Mode = pushfold.ICM
+	g.Payouts = []float64{50, 30, 20}
+	icm, err := pushfold.Solve(g, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// calling off a stack costs more tournament equity than it gains
+	for j := 1; j < 3; j++ {
+		if icm.Call[0][j].Fraction() >= chip.Call[0][j].Fraction() {
+			t.Fatalf("ICM call range %v should be tighter than chip EV %v", icm.Call[0][j], chip.Call[0][j])
+		}
+	}
+	if len(icm.Push[2].Combos()) != 0 {
+		t.Fatal("big blind should never push")
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	games := []pushfold.Game{
+		{Stacks: []float64{10}, SmallBlind: 0.5, BigBlind: 1, Mode: pushfold.ChipEV},
+		{Stacks: []float64{10, 10}, SmallBlind: 0.5, BigBlind: 0, Mode: pushfold.ChipEV},
+		{Stacks: []float64{10, 10}, SmallBlind: 0.5, BigBlind: 1, Mode: pushfold.ICM},
+		{Stacks: []float64{10, -1}, SmallBlind: 0.5, BigBlind: 1, Mode: pushfold.ChipEV},
+	}
+	for _, g := range games {
+		if _, err := pushfold.Solve(g, 1); err == nil {
+			t.Fatalf("expected error for %+v", g)
+		}
+	}
+}
+
+func class(t *testing.T, s string) preflop.Class {
+	c, err := preflop.ParseClass(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
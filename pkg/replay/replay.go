@@ -0,0 +1,342 @@
+// Package replay steps through a recorded hand, rebuilding the state of
+// the table after every action: the stacks, the pot, the board, the
+// actions open to the player to act, and every player's hand and equity.
+// The hand is played again on a table dealing the recorded cards, so the
+// state is exactly what the players saw.
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Config represents the configuration options for a replay.
+type Config struct {
+	samples int
+	r       *rand.Rand
+}
+
+// Samples sets the number of boards sampled for the players' equity when
+// the remaining boards are too many to enumerate.  The default is 1000.
+func Samples(n int) func(*Config) {
+	return func(c *Config) {
+		c.samples = n
+	}
+}
+
+// WithRand sets the random source used for sampling.  A source seeded
+// from the time is used by default.
+func WithRand(r *rand.Rand) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+	}
+}
+
+// Player is the state of a player dealt into the hand.  Description is
+// the player's best hand with the board so far, and Equity is their share
+// of the pot if the hand were checked down from here.
+type Player struct {
+	Seat        int         `json:"seat"`
+	Name        string      `json:"name"`
+	Chips       int         `json:"chips"`
+	Bet         int         `json:"bet"`
+	Folded      bool        `json:"folded,omitempty"`
+	AllIn       bool        `json:"allIn,omitempty"`
+	Cards       []hand.Card `json:"cards"`
+	Description string      `json:"description"`
+	Equity      float64     `json:"equity"`
+	Won         int         `json:"won,omitempty"`
+}
+
+// Snapshot is the state of a hand after an action.  Step counts the
+// actions replayed, not counting blinds and antes, and Action is the last
+// of them.  Options are the actions open to the player to act, and the
+// pots are set once the hand is over.  Equity is only calculated again
+// when the board or the players in the hand change, so snapshots in
+// between share it.
+type Snapshot struct {
+	Step    int                 `json:"step"`
+	Action  *table.RecordAction `json:"action,omitempty"`
+	Active  bool                `json:"active"`
+	Round   table.Round         `json:"round,omitempty"`
+	Board   []hand.Card         `json:"board"`
+	Pot     int                 `json:"pot"`
+	ToAct   int                 `json:"toAct"`
+	Options *table.Options      `json:"options,omitempty"`
+	Players []Player            `json:"players"`
+	Pots    []table.Pot         `json:"pots,omitempty"`
+}
+
+// Replayer steps through a hand.  It is used like a bufio.Scanner:
+//
+//	r, err := replay.New(rec)
+//	...
+//	for r.Next() {
+//		s := r.Snapshot()
+//		...
+//	}
+//	if err := r.Err(); err != nil {
+//		...
+//	}
+type Replayer struct {
+	config   Config
+	record   *table.Record
+	table    *table.Table
+	actions  []table.RecordAction
+	step     int
+	started  bool
+	snapshot *Snapshot
+	err      error
+
+	// equity is kept until the board or the players in the hand change
+	key    string
+	equity []float64
+}
+
+// New returns a replayer for a hand record.  The first snapshot is the
+// table after the blinds are posted and the cards dealt.
+func New(r *table.Record, options ...func(*Config)) (*Replayer, error) {
+	c := Config{samples: 1000}
+	for _, option := range options {
+		option(&c)
+	}
+	if c.r == nil {
+		c.r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if len(r.Seats) < 2 {
+		return nil, errors.New("replay: a hand needs at least two players")
+	}
+	// hole cards are dealt from the lowest seat up
+	sorted := append([]table.RecordSeat{}, r.Seats...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Seat < sorted[j].Seat })
+	seats := 0
+	var cards []hand.Card
+	for _, rs := range sorted {
+		if rs.Seat < 0 || rs.Seat >= table.MaxSeats {
+			return nil, errors.New("replay: invalid seat")
+		}
+		if rs.Seat >= seats {
+			seats = rs.Seat + 1
+		}
+		if len(rs.Cards) != 2 {
+			return nil, fmt.Errorf("replay: seat %d wasn't dealt two cards", rs.Seat)
+		}
+		cards = append(cards, rs.Cards...)
+	}
+	if len(r.Board) > 5 {
+		return nil, errors.New("replay: board has more than five cards")
+	}
+	cards = append(cards, r.Board...)
+	if seats < 2 {
+		seats = 2
+	}
+	if r.Button < 0 || r.Button >= seats {
+		return nil, errors.New("replay: invalid button")
+	}
+	d, err := newDealer(cards)
+	if err != nil {
+		return nil, err
+	}
+	ante := table.Ante(r.Ante)
+	if r.BigBlindAnte {
+		ante = table.BigBlindAnte(r.Ante)
+	}
+	t, err := table.New(seats,
+		table.Stakes(r.SmallBlind, r.BigBlind), ante,
+		table.Button(r.Button), table.WithDealer(d))
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range r.Seats {
+		if err := t.Sit(rs.Seat, rs.Name, rs.Chips); err != nil {
+			return nil, fmt.Errorf("replay: seat %d: %v", rs.Seat, err)
+		}
+	}
+	if err := t.Deal(); err != nil {
+		return nil, err
+	}
+	got := t.Record()
+	if got.Button != r.Button || len(got.Seats) != len(r.Seats) {
+		return nil, errors.New("replay: players and button don't match the hand")
+	}
+	rp := &Replayer{config: c, record: r, table: t}
+	for _, a := range r.Actions {
+		switch a.Type {
+		case table.PostAnte, table.PostSmallBlind, table.PostBigBlind:
+		default:
+			rp.actions = append(rp.actions, a)
+		}
+	}
+	return rp, nil
+}
+
+// All returns every snapshot of a hand.
+func All(r *table.Record, options ...func(*Config)) ([]*Snapshot, error) {
+	rp, err := New(r, options...)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []*Snapshot
+	for rp.Next() {
+		snapshots = append(snapshots, rp.Snapshot())
+	}
+	return snapshots, rp.Err()
+}
+
+// Next replays the next action and returns whether there is a snapshot
+// to read.  It returns false at the end of the hand or if the record
+// can't be replayed.
+func (rp *Replayer) Next() bool {
+	if rp.err != nil {
+		return false
+	}
+	if !rp.started {
+		rp.started = true
+	} else {
+		if rp.step == len(rp.actions) {
+			if err := rp.check(); err != nil {
+				rp.err = err
+			}
+			return false
+		}
+		a := rp.actions[rp.step]
+		if !rp.table.Active() {
+			rp.err = fmt.Errorf("replay: action %d is after the end of the hand", rp.step+1)
+			return false
+		}
+		if err := rp.table.Act(a.Seat, a.Action); err != nil {
+			rp.err = fmt.Errorf("replay: action %d (%v by seat %d): %v", rp.step+1, a.Action, a.Seat, err)
+			return false
+		}
+		rp.step++
+	}
+	s, err := rp.take()
+	if err != nil {
+		rp.err = err
+		return false
+	}
+	rp.snapshot = s
+	return true
+}
+
+// Snapshot returns the state after the last call to Next.
+func (rp *Replayer) Snapshot() *Snapshot {
+	return rp.snapshot
+}
+
+// Err returns the error that stopped the replay, if any.
+func (rp *Replayer) Err() error {
+	return rp.err
+}
+
+// check returns an error if the replayed hand ended differently than the
+// record.
+func (rp *Replayer) check() error {
+	if rp.table.Active() {
+		return errors.New("replay: the record ends before the hand does")
+	}
+	got := rp.table.Record()
+	for _, rs := range rp.record.Seats {
+		if g, _ := got.Seat(rs.Seat); g.Won != rs.Won || g.Net != rs.Net {
+			return fmt.Errorf("replay: seat %d won %d, not %d", rs.Seat, g.Won, rs.Won)
+		}
+	}
+	return nil
+}
+
+// take returns the snapshot of the table.
+func (rp *Replayer) take() (*Snapshot, error) {
+	v := rp.table.View(-1)
+	s := &Snapshot{
+		Step:   rp.step,
+		Active: v.Active,
+		Round:  v.Round,
+		Board:  v.Board,
+		Pot:    v.Pot,
+		ToAct:  v.ToAct,
+		Pots:   v.Pots,
+	}
+	if rp.step > 0 {
+		a := rp.actions[rp.step-1]
+		s.Action = &a
+	}
+	if v.Active {
+		s.Options = rp.table.View(v.ToAct).Options
+	}
+	var holes [][]hand.Card
+	var in []int
+	for _, rs := range rp.record.Seats {
+		pv := v.Players[rs.Seat]
+		p := Player{
+			Seat:        rs.Seat,
+			Name:        pv.Name,
+			Chips:       pv.Chips,
+			Bet:         pv.Bet,
+			Folded:      pv.Folded,
+			AllIn:       pv.AllIn,
+			Cards:       rs.Cards,
+			Description: hand.New(append(append([]hand.Card{}, rs.Cards...), v.Board...)).Description(),
+			Won:         pv.Won,
+		}
+		if !pv.Folded {
+			holes = append(holes, rs.Cards)
+			in = append(in, len(s.Players))
+		}
+		s.Players = append(s.Players, p)
+	}
+	if len(in) == 1 {
+		s.Players[in[0]].Equity = 1
+		return s, nil
+	}
+	if key := fmt.Sprint(len(v.Board), in); key != rp.key {
+		res, err := equity.Hands(holes, v.Board, equity.Iterations(rp.config.samples), equity.WithRand(rp.config.r))
+		if err != nil {
+			return nil, err
+		}
+		rp.key, rp.equity = key, res.Equity
+	}
+	for i, j := range in {
+		s.Players[j].Equity = rp.equity[i]
+	}
+	return s, nil
+}
+
+// dealer deals the recorded cards in order, followed by the rest of the
+// deck.
+type dealer []hand.Card
+
+func newDealer(cards []hand.Card) (dealer, error) {
+	used := map[hand.Card]bool{}
+	for _, c := range cards {
+		if used[c] {
+			return nil, errors.New("replay: cards are dealt more than once")
+		}
+		used[c] = true
+	}
+	return dealer(cards), nil
+}
+
+func (d dealer) Deck() *hand.Deck {
+	used := map[hand.Card]bool{}
+	for _, c := range d {
+		used[c] = true
+	}
+	var cards []hand.Card
+	for _, c := range hand.Cards() {
+		if !used[c] {
+			cards = append(cards, c)
+		}
+	}
+	// the deck is dealt from the end
+	for i := len(d) - 1; i >= 0; i-- {
+		cards = append(cards, d[i])
+	}
+	return &hand.Deck{Cards: cards}
+}
@@ -0,0 +1,179 @@
+package replay_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/replay"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// record plays a hand at a table with the button in seat 1 and returns
+// its record as read back from JSON.
+func record(t *testing.T) *table.Record {
+	r := rand.New(rand.NewSource(1))
+	tbl, err := table.New(4, table.Stakes(5, 10), table.Button(1), table.WithDealer(hand.NewDealer(r)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// seat 2 is empty
+	for _, seat := range []int{0, 1, 3} {
+		if err := tbl.Sit(seat, fmt.Sprint("p", seat), 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	actions := []table.Action{
+		{Type: table.Raise, Amount: 30}, {Type: table.Call}, {Type: table.Fold},
+		{Type: table.Bet, Amount: 40}, {Type: table.Call},
+		{Type: table.Check}, {Type: table.Check},
+		{Type: table.Check}, {Type: table.Check},
+	}
+	for _, a := range actions {
+		if err := tbl.Act(tbl.ToAct(), a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, _ := json.Marshal(tbl.Record())
+	rec := &table.Record{}
+	if err := json.Unmarshal(b, rec); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func TestReplay(t *testing.T) {
+	rec := record(t)
+	snapshots, err := replay.All(rec, replay.Samples(300), replay.WithRand(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 10 {
+		t.Fatalf("%d snapshots; want 10", len(snapshots))
+	}
+	// after the blinds the button, seat 1, is first to act
+	start := snapshots[0]
+	if start.Step != 0 || start.Action != nil || start.Pot != 15 || start.ToAct != 1 || start.Round != table.Preflop {
+		t.Fatalf("start = %+v", start)
+	}
+	if o := start.Options; o == nil || o.ToCall != 10 || o.MinRaise != 20 || o.MaxRaise != 1000 {
+		t.Fatalf("options = %+v", start.Options)
+	}
+	total := 0.0
+	for _, p := range start.Players {
+		total += p.Equity
+	}
+	if len(start.Players) != 3 || math.Abs(total-1) > 1e-9 {
+		t.Fatalf("players = %+v", start.Players)
+	}
+	// the flop comes after the big blind folds
+	flop := snapshots[3]
+	if flop.Round != table.Flop || len(flop.Board) != 3 || flop.Pot != 70 || flop.Action.Type != table.Fold {
+		t.Fatalf("flop = %+v", flop)
+	}
+	if p := flop.Players[0]; !p.Folded || p.Equity != 0 || p.Chips != 990 {
+		t.Fatalf("folded player = %+v", p)
+	}
+	if p := flop.Players[2]; p.Chips != 970 || p.Description != hand.New(append(p.Cards, flop.Board...)).Description() {
+		t.Fatalf("player = %+v", p)
+	}
+	bet := snapshots[4]
+	if bet.Pot != 110 || bet.Players[2].Bet != 40 || bet.Options.ToCall != 40 {
+		t.Fatalf("bet = %+v", bet)
+	}
+	// equity is only sampled again when the board or the players change
+	if snapshots[1].Players[2].Equity != start.Players[2].Equity || bet.Players[2].Equity != flop.Players[2].Equity {
+		t.Fatalf("equity changed within a street: %v %v", snapshots[1].Players, bet.Players)
+	}
+	end := snapshots[len(snapshots)-1]
+	if end.Active || end.Options != nil || len(end.Board) != 5 || len(end.Pots) == 0 {
+		t.Fatalf("end = %+v", end)
+	}
+	for i, p := range end.Players {
+		if rs := rec.Seats[i]; p.Won != rs.Won || p.Chips != rs.Chips+rs.Net {
+			t.Fatalf("player = %+v; record = %+v", p, rs)
+		}
+		if p.Won > 0 && p.Equity != 1 && p.Equity != 0.5 {
+			t.Fatalf("winner equity = %v", p.Equity)
+		}
+	}
+	b, err := json.Marshal(end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got replay.Snapshot
+	if err := json.Unmarshal(b, &got); err != nil || got.Step != 9 || got.Action.Type != table.Check {
+		t.Fatalf("snapshot %s: %v", b, err)
+	}
+}
+
+func TestBigBlindAnte(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tbl, err := table.New(3, table.Stakes(5, 10), table.BigBlindAnte(10), table.WithDealer(hand.NewDealer(r)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for seat := 0; seat < 3; seat++ {
+		tbl.Sit(seat, fmt.Sprint("p", seat), 1000)
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []table.ActionType{table.Call, table.Fold, table.Check} {
+		if err := tbl.Act(tbl.ToAct(), table.Action{Type: a}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for tbl.Active() {
+		if err := tbl.Act(tbl.ToAct(), table.Action{Type: table.Check}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := replay.All(tbl.Record(), replay.Samples(100)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(r *table.Record)
+	}{
+		{"illegal action", func(r *table.Record) { r.Actions[len(r.Actions)-5].Type = table.Check }},
+		{"wrong seat", func(r *table.Record) { r.Actions[3].Seat = 0 }},
+		{"missing actions", func(r *table.Record) { r.Actions = r.Actions[:6] }},
+		{"wrong winnings", func(r *table.Record) { r.Seats[0].Won++ }},
+		{"duplicate cards", func(r *table.Record) { r.Board[0] = r.Seats[0].Cards[0] }},
+		{"missing cards", func(r *table.Record) { r.Seats[1].Cards = nil }},
+		{"wrong button", func(r *table.Record) { r.Button = 2 }},
+		{"seat past the table", func(r *table.Record) { r.Seats[2].Seat = table.MaxSeats }},
+		{"huge seat", func(r *table.Record) { r.Seats[2].Seat = 1 << 36 }},
+	}
+	for _, test := range tests {
+		rec := record(t)
+		test.change(rec)
+		if _, err := replay.All(rec, replay.Samples(100)); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+	// the snapshots before a bad action are still read
+	rec := record(t)
+	rec.Actions[len(rec.Actions)-1].Type = table.Call
+	rp, err := replay.New(rec, replay.Samples(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for rp.Next() {
+		n++
+	}
+	if rp.Err() == nil || n != 9 {
+		t.Fatalf("%d snapshots, err %v", n, rp.Err())
+	}
+}
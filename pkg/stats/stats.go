@@ -0,0 +1,205 @@
+// Package stats aggregates player statistics, such as VPIP, PFR, and how
+// often players go to showdown, from hand records.  Statistics are kept
+// for each player, position, and stakes so that they can be broken down
+// or summed, and winnings are also given adjusted for the luck of all-in
+// hands.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+//go:generate stringer -type=Position -output=stringer_autogen.go
+
+// Position is a seat's position relative to the button.
+type Position int
+
+const (
+	// SmallBlind posts the small blind.  Heads-up the button posts the
+	// small blind and has the Button position.
+	SmallBlind Position = iota + 1
+
+	// BigBlind posts the big blind.
+	BigBlind
+
+	// UnderTheGun acts first before the flop at tables of six or more.
+	UnderTheGun
+
+	// Middle is any position between under the gun and the hijack.
+	Middle
+
+	// Hijack sits two to the right of the button.
+	Hijack
+
+	// Cutoff sits to the right of the button.
+	Cutoff
+
+	// Button acts last after the flop.
+	Button
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.  Positions
+// are written as "smallBlind", "underTheGun", and so on.
+func (p Position) MarshalText() ([]byte, error) {
+	return []byte(lowerFirst(p.String())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Position) UnmarshalText(text []byte) error {
+	for q := SmallBlind; q <= Button; q++ {
+		if lowerFirst(q.String()) == string(text) {
+			*p = q
+			return nil
+		}
+	}
+	return fmt.Errorf("stats: unknown position %q", text)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// Stakes are the forced bets of a hand.
+type Stakes struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+	Ante       int `json:"ante,omitempty"`
+}
+
+// String returns the stakes as "1/2", with any ante after a slash.
+func (s Stakes) String() string {
+	if s.Ante > 0 {
+		return fmt.Sprintf("%d/%d/%d", s.SmallBlind, s.BigBlind, s.Ante)
+	}
+	return fmt.Sprintf("%d/%d", s.SmallBlind, s.BigBlind)
+}
+
+// Stats are the counts behind a player's statistics.  Opportunities are
+// the hands where a player could have taken an action, such as a 3-bet
+// when facing a single raise before the flop.  Bets and Calls count
+// actions after the flop, with raises counted as bets.  Net is the chips
+// won or lost, and Adjusted is the same with the result of every all-in
+// hand replaced by the player's equity in the pots when the money went
+// in.  Both are also kept in big blinds so that stakes can be summed.
+type Stats struct {
+	Hands                       int     `json:"hands"`
+	VPIP                        int     `json:"vpip"`
+	PFR                         int     `json:"pfr"`
+	ThreeBetOpportunities       int     `json:"threeBetOpportunities"`
+	ThreeBets                   int     `json:"threeBets"`
+	FoldToThreeBetOpportunities int     `json:"foldToThreeBetOpportunities"`
+	FoldsToThreeBet             int     `json:"foldsToThreeBet"`
+	CBetOpportunities           int     `json:"cbetOpportunities"`
+	CBets                       int     `json:"cbets"`
+	SawFlop                     int     `json:"sawFlop"`
+	Showdowns                   int     `json:"showdowns"`
+	ShowdownsWon                int     `json:"showdownsWon"`
+	Bets                        int     `json:"bets"`
+	Calls                       int     `json:"calls"`
+	Net                         int     `json:"net"`
+	Adjusted                    float64 `json:"adjusted"`
+	NetBigBlinds                float64 `json:"netBigBlinds"`
+	AdjustedBigBlinds           float64 `json:"adjustedBigBlinds"`
+}
+
+// Add adds the counts of o to s.
+func (s *Stats) Add(o Stats) {
+	s.Hands += o.Hands
+	s.VPIP += o.VPIP
+	s.PFR += o.PFR
+	s.ThreeBetOpportunities += o.ThreeBetOpportunities
+	s.ThreeBets += o.ThreeBets
+	s.FoldToThreeBetOpportunities += o.FoldToThreeBetOpportunities
+	s.FoldsToThreeBet += o.FoldsToThreeBet
+	s.CBetOpportunities += o.CBetOpportunities
+	s.CBets += o.CBets
+	s.SawFlop += o.SawFlop
+	s.Showdowns += o.Showdowns
+	s.ShowdownsWon += o.ShowdownsWon
+	s.Bets += o.Bets
+	s.Calls += o.Calls
+	s.Net += o.Net
+	s.Adjusted += o.Adjusted
+	s.NetBigBlinds += o.NetBigBlinds
+	s.AdjustedBigBlinds += o.AdjustedBigBlinds
+}
+
+// VPIPRate returns the fraction of hands where the player voluntarily put
+// chips in before the flop.
+func (s Stats) VPIPRate() float64 {
+	return ratio(s.VPIP, s.Hands)
+}
+
+// PFRRate returns the fraction of hands where the player raised before
+// the flop.
+func (s Stats) PFRRate() float64 {
+	return ratio(s.PFR, s.Hands)
+}
+
+// ThreeBetRate returns how often the player re-raised a single raise
+// before the flop.
+func (s Stats) ThreeBetRate() float64 {
+	return ratio(s.ThreeBets, s.ThreeBetOpportunities)
+}
+
+// FoldToThreeBetRate returns how often the player folded to a re-raise of
+// their opening raise.
+func (s Stats) FoldToThreeBetRate() float64 {
+	return ratio(s.FoldsToThreeBet, s.FoldToThreeBetOpportunities)
+}
+
+// CBetRate returns how often the player bet the flop after making the
+// last raise before it, when nobody had bet first.
+func (s Stats) CBetRate() float64 {
+	return ratio(s.CBets, s.CBetOpportunities)
+}
+
+// WTSD returns how often the player went to showdown after seeing the
+// flop.
+func (s Stats) WTSD() float64 {
+	return ratio(s.Showdowns, s.SawFlop)
+}
+
+// WSD returns how often the player won chips at showdown, W$SD.
+func (s Stats) WSD() float64 {
+	return ratio(s.ShowdownsWon, s.Showdowns)
+}
+
+// AggressionFactor returns the player's bets and raises per call after the
+// flop.  It is infinite for a player who bets but never calls.
+func (s Stats) AggressionFactor() float64 {
+	if s.Calls == 0 && s.Bets > 0 {
+		return math.Inf(1)
+	}
+	return ratio(s.Bets, s.Calls)
+}
+
+// BigBlindsPer100 returns the player's winnings in big blinds per 100
+// hands.
+func (s Stats) BigBlindsPer100() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return 100 * s.NetBigBlinds / float64(s.Hands)
+}
+
+// AdjustedBigBlindsPer100 returns the player's all-in adjusted winnings in
+// big blinds per 100 hands.
+func (s Stats) AdjustedBigBlindsPer100() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return 100 * s.AdjustedBigBlinds / float64(s.Hands)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,267 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/stats"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// stacked deals its cards in order, two hole cards to each player from
+// the lowest seat up and then the board, followed by the rest of the deck.
+type stacked []hand.Card
+
+func (s stacked) Deck() *hand.Deck {
+	used := map[hand.Card]bool{}
+	for _, c := range s {
+		used[c] = true
+	}
+	var cards []hand.Card
+	for _, c := range hand.Cards() {
+		if !used[c] {
+			cards = append(cards, c)
+		}
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		cards = append(cards, s[i])
+	}
+	return &hand.Deck{Cards: cards}
+}
+
+// play deals a hand with the cards to players with the stacks and takes
+// the actions, such as "raise 6", in turn.
+func play(t *testing.T, cards []hand.Card, stacks []int, actions ...string) *table.Record {
+	tbl, err := table.New(len(stacks), table.Stakes(1, 2), table.WithDealer(stacked(cards)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, chips := range stacks {
+		if err := tbl.Sit(i, fmt.Sprint("p", i), chips); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range actions {
+		var a table.Action
+		parts := strings.Fields(s)
+		if err := a.Type.UnmarshalText([]byte(parts[0])); err != nil {
+			t.Fatal(err)
+		}
+		if len(parts) > 1 {
+			fmt.Sscan(parts[1], &a.Amount)
+		}
+		if err := tbl.Act(tbl.ToAct(), a); err != nil {
+			t.Fatalf("%s by seat %d: %v", s, tbl.ToAct(), err)
+		}
+	}
+	if tbl.Active() {
+		t.Fatal("expected the hand to be over")
+	}
+	return tbl.Record()
+}
+
+func TestCounts(t *testing.T) {
+	// the button opens, the small blind 3-bets and c-bets, and the button
+	// raises the flop and wins at showdown
+	cards := Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd", "7c", "2h", "3d", "4c", "9s")
+	rec := play(t, cards, []int{200, 200, 200},
+		"raise 6", "raise 18", "fold", "call",
+		"bet 20", "raise 60", "call",
+		"check", "check", "check", "check")
+	tr := stats.New()
+	if err := tr.Add(rec); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]stats.Stats{
+		"p0": {Hands: 1, VPIP: 1, PFR: 1, FoldToThreeBetOpportunities: 1, SawFlop: 1, Showdowns: 1, ShowdownsWon: 1, Bets: 1},
+		"p1": {Hands: 1, VPIP: 1, PFR: 1, ThreeBetOpportunities: 1, ThreeBets: 1, CBetOpportunities: 1, CBets: 1, SawFlop: 1, Showdowns: 1, Bets: 1, Calls: 1},
+		"p2": {Hands: 1},
+	}
+	for name, w := range want {
+		got := tr.Stats(name)
+		w.Net, w.Adjusted, w.NetBigBlinds, w.AdjustedBigBlinds = got.Net, got.Adjusted, got.NetBigBlinds, got.AdjustedBigBlinds
+		if got != w {
+			t.Fatalf("%s stats = %+v; want %+v", name, got, w)
+		}
+	}
+	if p0 := tr.Stats("p0"); p0.Net != 80 || p0.NetBigBlinds != 40 || p0.WSD() != 1 || !math.IsInf(p0.AggressionFactor(), 1) {
+		t.Fatalf("p0 = %+v", p0)
+	}
+	if got := tr.Stats("p1", stats.AtPosition(stats.SmallBlind)); got.Hands != 1 {
+		t.Fatalf("small blind stats = %+v", got)
+	}
+	if got := tr.Stats("p1", stats.AtPosition(stats.Button)); got.Hands != 0 {
+		t.Fatalf("button stats = %+v", got)
+	}
+	if got := tr.Stats("p2", stats.AtStakes(stats.Stakes{SmallBlind: 1, BigBlind: 2})); got.Net != -2 {
+		t.Fatalf("stakes stats = %+v", got)
+	}
+}
+
+func TestAllInEV(t *testing.T) {
+	// aces lose to kings that hit a king after the money goes in, on the
+	// turn with two outs and before the flop
+	turn := play(t, Cards("As", "Ad", "Ks", "Kd", "2c", "7d", "9h", "3s", "Kc"), []int{100, 100},
+		"call", "check", "check", "check", "bet 98", "call")
+	preflop := play(t, Cards("As", "Ad", "Ks", "Kd", "2c", "7d", "9h", "3s", "Kc"), []int{100, 100},
+		"raise 100", "call")
+	tr := stats.New(stats.WithRand(rand.New(rand.NewSource(1))), stats.Samples(5000))
+	if err := tr.Add(turn); err != nil {
+		t.Fatal(err)
+	}
+	aces, kings := tr.Stats("p0"), tr.Stats("p1")
+	if aces.Net != -100 || math.Abs(aces.Adjusted-(200*42.0/44-100)) > 1e-9 || math.Abs(aces.Adjusted+kings.Adjusted) > 1e-9 {
+		t.Fatalf("aces %+v kings %+v", aces, kings)
+	}
+	if math.Abs(aces.AdjustedBigBlinds-aces.Adjusted/2) > 1e-9 {
+		t.Fatalf("adjusted big blinds = %v", aces.AdjustedBigBlinds)
+	}
+	tr = stats.New(stats.WithRand(rand.New(rand.NewSource(1))), stats.Samples(5000))
+	if err := tr.Add(preflop); err != nil {
+		t.Fatal(err)
+	}
+	// aces are about 82% against kings
+	if aces := tr.Stats("p0"); aces.Adjusted < 55 || aces.Adjusted > 75 {
+		t.Fatalf("aces adjusted = %v", aces.Adjusted)
+	}
+}
+
+func TestSidePotEV(t *testing.T) {
+	cards := Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd", "7c", "2h", "3d", "9s", "Jh")
+	rec := play(t, cards, []int{200, 200, 200}, "call", "call", "check",
+		"check", "check", "check", "check", "check", "check", "check", "check", "check")
+	tr := stats.New()
+	if err := tr.Add(rec); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"p0", "p1", "p2"} {
+		if s := tr.Stats(name); s.Adjusted != float64(s.Net) {
+			t.Fatalf("%s adjusted %v for a hand that wasn't all in", name, s.Adjusted)
+		}
+	}
+
+	// the short stack is all in before the flop and seat 2 folds to a bet
+	// on the flop, leaving aces against kings for the main pot of 150
+	rec = play(t, cards, []int{50, 200, 200}, "raise 50", "call", "call", "bet 20", "fold")
+	tr = stats.New()
+	if err := tr.Add(rec); err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	for _, name := range []string{"p0", "p1", "p2"} {
+		total += tr.Stats(name).Adjusted
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Fatalf("adjusted winnings sum to %v", total)
+	}
+	if s := tr.Stats("p0"); s.Net != 100 || s.Adjusted < 80 || s.Adjusted > 95 {
+		t.Fatalf("short stack net %d adjusted %v", s.Net, s.Adjusted)
+	}
+	if s := tr.Stats("p2"); s.Adjusted != -50 {
+		t.Fatalf("folded player adjusted %v", s.Adjusted)
+	}
+
+	// with everyone all in before the flop the side pot is valued too
+	rec = play(t, cards, []int{50, 100, 200}, "raise 50", "raise 100", "call")
+	tr = stats.New(stats.WithRand(rand.New(rand.NewSource(1))), stats.Samples(2000))
+	if err := tr.Add(rec); err != nil {
+		t.Fatal(err)
+	}
+	total = 0.0
+	for _, name := range []string{"p0", "p1", "p2"} {
+		total += tr.Stats(name).Adjusted
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Fatalf("adjusted winnings sum to %v", total)
+	}
+	// seat 0 can only win the main pot of 150
+	if s := tr.Stats("p0"); s.Adjusted <= 0 || s.Adjusted > 100 {
+		t.Fatalf("short stack adjusted = %v", s.Adjusted)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	cards := Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd", "Js", "Jd", "Ts", "Td", "9s", "9d")
+	rec := play(t, cards, []int{100, 100, 100, 100, 100, 100}, "fold", "fold", "fold", "fold", "fold")
+	tr := stats.New()
+	tr.Add(rec)
+	var got []string
+	for _, k := range tr.Keys() {
+		got = append(got, k.Player+" "+k.Position.String())
+	}
+	const want = "[p0 Button p1 SmallBlind p2 BigBlind p3 UnderTheGun p4 Hijack p5 Cutoff]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("positions = %v; want %s", got, want)
+	}
+	if s := tr.Stats("p3"); s.Hands != 1 || s.VPIP != 0 {
+		t.Fatalf("p3 = %+v", s)
+	}
+	if err := tr.Read(strings.NewReader(`{"button":5,"seats":[{"seat":0},{"seat":1}]}`)); err == nil {
+		t.Fatal("expected error for a button that isn't one of the seats")
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	// a stream of records is read like records added one by one
+	b := &bytes.Buffer{}
+	enc := json.NewEncoder(b)
+	r := rand.New(rand.NewSource(1))
+	tbl, _ := table.New(3, table.WithDealer(hand.NewDealer(r)))
+	for i := 0; i < 3; i++ {
+		tbl.Sit(i, fmt.Sprint("p", i), 1000)
+	}
+	added := stats.New()
+	for n := 0; n < 20 && tbl.Deal() == nil; n++ {
+		for tbl.Active() {
+			o := tbl.View(tbl.ToAct()).Options
+			a := table.Action{Type: table.Call}
+			if o.ToCall == 0 {
+				a.Type = table.Check
+				if r.Intn(3) == 0 && o.MinRaise > 0 {
+					a = table.Action{Type: o.Actions[len(o.Actions)-1], Amount: o.MinRaise}
+				}
+			}
+			if err := tbl.Act(tbl.ToAct(), a); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := added.Add(tbl.Record()); err != nil {
+			t.Fatal(err)
+		}
+		enc.Encode(tbl.Record())
+	}
+	read := stats.New()
+	if err := read.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := json.Marshal(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := stats.New()
+	if err := json.Unmarshal(saved, restored); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(restored.Keys()) != fmt.Sprint(added.Keys()) || fmt.Sprint(read.Players()) != "[p0 p1 p2]" {
+		t.Fatalf("keys = %v; want %v", restored.Keys(), added.Keys())
+	}
+	for _, name := range added.Players() {
+		a, r := added.Stats(name), restored.Stats(name)
+		if a.Hands != 20 || a.Net != r.Net || a.VPIP != r.VPIP || a.SawFlop != r.SawFlop || a.Bets != r.Bets || a.Showdowns != r.Showdowns {
+			t.Fatalf("%s restored %+v; want %+v", name, r, a)
+		}
+	}
+	if err := json.Unmarshal([]byte(`[{"player":"p0","position":"dealer"}]`), restored); err == nil {
+		t.Fatal("expected error for an unknown position")
+	}
+}
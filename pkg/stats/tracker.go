@@ -0,0 +1,385 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Config represents the configuration options for a tracker.
+type Config struct {
+	samples int
+	r       *rand.Rand
+}
+
+// Samples sets the number of boards sampled for the equity of an all-in
+// hand when the remaining boards are too many to enumerate.  The default
+// is 1000.
+func Samples(n int) func(*Config) {
+	return func(c *Config) {
+		c.samples = n
+	}
+}
+
+// WithRand sets the random source used for sampling.  A source seeded
+// from the time is used by default.
+func WithRand(r *rand.Rand) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+	}
+}
+
+// Key identifies the hands that statistics are kept for.
+type Key struct {
+	Player   string   `json:"player"`
+	Position Position `json:"position"`
+	Stakes   Stakes   `json:"stakes"`
+}
+
+// Tracker aggregates statistics from a stream of hand records.  It is
+// safe for concurrent use and can be saved and restored as JSON.
+type Tracker struct {
+	mu     sync.Mutex
+	config Config
+	stats  map[Key]*Stats
+}
+
+// New returns an empty tracker.
+func New(options ...func(*Config)) *Tracker {
+	c := Config{samples: 1000}
+	for _, option := range options {
+		option(&c)
+	}
+	if c.r == nil {
+		c.r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &Tracker{config: c, stats: map[Key]*Stats{}}
+}
+
+// Add adds a finished hand to the statistics of the players dealt in.
+func (t *Tracker) Add(r *table.Record) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	positions, err := positions(r)
+	if err != nil {
+		return err
+	}
+	adjusted, err := t.allInEV(r)
+	if err != nil {
+		return err
+	}
+	stakes := Stakes{SmallBlind: r.SmallBlind, BigBlind: r.BigBlind, Ante: r.Ante}
+	for _, rs := range r.Seats {
+		s := count(r, rs)
+		s.Adjusted = float64(rs.Net)
+		if ev, ok := adjusted[rs.Seat]; ok {
+			s.Adjusted = ev
+		}
+		if r.BigBlind > 0 {
+			s.NetBigBlinds = float64(rs.Net) / float64(r.BigBlind)
+			s.AdjustedBigBlinds = s.Adjusted / float64(r.BigBlind)
+		}
+		k := Key{Player: rs.Name, Position: positions[rs.Seat], Stakes: stakes}
+		if t.stats[k] == nil {
+			t.stats[k] = &Stats{}
+		}
+		t.stats[k].Add(s)
+	}
+	return nil
+}
+
+// Read adds every hand in a stream of JSON hand records.
+func (t *Tracker) Read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		rec := &table.Record{}
+		if err := dec.Decode(rec); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := t.Add(rec); err != nil {
+			return err
+		}
+	}
+}
+
+// Keys returns the keys statistics are kept for, sorted by player,
+// stakes, and position.
+func (t *Tracker) Keys() []Key {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	keys := []Key{}
+	for k := range t.stats {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.Player != b.Player:
+			return a.Player < b.Player
+		case a.Stakes.BigBlind != b.Stakes.BigBlind:
+			return a.Stakes.BigBlind < b.Stakes.BigBlind
+		case a.Stakes.SmallBlind != b.Stakes.SmallBlind:
+			return a.Stakes.SmallBlind < b.Stakes.SmallBlind
+		case a.Stakes.Ante != b.Stakes.Ante:
+			return a.Stakes.Ante < b.Stakes.Ante
+		}
+		return a.Position < b.Position
+	})
+	return keys
+}
+
+// Players returns the names of the players seen, sorted.
+func (t *Tracker) Players() []string {
+	names := []string{}
+	for _, k := range t.Keys() {
+		if len(names) == 0 || names[len(names)-1] != k.Player {
+			names = append(names, k.Player)
+		}
+	}
+	return names
+}
+
+// Stats returns a player's statistics summed over the keys matching all
+// of the filters, such as AtPosition(Button).
+func (t *Tracker) Stats(player string, filters ...func(Key) bool) Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total := Stats{}
+outer:
+	for k, s := range t.stats {
+		if k.Player != player {
+			continue
+		}
+		for _, f := range filters {
+			if !f(k) {
+				continue outer
+			}
+		}
+		total.Add(*s)
+	}
+	return total
+}
+
+// AtPosition matches keys for a position.
+func AtPosition(p Position) func(Key) bool {
+	return func(k Key) bool {
+		return k.Position == p
+	}
+}
+
+// AtStakes matches keys for stakes.
+func AtStakes(s Stakes) func(Key) bool {
+	return func(k Key) bool {
+		return k.Stakes == s
+	}
+}
+
+type entry struct {
+	Key
+	Stats Stats `json:"stats"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, saving the
+// statistics as a list of keys and their counts.
+func (t *Tracker) MarshalJSON() ([]byte, error) {
+	keys := t.Keys()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entries := []entry{}
+	for _, k := range keys {
+		entries = append(entries, entry{Key: k, Stats: *t.stats[k]})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, restoring
+// statistics saved with MarshalJSON.  The tracker's options are kept.
+func (t *Tracker) UnmarshalJSON(b []byte) error {
+	var entries []entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.config.r == nil {
+		t.config = New().config
+	}
+	t.stats = map[Key]*Stats{}
+	for _, e := range entries {
+		s := e.Stats
+		t.stats[e.Key] = &s
+	}
+	return nil
+}
+
+// positions returns the position of each seat dealt in.
+func positions(r *table.Record) (map[int]Position, error) {
+	seats := []int{}
+	for _, s := range r.Seats {
+		seats = append(seats, s.Seat)
+	}
+	sort.Ints(seats)
+	// start from the button
+	button := sort.SearchInts(seats, r.Button)
+	if button == len(seats) || seats[button] != r.Button {
+		return nil, errors.New("stats: the button isn't one of the seats")
+	}
+	seats = append(append([]int{}, seats[button:]...), seats[:button]...)
+	n := len(seats)
+	p := map[int]Position{}
+	for i, seat := range seats {
+		switch {
+		case i == 0:
+			p[seat] = Button
+		case n == 2 || i == 2:
+			p[seat] = BigBlind
+		case i == 1:
+			p[seat] = SmallBlind
+		case i == n-1:
+			p[seat] = Cutoff
+		case i == n-2:
+			p[seat] = Hijack
+		case i == 3:
+			p[seat] = UnderTheGun
+		default:
+			p[seat] = Middle
+		}
+	}
+	return p, nil
+}
+
+// count returns the counts of a single hand for a seat, apart from the
+// winnings.
+func count(r *table.Record, rs table.RecordSeat) Stats {
+	s := Stats{Hands: 1, Net: rs.Net}
+	raises := 0
+	opener, aggressor := -1, -1
+	threeBetSeen, foldSeen := false, false
+	folded := false
+	for _, a := range r.Actions {
+		if a.Round != table.Preflop {
+			continue
+		}
+		if a.Seat == rs.Seat {
+			switch a.Type {
+			case table.Call, table.Bet, table.Raise:
+				s.VPIP = 1
+			case table.Fold:
+				folded = true
+			}
+			if (a.Type == table.Bet || a.Type == table.Raise) && s.PFR == 0 {
+				s.PFR = 1
+			}
+			// a 3-bet can be made facing a single raise by someone else
+			if raises == 1 && opener != rs.Seat && !threeBetSeen {
+				threeBetSeen = true
+				s.ThreeBetOpportunities = 1
+				if a.Type == table.Raise {
+					s.ThreeBets = 1
+				}
+			}
+			if raises == 2 && opener == rs.Seat && !foldSeen {
+				foldSeen = true
+				s.FoldToThreeBetOpportunities = 1
+				if a.Type == table.Fold {
+					s.FoldsToThreeBet = 1
+				}
+			}
+		}
+		if a.Type == table.Bet || a.Type == table.Raise {
+			raises++
+			if raises == 1 {
+				opener = a.Seat
+			}
+			aggressor = a.Seat
+		}
+	}
+	if folded || len(r.Board) < 3 {
+		return s
+	}
+	s.SawFlop = 1
+	flopBets := 0
+	acted := false
+	for _, a := range r.Actions {
+		if a.Round == table.Preflop {
+			continue
+		}
+		if a.Seat == rs.Seat {
+			if a.Round == table.Flop && !acted && aggressor == rs.Seat && flopBets == 0 {
+				s.CBetOpportunities = 1
+				if a.Type == table.Bet {
+					s.CBets = 1
+				}
+			}
+			if a.Round == table.Flop {
+				acted = true
+			}
+			switch a.Type {
+			case table.Bet, table.Raise:
+				s.Bets++
+			case table.Call:
+				s.Calls++
+			}
+		}
+		if a.Round == table.Flop && (a.Type == table.Bet || a.Type == table.Raise) {
+			flopBets++
+		}
+	}
+	if rs.Shown {
+		s.Showdowns = 1
+		if rs.Won > 0 {
+			s.ShowdownsWon = 1
+		}
+	}
+	return s
+}
+
+// boardAt is the number of board cards dealt in each round.
+var boardAt = map[table.Round]int{table.Preflop: 0, table.Flop: 3, table.Turn: 4, table.River: 5}
+
+// allInEV returns the expected net of each player in a hand where the
+// chips all went in before the river, with the pots valued over the boards
+// that could have come after the last action.  It returns nil for other
+// hands.
+func (t *Tracker) allInEV(r *table.Record) (map[int]float64, error) {
+	if len(r.Actions) == 0 || len(r.Pots) == 0 {
+		return nil, nil
+	}
+	known := boardAt[r.Actions[len(r.Actions)-1].Round]
+	if known >= len(r.Board) {
+		return nil, nil
+	}
+	var stakes []equity.Stake
+	shown := 0
+	for _, rs := range r.Seats {
+		// what was put in, after any uncalled bet was returned
+		s := equity.Stake{Seat: rs.Seat, Chips: rs.Won - rs.Net, Folded: !rs.Shown}
+		if rs.Shown {
+			s.Cards = rs.Cards
+			shown++
+		}
+		stakes = append(stakes, s)
+	}
+	if shown < 2 {
+		return nil, nil
+	}
+	res, err := equity.EV(stakes, r.Button, r.Board[:known],
+		equity.Iterations(t.config.samples), equity.WithRand(t.config.r))
+	if err != nil {
+		return nil, err
+	}
+	ev := map[int]float64{}
+	for i, s := range stakes {
+		ev[s.Seat] = res.Net[i]
+	}
+	return ev, nil
+}
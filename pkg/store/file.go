@@ -0,0 +1,171 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+)
+
+// file is a store kept in an append-only journal.  Each committed
+// transaction is appended as a single entry, its length and checksum
+// followed by its changes as JSON, and synced before the transaction
+// returns.  Opening the file replays the journal, dropping a final entry
+// cut short by a crash, so a transaction is either saved whole or not at
+// all.
+type file struct {
+	mu     sync.RWMutex
+	f      *os.File
+	d      *data
+	size   int64
+	closed bool
+}
+
+// headerSize is the size of an entry's length and checksum.
+const headerSize = 8
+
+// Open opens the store kept in the file at path, creating it if needed.
+// A file must only be opened by one store at a time.
+func Open(path string) (Store, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s := &file{f: f, d: newData()}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load replays the journal and truncates a final entry cut short by a
+// crash: one that runs past the end of the file, or a tail of zeros left
+// where the file grew but the entry was never written.  A damaged entry
+// that isn't the last is an error, since dropping it would lose the
+// transactions after it.
+func (s *file) load() error {
+	buf, err := io.ReadAll(s.f)
+	if err != nil {
+		return err
+	}
+	for s.size < int64(len(buf)) {
+		rest := buf[s.size:]
+		if len(rest) < headerSize || zeros(rest) {
+			break
+		}
+		n := int64(binary.BigEndian.Uint32(rest[:4]))
+		if headerSize+n > int64(len(rest)) {
+			break
+		}
+		payload := rest[headerSize : headerSize+n]
+		if n == 0 || crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(rest[4:headerSize]) {
+			if headerSize+n == int64(len(rest)) {
+				break
+			}
+			return fmt.Errorf("store: corrupt journal entry at byte %d", s.size)
+		}
+		c := &change{}
+		if err := json.Unmarshal(payload, c); err != nil {
+			return fmt.Errorf("store: corrupt journal entry at byte %d", s.size)
+		}
+		s.d.apply(c)
+		s.size += headerSize + n
+	}
+	if err := s.f.Truncate(s.size); err != nil {
+		return err
+	}
+	_, err = s.f.Seek(s.size, io.SeekStart)
+	return err
+}
+
+// zeros returns whether b is all zero bytes.
+func zeros(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *file) View(f func(Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrClosed
+	}
+	return f(newTx(s.d, false))
+}
+
+func (s *file) Update(f func(Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	t := newTx(s.d, true)
+	if err := f(t); err != nil {
+		return err
+	}
+	c, err := t.commit()
+	if err != nil {
+		return err
+	}
+	if c.empty() {
+		return nil
+	}
+	if err := s.write(c); err != nil {
+		return err
+	}
+	s.d.apply(c)
+	return nil
+}
+
+// write appends an entry to the journal, removing any part of it written
+// if it fails.
+func (s *file) write(c *change) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	entry := make([]byte, headerSize, headerSize+len(payload))
+	binary.BigEndian.PutUint32(entry[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(entry[4:], crc32.ChecksumIEEE(payload))
+	entry = append(entry, payload...)
+	if _, err := s.f.Write(entry); err != nil {
+		s.rollback()
+		return err
+	}
+	if err := s.f.Sync(); err != nil {
+		s.rollback()
+		return err
+	}
+	s.size += int64(len(entry))
+	return nil
+}
+
+// rollback cuts the journal back to its last whole entry.  If even that
+// fails the store is closed, since later entries would follow a broken
+// one.
+func (s *file) rollback() {
+	if s.f.Truncate(s.size) != nil {
+		s.closed = true
+		s.f.Close()
+		return
+	}
+	s.f.Seek(s.size, io.SeekStart)
+}
+
+func (s *file) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.f.Close()
+}
@@ -0,0 +1,48 @@
+package store
+
+import "sync"
+
+type memory struct {
+	mu     sync.RWMutex
+	d      *data
+	closed bool
+}
+
+// NewMemory returns an empty store kept in memory.
+func NewMemory() Store {
+	return &memory{d: newData()}
+}
+
+func (m *memory) View(f func(Tx) error) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return ErrClosed
+	}
+	return f(newTx(m.d, false))
+}
+
+func (m *memory) Update(f func(Tx) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	t := newTx(m.d, true)
+	if err := f(t); err != nil {
+		return err
+	}
+	c, err := t.commit()
+	if err != nil {
+		return err
+	}
+	m.d.apply(c)
+	return nil
+}
+
+func (m *memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
@@ -0,0 +1,466 @@
+// Package store keeps players, tables, hand records, and chip balances.
+// Everything is read and written in transactions, so a hand's record and
+// the chips it moved are saved together or not at all, and a transaction
+// that would create or destroy chips is refused.  Chips only enter and
+// leave through deposits and withdrawals.
+//
+// NewMemory returns a store kept in memory, for tests, and Open returns
+// one kept in a journal file that survives crashes.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/notnil/joker/pkg/table"
+)
+
+var (
+	// ErrNotFound is returned for a player or table that doesn't exist.
+	ErrNotFound = errors.New("store: not found")
+
+	// ErrInsufficientChips is returned when an account would go below
+	// zero.
+	ErrInsufficientChips = errors.New("store: insufficient chips")
+
+	// ErrReadOnly is returned for writes in a read-only transaction.
+	ErrReadOnly = errors.New("store: read-only transaction")
+
+	// ErrClosed is returned once a store is closed.
+	ErrClosed = errors.New("store: closed")
+)
+
+// Store is a store of players, tables, hands, and balances.
+type Store interface {
+	// View runs a read-only transaction.
+	View(f func(Tx) error) error
+
+	// Update runs a transaction that is committed if f returns nil and
+	// discarded otherwise.
+	Update(f func(Tx) error) error
+
+	// Close closes the store.
+	Close() error
+}
+
+// Tx is a transaction.
+type Tx interface {
+	// Player returns a player.
+	Player(name string) (*Player, error)
+
+	// Players returns every player, sorted by name.
+	Players() ([]*Player, error)
+
+	// PutPlayer adds or replaces a player.
+	PutPlayer(p *Player) error
+
+	// Table returns a table.
+	Table(id string) (*Table, error)
+
+	// Tables returns every table, sorted by id.
+	Tables() ([]*Table, error)
+
+	// PutTable adds or replaces a table.
+	PutTable(t *Table) error
+
+	// Balance returns the chips in an account.
+	Balance(a Account) (int, error)
+
+	// Deposit adds chips to a player's account.
+	Deposit(player string, chips int) error
+
+	// Withdraw takes chips out of a player's account.
+	Withdraw(player string, chips int) error
+
+	// Move moves chips between accounts, such as a player's account and
+	// their stack at a table.
+	Move(from, to Account, chips int) error
+
+	// AddHand saves a finished hand played at a table and moves the
+	// chips it won and lost between the stacks of its players.
+	AddHand(tableID string, r *table.Record) error
+
+	// Hands returns the hands played at a table in the order they were
+	// added.
+	Hands(tableID string) ([]*table.Record, error)
+}
+
+// Player is a registered player.
+type Player struct {
+	Name string `json:"name"`
+}
+
+// Table is a table's settings.
+type Table struct {
+	ID         string `json:"id"`
+	Seats      int    `json:"seats"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	Ante       int    `json:"ante,omitempty"`
+}
+
+// Account holds chips: a player's account, or their stack at a table if
+// Table is set.
+type Account struct {
+	Player string `json:"player"`
+	Table  string `json:"table,omitempty"`
+}
+
+// Stack returns the account of a player's stack at a table.
+func Stack(player, tableID string) Account {
+	return Account{Player: player, Table: tableID}
+}
+
+// Wallet returns a player's account.
+func Wallet(player string) Account {
+	return Account{Player: player}
+}
+
+// data is the contents of a store.
+type data struct {
+	players  map[string]*Player
+	tables   map[string]*Table
+	balances map[Account]int
+	hands    map[string][]*table.Record
+}
+
+func newData() *data {
+	return &data{
+		players:  map[string]*Player{},
+		tables:   map[string]*Table{},
+		balances: map[Account]int{},
+		hands:    map[string][]*table.Record{},
+	}
+}
+
+// change is the writes of a transaction.  Balances are the new amounts of
+// the accounts changed.
+type change struct {
+	Players  []*Player  `json:"players,omitempty"`
+	Tables   []*Table   `json:"tables,omitempty"`
+	Balances []balance  `json:"balances,omitempty"`
+	Hands    []handItem `json:"hands,omitempty"`
+}
+
+type balance struct {
+	Account
+	Chips int `json:"chips"`
+}
+
+type handItem struct {
+	Table  string        `json:"table"`
+	Record *table.Record `json:"record"`
+}
+
+func (c *change) empty() bool {
+	return len(c.Players) == 0 && len(c.Tables) == 0 && len(c.Balances) == 0 && len(c.Hands) == 0
+}
+
+// apply applies a committed change.
+func (d *data) apply(c *change) {
+	for _, p := range c.Players {
+		d.players[p.Name] = p
+	}
+	for _, t := range c.Tables {
+		d.tables[t.ID] = t
+	}
+	for _, b := range c.Balances {
+		if b.Chips == 0 {
+			delete(d.balances, b.Account)
+		} else {
+			d.balances[b.Account] = b.Chips
+		}
+	}
+	for _, h := range c.Hands {
+		d.hands[h.Table] = append(d.hands[h.Table], h.Record)
+	}
+}
+
+// tx is a transaction over data.  Writes are kept aside until commit, and
+// reads see them.
+type tx struct {
+	d        *data
+	writable bool
+	players  map[string]*Player
+	tables   map[string]*Table
+	balances map[Account]int
+	hands    []handItem
+	minted   int
+}
+
+func newTx(d *data, writable bool) *tx {
+	return &tx{
+		d:        d,
+		writable: writable,
+		players:  map[string]*Player{},
+		tables:   map[string]*Table{},
+		balances: map[Account]int{},
+	}
+}
+
+func (t *tx) Player(name string) (*Player, error) {
+	p, ok := t.players[name]
+	if !ok {
+		p, ok = t.d.players[name]
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *p
+	return &c, nil
+}
+
+func (t *tx) Players() ([]*Player, error) {
+	var names []string
+	for name := range t.d.players {
+		names = append(names, name)
+	}
+	for name := range t.players {
+		if _, ok := t.d.players[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	players := []*Player{}
+	for _, name := range names {
+		p, _ := t.Player(name)
+		players = append(players, p)
+	}
+	return players, nil
+}
+
+func (t *tx) PutPlayer(p *Player) error {
+	if !t.writable {
+		return ErrReadOnly
+	}
+	if p.Name == "" {
+		return errors.New("store: a player needs a name")
+	}
+	c := *p
+	t.players[p.Name] = &c
+	return nil
+}
+
+func (t *tx) Table(id string) (*Table, error) {
+	tb, ok := t.tables[id]
+	if !ok {
+		tb, ok = t.d.tables[id]
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *tb
+	return &c, nil
+}
+
+func (t *tx) Tables() ([]*Table, error) {
+	var ids []string
+	for id := range t.d.tables {
+		ids = append(ids, id)
+	}
+	for id := range t.tables {
+		if _, ok := t.d.tables[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	tables := []*Table{}
+	for _, id := range ids {
+		tb, _ := t.Table(id)
+		tables = append(tables, tb)
+	}
+	return tables, nil
+}
+
+func (t *tx) PutTable(tb *Table) error {
+	if !t.writable {
+		return ErrReadOnly
+	}
+	if tb.ID == "" {
+		return errors.New("store: a table needs an id")
+	}
+	c := *tb
+	t.tables[tb.ID] = &c
+	return nil
+}
+
+func (t *tx) Balance(a Account) (int, error) {
+	if chips, ok := t.balances[a]; ok {
+		return chips, nil
+	}
+	return t.d.balances[a], nil
+}
+
+// exists returns ErrNotFound unless an account's player and table exist.
+func (t *tx) exists(a Account) error {
+	if _, err := t.Player(a.Player); err != nil {
+		return err
+	}
+	if a.Table != "" {
+		if _, err := t.Table(a.Table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add changes the chips in an account.
+func (t *tx) add(a Account, chips int) error {
+	if !t.writable {
+		return ErrReadOnly
+	}
+	if err := t.exists(a); err != nil {
+		return err
+	}
+	b, _ := t.Balance(a)
+	if b+chips < 0 {
+		return ErrInsufficientChips
+	}
+	t.balances[a] = b + chips
+	return nil
+}
+
+func (t *tx) Deposit(player string, chips int) error {
+	if chips <= 0 {
+		return errors.New("store: invalid amount")
+	}
+	if err := t.add(Wallet(player), chips); err != nil {
+		return err
+	}
+	t.minted += chips
+	return nil
+}
+
+func (t *tx) Withdraw(player string, chips int) error {
+	if chips <= 0 {
+		return errors.New("store: invalid amount")
+	}
+	if err := t.add(Wallet(player), -chips); err != nil {
+		return err
+	}
+	t.minted -= chips
+	return nil
+}
+
+func (t *tx) Move(from, to Account, chips int) error {
+	if chips <= 0 || from == to {
+		return errors.New("store: invalid amount")
+	}
+	if err := t.exists(to); err != nil {
+		return err
+	}
+	if err := t.add(from, -chips); err != nil {
+		return err
+	}
+	return t.add(to, chips)
+}
+
+func (t *tx) AddHand(tableID string, r *table.Record) error {
+	if !t.writable {
+		return ErrReadOnly
+	}
+	if _, err := t.Table(tableID); err != nil {
+		return err
+	}
+	sum := 0
+	for _, rs := range r.Seats {
+		sum += rs.Net
+	}
+	if sum != 0 {
+		return errors.New("store: the hand's winnings don't add up")
+	}
+	// check every stack first so that a failed hand moves nothing
+	seen := map[string]bool{}
+	for _, rs := range r.Seats {
+		if seen[rs.Name] {
+			return errors.New("store: a player is seated twice in the hand")
+		}
+		seen[rs.Name] = true
+		a := Stack(rs.Name, tableID)
+		if err := t.exists(a); err != nil {
+			return err
+		}
+		if b, _ := t.Balance(a); b+rs.Net < 0 {
+			return ErrInsufficientChips
+		}
+	}
+	for _, rs := range r.Seats {
+		if rs.Net == 0 {
+			continue
+		}
+		if err := t.add(Stack(rs.Name, tableID), rs.Net); err != nil {
+			return err
+		}
+	}
+	c, err := cloneRecord(r)
+	if err != nil {
+		return err
+	}
+	t.hands = append(t.hands, handItem{Table: tableID, Record: c})
+	return nil
+}
+
+func (t *tx) Hands(tableID string) ([]*table.Record, error) {
+	if _, err := t.Table(tableID); err != nil {
+		return nil, err
+	}
+	records := append([]*table.Record{}, t.d.hands[tableID]...)
+	for _, h := range t.hands {
+		if h.Table == tableID {
+			records = append(records, h.Record)
+		}
+	}
+	hands := []*table.Record{}
+	for _, r := range records {
+		c, err := cloneRecord(r)
+		if err != nil {
+			return nil, err
+		}
+		hands = append(hands, c)
+	}
+	return hands, nil
+}
+
+// cloneRecord copies a record so that the store's copy can't be changed
+// by the caller.
+func cloneRecord(r *table.Record) (*table.Record, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &table.Record{}
+	return c, json.Unmarshal(b, c)
+}
+
+// commit returns the writes of the transaction, or an error if they would
+// create or destroy chips.
+func (t *tx) commit() (*change, error) {
+	c := &change{}
+	delta := 0
+	for a, chips := range t.balances {
+		delta += chips - t.d.balances[a]
+		c.Balances = append(c.Balances, balance{Account: a, Chips: chips})
+	}
+	if delta != t.minted {
+		return nil, errors.New("store: the transaction would create or destroy chips")
+	}
+	for _, p := range t.players {
+		c.Players = append(c.Players, p)
+	}
+	for _, tb := range t.tables {
+		c.Tables = append(c.Tables, tb)
+	}
+	c.Hands = t.hands
+	// map order is random, so sort for a stable journal
+	sort.Slice(c.Players, func(i, j int) bool { return c.Players[i].Name < c.Players[j].Name })
+	sort.Slice(c.Tables, func(i, j int) bool { return c.Tables[i].ID < c.Tables[j].ID })
+	sort.Slice(c.Balances, func(i, j int) bool {
+		a, b := c.Balances[i], c.Balances[j]
+		if a.Player != b.Player {
+			return a.Player < b.Player
+		}
+		return a.Table < b.Table
+	})
+	return c, nil
+}
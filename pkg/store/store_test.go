@@ -0,0 +1,273 @@
+package store_test
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/store"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// playHand plays a hand at a table where a and b bought in for 100 each
+// and returns its record.
+func playHand(t *testing.T) *table.Record {
+	tbl, _ := table.New(2, table.WithDealer(hand.NewDealer(rand.New(rand.NewSource(1)))))
+	tbl.Sit(0, "a", 100)
+	tbl.Sit(1, "b", 100)
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []table.Action{{Type: table.Raise, Amount: 10}, {Type: table.Call}} {
+		if err := tbl.Act(tbl.ToAct(), a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for tbl.Active() {
+		tbl.Act(tbl.ToAct(), table.Action{Type: table.Check})
+	}
+	return tbl.Record()
+}
+
+func balance(t *testing.T, s store.Store, a store.Account) int {
+	var chips int
+	if err := s.View(func(tx store.Tx) (err error) {
+		chips, err = tx.Balance(a)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return chips
+}
+
+// setup adds players a and b with 1000 chips each, and table t1 where
+// they bought in for 100.
+func setup(t *testing.T, s store.Store) {
+	err := s.Update(func(tx store.Tx) error {
+		tx.PutTable(&store.Table{ID: "t1", Seats: 2, SmallBlind: 1, BigBlind: 2})
+		for _, name := range []string{"a", "b"} {
+			tx.PutPlayer(&store.Player{Name: name})
+			if err := tx.Deposit(name, 1000); err != nil {
+				return err
+			}
+			if err := tx.Move(store.Wallet(name), store.Stack(name, "t1"), 100); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testStore(t *testing.T, s store.Store) {
+	setup(t, s)
+	rec := playHand(t)
+	if err := s.Update(func(tx store.Tx) error { return tx.AddHand("t1", rec) }); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := rec.Seat(0)
+	if got := balance(t, s, store.Stack("a", "t1")); got != 100+a.Net || a.Net == 0 {
+		t.Fatalf("stack = %d; want %d", got, 100+a.Net)
+	}
+	if got := balance(t, s, store.Stack("a", "t1")) + balance(t, s, store.Stack("b", "t1")); got != 200 {
+		t.Fatalf("stacks total %d; want 200", got)
+	}
+
+	// a failed transaction changes nothing
+	boom := errors.New("boom")
+	err := s.Update(func(tx store.Tx) error {
+		if err := tx.Move(store.Wallet("a"), store.Wallet("b"), 500); err != nil {
+			return err
+		}
+		if err := tx.AddHand("t1", rec); err != nil {
+			return err
+		}
+		return boom
+	})
+	if err != boom || balance(t, s, store.Wallet("a")) != 900 || balance(t, s, store.Wallet("b")) != 900 {
+		t.Fatalf("err = %v and balances changed", err)
+	}
+
+	err = s.Update(func(tx store.Tx) error {
+		return tx.Move(store.Wallet("a"), store.Stack("a", "t1"), 901)
+	})
+	if err != store.ErrInsufficientChips {
+		t.Fatalf("err = %v; want %v", err, store.ErrInsufficientChips)
+	}
+	err = s.Update(func(tx store.Tx) error {
+		return tx.Move(store.Wallet("a"), store.Stack("c", "t1"), 1)
+	})
+	if err != store.ErrNotFound {
+		t.Fatalf("err = %v; want %v", err, store.ErrNotFound)
+	}
+	err = s.View(func(tx store.Tx) error { return tx.Deposit("a", 1) })
+	if err != store.ErrReadOnly {
+		t.Fatalf("err = %v; want %v", err, store.ErrReadOnly)
+	}
+	// a hand that doesn't add up is refused
+	bad := *rec
+	bad.Seats = append([]table.RecordSeat{}, rec.Seats...)
+	bad.Seats[0].Net++
+	if err := s.Update(func(tx store.Tx) error { return tx.AddHand("t1", &bad) }); err == nil {
+		t.Fatal("expected error for a hand that creates chips")
+	}
+	// as is one that seats a player twice
+	twice := *rec
+	twice.Seats = append([]table.RecordSeat{}, rec.Seats...)
+	twice.Seats[1].Name = twice.Seats[0].Name
+	if err := s.Update(func(tx store.Tx) error { return tx.AddHand("t1", &twice) }); err == nil {
+		t.Fatal("expected error for a player seated twice")
+	}
+
+	err = s.View(func(tx store.Tx) error {
+		hands, err := tx.Hands("t1")
+		if err != nil {
+			return err
+		}
+		if len(hands) != 1 || hands[0].Seats[0].Net != a.Net {
+			t.Fatalf("hands = %+v", hands)
+		}
+		players, _ := tx.Players()
+		tables, _ := tx.Tables()
+		if len(players) != 2 || players[0].Name != "a" || len(tables) != 1 || tables[0].BigBlind != 2 {
+			t.Fatalf("players = %v tables = %v", players, tables)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	s := store.NewMemory()
+	testStore(t, s)
+	s.Close()
+	if err := s.View(func(store.Tx) error { return nil }); err != store.ErrClosed {
+		t.Fatalf("err = %v; want %v", err, store.ErrClosed)
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "joker.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+	want := balance(t, s, store.Stack("a", "t1"))
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a crash in the middle of writing a transaction leaves part of an
+	// entry at the end of the file
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0, 0, 1, 0, 1, 2, 3, 4, '{', '"'})
+	f.Close()
+
+	s, err = store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(t, s, store.Stack("a", "t1")); got != want || balance(t, s, store.Wallet("a")) != 900 {
+		t.Fatalf("reopened stack = %d; want %d", got, want)
+	}
+	if err := s.Update(func(tx store.Tx) error {
+		return tx.Move(store.Stack("a", "t1"), store.Wallet("a"), want)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	s, err = store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if got := balance(t, s, store.Wallet("a")); got != 900+want || balance(t, s, store.Stack("a", "t1")) != 0 {
+		t.Fatalf("wallet = %d; want %d", got, 900+want)
+	}
+	err = s.View(func(tx store.Tx) error {
+		hands, err := tx.Hands("t1")
+		if len(hands) != 1 {
+			t.Fatalf("%d hands after reopening", len(hands))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileDamaged(t *testing.T) {
+	tests := []struct {
+		name   string
+		damage func(b []byte) []byte
+		err    bool
+	}{
+		{"zero-filled tail", func(b []byte) []byte { return append(b, make([]byte, 8)...) }, false},
+		{"long zero-filled tail", func(b []byte) []byte { return append(b, make([]byte, 4096)...) }, false},
+		{"final entry cut short", func(b []byte) []byte { return append(b, 0, 0, 1, 0, 1, 2, 3, 4, '{') }, false},
+		{"flipped byte in the first entry", func(b []byte) []byte { b[10] ^= 1; return b }, true},
+		{"zero-length entry before others", func(b []byte) []byte {
+			return append(make([]byte, 8), b...)
+		}, true},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "joker.db")
+		s, err := store.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		setup(t, s)
+		s.Update(func(tx store.Tx) error { return tx.Deposit("a", 1) })
+		s.Close()
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := len(b)
+		if err := os.WriteFile(path, test.damage(b), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		s, err = store.Open(path)
+		if test.err {
+			if err == nil {
+				t.Fatalf("%s: expected error", test.name)
+			}
+			// the journal is left as it was
+			if fi, _ := os.Stat(path); fi.Size() < int64(size) {
+				t.Fatalf("%s: journal truncated to %d bytes", test.name, fi.Size())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if got := balance(t, s, store.Wallet("a")); got != 901 {
+			t.Fatalf("%s: balance = %d; want 901", test.name, got)
+		}
+		// later transactions are kept after reopening
+		if err := s.Update(func(tx store.Tx) error { return tx.Deposit("a", 1) }); err != nil {
+			t.Fatal(err)
+		}
+		s.Close()
+		s, err = store.Open(path)
+		if err != nil {
+			t.Fatalf("%s: reopening: %v", test.name, err)
+		}
+		if got := balance(t, s, store.Wallet("a")); got != 902 {
+			t.Fatalf("%s: balance = %d after reopening; want 902", test.name, got)
+		}
+		s.Close()
+	}
+}
@@ -0,0 +1,286 @@
+// Package strength calculates Billings-style hand strength and hand
+// potential metrics for hold'em hands.
+//
+// Hand strength (HS) is the probability that the hand is currently best
+// against an opponent hand, counting ties as half.  Positive potential
+// (PPot) is the probability that a hand that is behind or tied ends up
+// ahead after the board is dealt out, and negative potential (NPot) is the
+// probability that a hand that is ahead or tied ends up behind.  Effective
+// hand strength combines them as EHS = HS×(1−NPot) + (1−HS)×PPot.
+package strength
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// Config represents the configuration options for a calculation.
+type Config struct {
+	opponentRange [][]hand.Card
+	opponents     int
+	samples       int
+	r             *rand.Rand
+	toRiver       bool
+}
+
+// Range configures the calculation to use an opponent range given as a
+// list of two card combos instead of every possible hand.
+func Range(combos [][]hand.Card) func(*Config) {
+	return func(c *Config) {
+		c.opponentRange = combos
+	}
+}
+
+// Opponents configures the number of opponents used to raise hand
+// strength to a power for EHS.  The default is one.
+func Opponents(n int) func(*Config) {
+	return func(c *Config) {
+		c.opponents = n
+	}
+}
+
+// Sampling configures the calculation to sample n random opponent hands
+// and board runouts with the given random source instead of enumerating
+// them all.
+func Sampling(r *rand.Rand, n int) func(*Config) {
+	return func(c *Config) {
+		c.r = r
+		c.samples = n
+	}
+}
+
+// ToRiver configures potential to be calculated over all the remaining
+// streets instead of just the next one.
+func ToRiver(c *Config) {
+	c.toRiver = true
+}
+
+// Result holds the hand strength and potential metrics.
+type Result struct {
+	// HS is the current hand strength.
+	HS float64
+
+	// PPot is the positive potential.
+	PPot float64
+
+	// NPot is the negative potential.
+	NPot float64
+
+	// EHS is the effective hand strength.
+	EHS float64
+}
+
+// outcome indexes of the hand potential table
+const (
+	ahead = iota
+	tied
+	behind
+)
+
+// Calculate returns the metrics of the hole cards with a board of zero,
+// three, four, or five cards.  On the river there are no cards to come so
+// the potentials are zero and EHS equals HS raised to the number of
+// opponents.  Before the flop the boards must be sampled.
+func Calculate(hole, board []hand.Card, options ...func(*Config)) (*Result, error) {
+	c := &Config{opponents: 1}
+	for _, option := range options {
+		option(c)
+	}
+	if len(hole) != 2 {
+		return nil, errors.New("strength: hole must have two cards")
+	}
+	if len(board) != 0 && (len(board) < 3 || len(board) > 5) {
+		return nil, errors.New("strength: board must have zero, three, four, or five cards")
+	}
+	if c.opponents < 1 {
+		return nil, errors.New("strength: must have at least one opponent")
+	}
+	if len(board) == 0 && c.samples <= 0 {
+		return nil, errors.New("strength: before the flop there are too many boards to enumerate, so Sampling is needed")
+	}
+	known := join(hole, board)
+	if hasDuplicates(known) {
+		return nil, errors.New("strength: hole and board share cards")
+	}
+	opps := c.opponentRange
+	if opps == nil {
+		opps = combos(remaining(known), 2)
+	}
+	compatible := [][]hand.Card{}
+	for _, opp := range opps {
+		if len(opp) != 2 {
+			return nil, errors.New("strength: opponent combos must have two cards")
+		}
+		if !sharesCards(opp, known) {
+			compatible = append(compatible, opp)
+		}
+	}
+	if len(compatible) == 0 {
+		return nil, errors.New("strength: no opponent combos are possible")
+	}
+
+	toCome := 0
+	switch {
+	case len(board) == 5:
+	case c.toRiver:
+		toCome = 5 - len(board)
+	case len(board) == 0:
+		toCome = 3
+	default:
+		toCome = 1
+	}
+
+	t := &tally{hole: hole, board: board, heroCache: map[uint64]*hand.Hand{}}
+	if c.samples > 0 {
+		if c.r == nil {
+			return nil, errors.New("strength: sampling requires a random source")
+		}
+		for i := 0; i < c.samples; i++ {
+			opp := compatible[c.r.Intn(len(compatible))]
+			cards := remaining(join(known, opp))
+			c.r.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+			t.add(opp, [][]hand.Card{cards[:toCome]})
+		}
+	} else {
+		for _, opp := range compatible {
+			t.add(opp, combos(remaining(join(known, opp)), toCome))
+		}
+	}
+	return t.result(c.opponents), nil
+}
+
+// Bucket returns the bucket from 0 to n-1 that a metric between zero and
+// one falls into when the range is divided into n equal buckets.
+func Bucket(metric float64, n int) int {
+	b := int(metric * float64(n))
+	if b >= n {
+		b = n - 1
+	}
+	if b < 0 {
+		b = 0
+	}
+	return b
+}
+
+// tally is the hand potential table of Billings et al. where hp[i][j]
+// counts runouts in which the hand was in state i and ended in state j.
+type tally struct {
+	hole      []hand.Card
+	board     []hand.Card
+	heroCache map[uint64]*hand.Hand
+	hp        [3][3]float64
+	total     [3]float64
+}
+
+func (t *tally) add(opp []hand.Card, runouts [][]hand.Card) {
+	now := compare(t.hero(nil), hand.New(join(opp, t.board)))
+	for _, runout := range runouts {
+		later := now
+		if len(runout) > 0 {
+			board := join(t.board, runout)
+			later = compare(t.hero(runout), hand.New(join(opp, board)))
+		}
+		t.hp[now][later]++
+		t.total[now]++
+	}
+}
+
+// hero returns the hero's hand with the runout, caching it since it
+// doesn't depend on the opponent's cards.
+func (t *tally) hero(runout []hand.Card) *hand.Hand {
+	key := uint64(0)
+	for _, c := range runout {
+		key |= 1 << uint(c)
+	}
+	if h, ok := t.heroCache[key]; ok {
+		return h
+	}
+	h := hand.New(join(t.hole, join(t.board, runout)))
+	t.heroCache[key] = h
+	return h
+}
+
+func (t *tally) result(opponents int) *Result {
+	all := t.total[ahead] + t.total[tied] + t.total[behind]
+	r := &Result{HS: (t.total[ahead] + t.total[tied]/2) / all}
+	if d := t.total[behind] + t.total[tied]/2; d > 0 {
+		r.PPot = (t.hp[behind][ahead] + t.hp[behind][tied]/2 + t.hp[tied][ahead]/2) / d
+	}
+	if d := t.total[ahead] + t.total[tied]/2; d > 0 {
+		r.NPot = (t.hp[ahead][behind] + t.hp[tied][behind]/2 + t.hp[ahead][tied]/2) / d
+	}
+	hs := math.Pow(r.HS, float64(opponents))
+	r.EHS = hs*(1-r.NPot) + (1-hs)*r.PPot
+	return r
+}
+
+// compare compares hands like hand.CompareTo, but also hands of fewer
+// than five cards, as hole cards are before the flop.
+func compare(h, o *hand.Hand) int {
+	c := int(h.Ranking()) - int(o.Ranking())
+	hc, oc := h.Cards(), o.Cards()
+	for i := 0; c == 0 && i < len(hc) && i < len(oc); i++ {
+		c = int(hc[i].Rank()) - int(oc[i].Rank())
+	}
+	switch {
+	case c > 0:
+		return ahead
+	case c < 0:
+		return behind
+	}
+	return tied
+}
+
+func combos(cards []hand.Card, k int) [][]hand.Card {
+	if k == 0 {
+		return [][]hand.Card{nil}
+	}
+	result := [][]hand.Card{}
+	for _, combo := range util.Combinations(len(cards), k) {
+		cs := make([]hand.Card, k)
+		for i, j := range combo {
+			cs[i] = cards[j]
+		}
+		result = append(result, cs)
+	}
+	return result
+}
+
+func remaining(known []hand.Card) []hand.Card {
+	cards := []hand.Card{}
+	for _, c := range hand.Cards() {
+		if !sharesCards([]hand.Card{c}, known) {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// join returns a new slice with the cards of a followed by the cards of b.
+func join(a, b []hand.Card) []hand.Card {
+	return append(append([]hand.Card{}, a...), b...)
+}
+
+func sharesCards(a, b []hand.Card) bool {
+	for _, c1 := range a {
+		for _, c2 := range b {
+			if c1 == c2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasDuplicates(cards []hand.Card) bool {
+	for i := range cards {
+		if sharesCards(cards[i:i+1], cards[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
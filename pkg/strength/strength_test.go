@@ -0,0 +1,112 @@
+package strength_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/strength"
+)
+
+func TestHandStrength(t *testing.T) {
+	// example from Billings et al. "The challenge of poker"
+	r, err := strength.Calculate(Cards("Ad", "Qc"), Cards("3h", "4c", "Jh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(r.HS-0.585) > 0.001 {
+		t.Fatalf("HS = %v; want %v", r.HS, 0.585)
+	}
+}
+
+func TestPotentialSampling(t *testing.T) {
+	// example from Billings et al. "The challenge of poker"
+	r, err := strength.Calculate(Cards("Ad", "Qc"), Cards("3h", "4c", "Jh"),
+		strength.ToRiver, strength.Sampling(rand.New(rand.NewSource(0)), 10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(r.PPot-0.208) > 0.02 {
+		t.Fatalf("PPot = %v; want about %v", r.PPot, 0.208)
+	}
+	if math.Abs(r.NPot-0.274) > 0.02 {
+		t.Fatalf("NPot = %v; want about %v", r.NPot, 0.274)
+	}
+	ehs := r.HS*(1-r.NPot) + (1-r.HS)*r.PPot
+	if math.Abs(r.EHS-ehs) > 1e-9 {
+		t.Fatalf("EHS = %v; want %v", r.EHS, ehs)
+	}
+}
+
+func TestPotentialAgainstRange(t *testing.T) {
+	// a flush draw against an overpair on the turn
+	opp := [][]hand.Card{Cards("Qs", "Qd")}
+	r, err := strength.Calculate(Cards("Ah", "Kh"), Cards("2h", "7h", "Jc", "3s"), strength.Range(opp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HS != 0 {
+		t.Fatalf("HS = %v; want %v", r.HS, 0)
+	}
+	// 9 hearts, 3 aces, and 3 kings out of 44 cards
+	if math.Abs(r.PPot-15.0/44) > 1e-9 {
+		t.Fatalf("PPot = %v; want %v", r.PPot, 15.0/44)
+	}
+	if r.NPot != 0 {
+		t.Fatalf("NPot = %v; want %v", r.NPot, 0)
+	}
+}
+
+func TestRiver(t *testing.T) {
+	r, err := strength.Calculate(Cards("As", "Ks"), Cards("Qs", "Js", "Ts", "2c", "3d"), strength.Opponents(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HS != 1 || r.EHS != 1 || r.PPot != 0 || r.NPot != 0 {
+		t.Fatalf("royal flush result = %+v", r)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	buckets := map[float64]int{0: 0, 0.09: 0, 0.1: 1, 0.55: 5, 0.99: 9, 1: 9}
+	for v, want := range buckets {
+		if b := strength.Bucket(v, 10); b != want {
+			t.Fatalf("Bucket(%v, 10) = %d; want %d", v, b, want)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	if _, err := strength.Calculate(Cards("As"), nil); err == nil {
+		t.Fatal("expected error for one hole card")
+	}
+	if _, err := strength.Calculate(Cards("As", "Ks"), Cards("Qs")); err == nil {
+		t.Fatal("expected error for one board card")
+	}
+	if _, err := strength.Calculate(Cards("As", "Ks"), Cards("Qs", "Js", "Ts"), strength.Range([][]hand.Card{Cards("As", "Ah")})); err == nil {
+		t.Fatal("expected error for impossible range")
+	}
+	if _, err := strength.Calculate(Cards("As", "Ks"), nil, strength.ToRiver); err == nil {
+		t.Fatal("expected error for enumerating boards before the flop")
+	}
+}
+
+func TestPreflop(t *testing.T) {
+	// aces are ahead of every other hand before the flop
+	r, err := strength.Calculate(Cards("As", "Ad"), nil, strength.ToRiver, strength.Sampling(rand.New(rand.NewSource(0)), 2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HS < 0.99 || r.NPot < 0.1 || r.NPot > 0.25 || r.EHS < 0.8 {
+		t.Fatalf("result = %+v", r)
+	}
+	r, err = strength.Calculate(Cards("7h", "2c"), nil, strength.Sampling(rand.New(rand.NewSource(0)), 2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HS > 0.2 || r.PPot <= 0 {
+		t.Fatalf("result = %+v", r)
+	}
+}
@@ -0,0 +1,111 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate stringer -type=ActionType,Round -output=stringer_autogen.go
+
+// ActionType is the kind of an action.
+type ActionType int
+
+const (
+	// Fold gives up the hand.
+	Fold ActionType = iota + 1
+
+	// Check passes when there is nothing to call.
+	Check
+
+	// Call matches the current bet.
+	Call
+
+	// Bet opens the betting in a round.
+	Bet
+
+	// Raise increases the current bet.
+	Raise
+
+	// PostAnte posts an ante.  Posts are made by the table and only
+	// appear in records.
+	PostAnte
+
+	// PostSmallBlind posts the small blind.
+	PostSmallBlind
+
+	// PostBigBlind posts the big blind.
+	PostBigBlind
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.  Action
+// types are encoded in lower camel case such as "call" or "postBigBlind".
+func (a ActionType) MarshalText() ([]byte, error) {
+	return []byte(lowerFirst(a.String())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *ActionType) UnmarshalText(text []byte) error {
+	for t := Fold; t <= PostBigBlind; t++ {
+		if strings.EqualFold(string(text), t.String()) {
+			*a = t
+			return nil
+		}
+	}
+	return fmt.Errorf("table: unknown action %q", text)
+}
+
+// Action is a player's action.  For bets and raises Amount is the total the
+// player bets in the round, so a raise to 300 has an Amount of 300.  In
+// records Amount is also filled in with the chips put in by calls and
+// posts.
+type Action struct {
+	Type   ActionType `json:"type"`
+	Amount int        `json:"amount,omitempty"`
+}
+
+func (a Action) String() string {
+	if a.Amount == 0 {
+		return lowerFirst(a.Type.String())
+	}
+	return fmt.Sprintf("%s %d", lowerFirst(a.Type.String()), a.Amount)
+}
+
+// Round is a betting round.
+type Round int
+
+const (
+	// Preflop is the round after the hole cards are dealt.
+	Preflop Round = iota + 1
+
+	// Flop is the round after the first three board cards.
+	Flop
+
+	// Turn is the round after the fourth board card.
+	Turn
+
+	// River is the round after the last board card.
+	River
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Round) MarshalText() ([]byte, error) {
+	return []byte(lowerFirst(r.String())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Round) UnmarshalText(text []byte) error {
+	for round := Preflop; round <= River; round++ {
+		if strings.EqualFold(string(text), round.String()) {
+			*r = round
+			return nil
+		}
+	}
+	return fmt.Errorf("table: unknown round %q", text)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
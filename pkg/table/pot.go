@@ -0,0 +1,169 @@
+package table
+
+import (
+	"sort"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// finish returns uncalled bets, awards the pots, and ends the hand.
+func (t *Table) finish() {
+	t.returnUncalled()
+	var in []int
+	for i, p := range t.seats {
+		if p != nil && p.inHand && !p.folded {
+			in = append(in, i)
+		}
+	}
+	won := map[int]int{}
+	if len(in) == 1 {
+		pot := Pot{Seats: in, Winners: in}
+		for _, p := range t.seats {
+			if p != nil {
+				pot.Amount += p.total
+			}
+		}
+		won[in[0]] = pot.Amount
+		t.record.Pots = []Pot{pot}
+	} else {
+		hands := map[int]*hand.Hand{}
+		for _, i := range in {
+			h := hand.New(append(append([]hand.Card{}, t.seats[i].cards...), t.board...))
+			hands[i] = h
+			rs := t.record.seat(i)
+			rs.Shown = true
+			rs.Hand = h
+		}
+		t.record.Pots = t.pots(in)
+		for i := range t.record.Pots {
+			pot := &t.record.Pots[i]
+			pot.Winners = best(pot.Seats, hands)
+			share := pot.Amount / len(pot.Winners)
+			odd := pot.Amount % len(pot.Winners)
+			for _, w := range t.fromButton(pot.Winners) {
+				won[w] += share
+				if odd > 0 {
+					won[w]++
+					odd--
+				}
+			}
+		}
+	}
+	t.record.Board = append([]hand.Card{}, t.board...)
+	for i, p := range t.seats {
+		if p == nil || !p.inHand {
+			continue
+		}
+		p.chips += won[i]
+		rs := t.record.seat(i)
+		rs.Won = won[i]
+		rs.Net = won[i] - p.total
+	}
+	t.active = false
+	t.toAct = -1
+	for i, p := range t.seats {
+		if p != nil && p.leaving {
+			t.seats[i] = nil
+		}
+	}
+}
+
+// returnUncalled gives back the part of the biggest bet nobody matched.
+func (t *Table) returnUncalled() {
+	top, second := -1, 0
+	for i, p := range t.seats {
+		if p == nil || !p.inHand {
+			continue
+		}
+		if top == -1 || p.total > t.seats[top].total {
+			if top != -1 {
+				second = t.seats[top].total
+			}
+			top = i
+		} else if p.total > second {
+			second = p.total
+		}
+	}
+	if p := t.seats[top]; p.total > second {
+		excess := p.total - second
+		p.total -= excess
+		p.bet -= excess
+		p.chips += excess
+	}
+}
+
+// pots splits the chips put in into a main pot and side pots, one for
+// each all in amount of the players still in.
+func (t *Table) pots(in []int) []Pot {
+	var levels []int
+	for _, i := range in {
+		levels = append(levels, t.seats[i].total)
+	}
+	sort.Ints(levels)
+	var pots []Pot
+	prev := 0
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		pot := Pot{}
+		for _, p := range t.seats {
+			if p != nil && p.inHand {
+				pot.Amount += clamp(p.total, prev, level)
+			}
+		}
+		for _, i := range in {
+			if t.seats[i].total >= level {
+				pot.Seats = append(pot.Seats, i)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+	// chips folded above the biggest amount still in go to the last pot
+	for _, p := range t.seats {
+		if p != nil && p.inHand && p.total > prev {
+			pots[len(pots)-1].Amount += p.total - prev
+		}
+	}
+	return pots
+}
+
+// fromButton orders seats starting left of the button, the order odd
+// chips are handed out in.
+func (t *Table) fromButton(seats []int) []int {
+	sorted := append([]int{}, seats...)
+	n := len(t.seats)
+	sort.Slice(sorted, func(a, b int) bool {
+		return (sorted[a]-t.button-1+n)%n < (sorted[b]-t.button-1+n)%n
+	})
+	return sorted
+}
+
+func best(seats []int, hands map[int]*hand.Hand) []int {
+	var winners []int
+	for _, s := range seats {
+		if len(winners) == 0 {
+			winners = []int{s}
+			continue
+		}
+		switch c := hands[s].CompareTo(hands[winners[0]]); {
+		case c > 0:
+			winners = []int{s}
+		case c == 0:
+			winners = append(winners, s)
+		}
+	}
+	return winners
+}
+
+func clamp(total, lo, hi int) int {
+	switch {
+	case total <= lo:
+		return 0
+	case total >= hi:
+		return hi - lo
+	default:
+		return total - lo
+	}
+}
@@ -0,0 +1,69 @@
+package table
+
+import "github.com/notnil/joker/pkg/hand"
+
+// Record is the history of a hand.  It includes every player's hole cards
+// and must not be shown to players.
+type Record struct {
+	ID         int            `json:"id"`
+	Button     int            `json:"button"`
+	SmallBlind int            `json:"smallBlind"`
+	BigBlind   int            `json:"bigBlind"`
+	Ante       int            `json:"ante,omitempty"`
+	Seats      []RecordSeat   `json:"seats"`
+	Actions    []RecordAction `json:"actions"`
+	Board      []hand.Card    `json:"board"`
+	Pots       []Pot          `json:"pots,omitempty"`
+
+	// BigBlindAnte indicates the ante was paid by the big blind alone.
+	BigBlindAnte bool `json:"bigBlindAnte,omitempty"`
+}
+
+// RecordSeat is a player dealt into a hand.  Chips is their stack at the
+// start of the hand, Won is what they collected from the pots, and Net is
+// their profit or loss.  Hand is set for players who showed down.
+type RecordSeat struct {
+	Seat  int         `json:"seat"`
+	Name  string      `json:"name"`
+	Chips int         `json:"chips"`
+	Cards []hand.Card `json:"cards"`
+	Shown bool        `json:"shown,omitempty"`
+	Hand  *hand.Hand  `json:"hand,omitempty"`
+	Won   int         `json:"won"`
+	Net   int         `json:"net"`
+}
+
+// RecordAction is an action taken in a hand.
+type RecordAction struct {
+	Seat  int   `json:"seat"`
+	Round Round `json:"round"`
+	Action
+}
+
+// Pot is a main or side pot.  Seats are the players eligible to win it and
+// Winners are the players who split it.  Uncalled bets are returned before
+// the pots are made.
+type Pot struct {
+	Amount  int   `json:"amount"`
+	Seats   []int `json:"seats"`
+	Winners []int `json:"winners,omitempty"`
+}
+
+// Seat returns the record of the player in the seat.
+func (r *Record) Seat(seat int) (RecordSeat, bool) {
+	for _, s := range r.Seats {
+		if s.Seat == seat {
+			return s, true
+		}
+	}
+	return RecordSeat{}, false
+}
+
+func (r *Record) seat(seat int) *RecordSeat {
+	for i := range r.Seats {
+		if r.Seats[i].Seat == seat {
+			return &r.Seats[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,471 @@
+// Package table runs hands of no-limit Texas hold'em at a table, enforcing
+// the betting rules, building side pots, and settling showdowns.  Each
+// seat gets a view of the table that only shows the cards it may see.
+package table
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// Config represents the configuration options for a table.
+type Config struct {
+	smallBlind   int
+	bigBlind     int
+	ante         int
+	bigBlindAnte bool
+	button       int
+	dealer       hand.Dealer
+}
+
+// Stakes sets the blinds.  The default blinds are 1 and 2.
+func Stakes(small, big int) func(*Config) {
+	return func(c *Config) {
+		c.smallBlind = small
+		c.bigBlind = big
+	}
+}
+
+// Ante sets an ante paid by every player dealt in.
+func Ante(ante int) func(*Config) {
+	return func(c *Config) {
+		c.ante = ante
+		c.bigBlindAnte = false
+	}
+}
+
+// BigBlindAnte sets an ante paid once per hand by the player in the big
+// blind.  A big blind who can't cover both posts the blind first.
+func BigBlindAnte(ante int) func(*Config) {
+	return func(c *Config) {
+		c.ante = ante
+		c.bigBlindAnte = true
+	}
+}
+
+// Button sets the seat the button starts from.  The first hand's button
+// is the first player dealt in at or after the seat.  The default is 0.
+func Button(seat int) func(*Config) {
+	return func(c *Config) {
+		c.button = seat
+	}
+}
+
+// WithDealer sets the dealer that shuffles the decks.  A dealer seeded
+// from the time is used by default.
+func WithDealer(d hand.Dealer) func(*Config) {
+	return func(c *Config) {
+		c.dealer = d
+	}
+}
+
+type player struct {
+	name    string
+	chips   int
+	cards   []hand.Card
+	bet     int
+	total   int
+	inHand  bool
+	folded  bool
+	allIn   bool
+	acted   bool
+	faced   int
+	leaving bool
+}
+
+// Table is a poker table.  A Table isn't safe for concurrent use.
+type Table struct {
+	config     Config
+	seats      []*player
+	button     int
+	hands      int
+	deck       *hand.Deck
+	board      []hand.Card
+	round      Round
+	active     bool
+	toAct      int
+	currentBet int
+	minRaise   int
+	record     *Record
+}
+
+// MaxSeats is the most seats a table can have, since one deck deals two
+// hole cards to at most 23 players and still has a five card board.
+const MaxSeats = 23
+
+// New returns a table with the given number of seats.
+func New(seats int, options ...func(*Config)) (*Table, error) {
+	c := Config{smallBlind: 1, bigBlind: 2}
+	for _, option := range options {
+		option(&c)
+	}
+	if seats < 2 || seats > MaxSeats {
+		return nil, errors.New("table: a table needs two to 23 seats")
+	}
+	if !c.validStakes() {
+		return nil, errors.New("table: invalid stakes")
+	}
+	if c.button < 0 || c.button >= seats {
+		return nil, errors.New("table: invalid seat")
+	}
+	if c.dealer == nil {
+		c.dealer = hand.NewDealer(rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+	return &Table{
+		config: c,
+		seats:  make([]*player, seats),
+		button: c.button - 1,
+		toAct:  -1,
+	}, nil
+}
+
+// Configure changes the table's options between hands, such as raising the
+// blinds when a tournament level ends.  The button option only applies to
+// new tables.
+func (t *Table) Configure(options ...func(*Config)) error {
+	if t.active {
+		return errors.New("table: hand in progress")
+	}
+	c := t.config
+	for _, option := range options {
+		option(&c)
+	}
+	if !c.validStakes() {
+		return errors.New("table: invalid stakes")
+	}
+	if c.dealer == nil {
+		return errors.New("table: no dealer")
+	}
+	t.config = c
+	return nil
+}
+
+func (c Config) validStakes() bool {
+	return c.bigBlind > 0 && c.smallBlind >= 0 && c.smallBlind <= c.bigBlind && c.ante >= 0
+}
+
+// Seats returns the number of seats.
+func (t *Table) Seats() int {
+	return len(t.seats)
+}
+
+// Active returns whether a hand is in progress.
+func (t *Table) Active() bool {
+	return t.active
+}
+
+// ToAct returns the seat to act or -1 if no hand is in progress.
+func (t *Table) ToAct() int {
+	return t.toAct
+}
+
+// Record returns the record of the hand in progress or the last hand
+// played, or nil before the first hand.  The record of a hand in progress
+// changes as the hand is played.
+func (t *Table) Record() *Record {
+	return t.record
+}
+
+// Sit seats a player with a stack of chips.  Players may sit down during a
+// hand and are dealt in from the next one.
+func (t *Table) Sit(seat int, name string, chips int) error {
+	if seat < 0 || seat >= len(t.seats) {
+		return errors.New("table: invalid seat")
+	}
+	if t.seats[seat] != nil {
+		return errors.New("table: seat is taken")
+	}
+	if name == "" || chips < 0 {
+		return errors.New("table: invalid player")
+	}
+	for _, p := range t.seats {
+		if p != nil && p.name == name {
+			return errors.New("table: player is already seated")
+		}
+	}
+	t.seats[seat] = &player{name: name, chips: chips}
+	return nil
+}
+
+// Leave takes a player out of their seat.  A player leaving during a hand
+// folds when it is their turn and their seat is freed when the hand ends.
+func (t *Table) Leave(seat int) error {
+	p, err := t.player(seat)
+	if err != nil {
+		return err
+	}
+	if !t.active || !p.inHand {
+		t.seats[seat] = nil
+		return nil
+	}
+	p.leaving = true
+	if t.toAct == seat {
+		return t.Act(seat, Action{Type: Fold})
+	}
+	return nil
+}
+
+// AddChips adds chips to a player's stack between hands.
+func (t *Table) AddChips(seat, chips int) error {
+	p, err := t.player(seat)
+	if err != nil {
+		return err
+	}
+	if t.active && p.inHand {
+		return errors.New("table: can't add chips during a hand")
+	}
+	if chips < 0 {
+		return errors.New("table: negative chips")
+	}
+	p.chips += chips
+	return nil
+}
+
+// Deal starts a new hand.  The button moves to the next player with chips
+// and the blinds, antes, and hole cards are dealt.
+func (t *Table) Deal() error {
+	if t.active {
+		return errors.New("table: hand in progress")
+	}
+	var dealt []int
+	for i, p := range t.seats {
+		if p != nil && p.chips > 0 {
+			dealt = append(dealt, i)
+		}
+	}
+	if len(dealt) < 2 {
+		return errors.New("table: not enough players")
+	}
+	for _, p := range t.seats {
+		if p != nil {
+			*p = player{name: p.name, chips: p.chips}
+		}
+	}
+	for _, i := range dealt {
+		t.seats[i].inHand = true
+	}
+	t.button = t.nextInHand(t.button)
+	sb, bb := t.nextInHand(t.button), 0
+	if len(dealt) == 2 {
+		sb = t.button
+	}
+	bb = t.nextInHand(sb)
+	t.hands++
+	t.active = true
+	t.deck = t.config.dealer.Deck()
+	t.board = nil
+	t.round = Preflop
+	t.currentBet = t.config.bigBlind
+	t.minRaise = t.config.bigBlind
+	t.record = &Record{
+		ID:           t.hands,
+		Button:       t.button,
+		SmallBlind:   t.config.smallBlind,
+		BigBlind:     t.config.bigBlind,
+		Ante:         t.config.ante,
+		BigBlindAnte: t.config.bigBlindAnte,
+	}
+	for _, i := range dealt {
+		p := t.seats[i]
+		p.cards = t.deck.PopMulti(2)
+		t.record.Seats = append(t.record.Seats, RecordSeat{
+			Seat:  i,
+			Name:  p.name,
+			Chips: p.chips,
+			Cards: p.cards,
+		})
+	}
+	if t.config.ante > 0 && !t.config.bigBlindAnte {
+		for _, i := range dealt {
+			t.post(i, PostAnte, t.config.ante, false)
+		}
+	}
+	t.post(sb, PostSmallBlind, t.config.smallBlind, true)
+	t.post(bb, PostBigBlind, t.config.bigBlind, true)
+	if t.config.ante > 0 && t.config.bigBlindAnte && t.seats[bb].chips > 0 {
+		t.post(bb, PostAnte, t.config.ante, false)
+	}
+	t.toAct = bb
+	t.next()
+	return nil
+}
+
+// Act takes an action for the player to act.
+func (t *Table) Act(seat int, a Action) error {
+	if !t.active {
+		return errors.New("table: no hand in progress")
+	}
+	if seat != t.toAct {
+		return errors.New("table: not your turn")
+	}
+	p := t.seats[seat]
+	opts := t.options(p)
+	toCall := t.currentBet - p.bet
+	recorded := Action{Type: a.Type, Amount: a.Amount}
+	switch a.Type {
+	case Fold:
+		p.folded = true
+		recorded.Amount = 0
+	case Check:
+		if toCall > 0 {
+			return errors.New("table: can't check facing a bet")
+		}
+		recorded.Amount = 0
+	case Call:
+		if toCall == 0 {
+			return errors.New("table: nothing to call")
+		}
+		recorded.Amount = t.put(p, toCall)
+	case Bet, Raise:
+		if !opts.has(a.Type) {
+			return errors.New("table: can't " + lowerFirst(a.Type.String()))
+		}
+		if a.Amount < opts.MinRaise || a.Amount > opts.MaxRaise {
+			return errors.New("table: invalid amount")
+		}
+		if inc := a.Amount - t.currentBet; inc >= t.minRaise {
+			t.minRaise = inc
+		}
+		t.currentBet = a.Amount
+		t.put(p, a.Amount-p.bet)
+	default:
+		return errors.New("table: invalid action")
+	}
+	p.acted = true
+	p.faced = t.currentBet
+	t.record.Actions = append(t.record.Actions, RecordAction{Seat: seat, Round: t.round, Action: recorded})
+	t.next()
+	return nil
+}
+
+// post puts in a forced bet.  Antes are dead money and don't count
+// towards the bet to call.
+func (t *Table) post(seat int, typ ActionType, amount int, live bool) {
+	p := t.seats[seat]
+	if amount > p.chips {
+		amount = p.chips
+	}
+	if live {
+		t.put(p, amount)
+	} else {
+		p.chips -= amount
+		p.total += amount
+		p.allIn = p.chips == 0
+	}
+	t.record.Actions = append(t.record.Actions, RecordAction{
+		Seat:   seat,
+		Round:  Preflop,
+		Action: Action{Type: typ, Amount: amount},
+	})
+}
+
+// put moves chips from a player's stack into their bet, limited to their
+// stack, and returns the amount moved.
+func (t *Table) put(p *player, amount int) int {
+	if amount >= p.chips {
+		amount = p.chips
+		p.allIn = true
+	}
+	p.chips -= amount
+	p.bet += amount
+	p.total += amount
+	return amount
+}
+
+// next moves on to the next player to act, dealing the next streets and
+// finishing the hand as needed.
+func (t *Table) next() {
+	for {
+		if t.remaining() == 1 {
+			t.finish()
+			return
+		}
+		if seat := t.nextToAct(); seat != -1 {
+			t.toAct = seat
+			if t.seats[seat].leaving {
+				t.Act(seat, Action{Type: Fold})
+			}
+			return
+		}
+		if t.round == River {
+			t.finish()
+			return
+		}
+		t.nextRound()
+	}
+}
+
+func (t *Table) nextRound() {
+	for _, p := range t.seats {
+		if p != nil {
+			p.bet = 0
+			p.acted = false
+			p.faced = 0
+		}
+	}
+	t.currentBet = 0
+	t.minRaise = t.config.bigBlind
+	t.round++
+	n := 1
+	if t.round == Flop {
+		n = 3
+	}
+	t.board = append(t.board, t.deck.PopMulti(n)...)
+	t.record.Board = append([]hand.Card{}, t.board...)
+	t.toAct = t.button
+}
+
+// nextToAct returns the next seat after the one to act that must act, or
+// -1 if the round is over.  A player must act if they haven't acted this
+// round or face a bet, unless nobody is left to bet against them.
+func (t *Table) nextToAct() int {
+	canAct := 0
+	for _, p := range t.seats {
+		if p != nil && p.inHand && !p.folded && !p.allIn {
+			canAct++
+		}
+	}
+	for k := 1; k <= len(t.seats); k++ {
+		seat := (t.toAct + k) % len(t.seats)
+		p := t.seats[seat]
+		if p == nil || !p.inHand || p.folded || p.allIn {
+			continue
+		}
+		if p.bet < t.currentBet || (!p.acted && canAct > 1) {
+			return seat
+		}
+	}
+	return -1
+}
+
+// remaining returns the number of players who haven't folded.
+func (t *Table) remaining() int {
+	n := 0
+	for _, p := range t.seats {
+		if p != nil && p.inHand && !p.folded {
+			n++
+		}
+	}
+	return n
+}
+
+// nextInHand returns the next seat after seat dealt into the hand.
+func (t *Table) nextInHand(seat int) int {
+	for k := 1; k <= len(t.seats); k++ {
+		i := (seat + k) % len(t.seats)
+		if p := t.seats[i]; p != nil && p.inHand {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *Table) player(seat int) (*player, error) {
+	if seat < 0 || seat >= len(t.seats) || t.seats[seat] == nil {
+		return nil, errors.New("table: seat is empty")
+	}
+	return t.seats[seat], nil
+}
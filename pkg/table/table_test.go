@@ -0,0 +1,322 @@
+package table_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/table"
+)
+
+// stacked deals its cards in order, two hole cards to each player from
+// the lowest seat up and then the board, followed by the rest of the deck.
+type stacked []hand.Card
+
+func (s stacked) Deck() *hand.Deck {
+	used := map[hand.Card]bool{}
+	for _, c := range s {
+		used[c] = true
+	}
+	var cards []hand.Card
+	for _, c := range hand.Cards() {
+		if !used[c] {
+			cards = append(cards, c)
+		}
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		cards = append(cards, s[i])
+	}
+	return &hand.Deck{Cards: cards}
+}
+
+func newTable(t *testing.T, cards []hand.Card, stacks ...int) *table.Table {
+	tbl, err := table.New(len(stacks), table.Stakes(1, 2), table.WithDealer(stacked(cards)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, chips := range stacks {
+		if chips > 0 {
+			if err := tbl.Sit(i, fmt.Sprint("p", i), chips); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	return tbl
+}
+
+func act(t *testing.T, tbl *table.Table, actions ...string) {
+	for _, s := range actions {
+		var a table.Action
+		parts := strings.Fields(s)
+		if err := a.Type.UnmarshalText([]byte(parts[0])); err != nil {
+			t.Fatal(err)
+		}
+		if len(parts) > 1 {
+			fmt.Sscan(parts[1], &a.Amount)
+		}
+		if err := tbl.Act(tbl.ToAct(), a); err != nil {
+			t.Fatalf("%s by seat %d: %v", s, tbl.ToAct(), err)
+		}
+	}
+}
+
+func chips(tbl *table.Table) []int {
+	var c []int
+	for _, p := range tbl.View(-1).Players {
+		c = append(c, p.Chips)
+	}
+	return c
+}
+
+func TestHeadsUpBlinds(t *testing.T) {
+	tbl := newTable(t, Cards("As", "Ad", "Ks", "Kd"), 100, 100)
+	v := tbl.View(0)
+	// heads up the button posts the small blind and acts first
+	if v.Button != 0 || tbl.ToAct() != 0 || v.Pot != 3 || v.Players[0].Bet != 1 || v.Players[1].Bet != 2 {
+		t.Fatalf("unexpected start %+v", v)
+	}
+	if v.Options == nil || v.Options.ToCall != 1 || v.Options.MinRaise != 4 || v.Options.MaxRaise != 100 {
+		t.Fatalf("options = %+v", v.Options)
+	}
+	act(t, tbl, "fold")
+	if tbl.Active() {
+		t.Fatal("expected hand to end")
+	}
+	if got := fmt.Sprint(chips(tbl)); got != "[99 101]" {
+		t.Fatalf("chips = %s", got)
+	}
+	r := tbl.Record()
+	if s, _ := r.Seat(1); s.Net != 1 || s.Shown {
+		t.Fatalf("winner record = %+v", s)
+	}
+	// the button moves
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if v := tbl.View(-1); v.Button != 1 || tbl.ToAct() != 1 {
+		t.Fatalf("button = %d to act = %d; want 1", v.Button, tbl.ToAct())
+	}
+}
+
+func TestButton(t *testing.T) {
+	tbl, _ := table.New(4, table.Button(2))
+	tbl.Sit(0, "a", 100)
+	tbl.Sit(1, "b", 100)
+	tbl.Sit(3, "c", 100)
+	// the empty seat is skipped
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if v := tbl.View(-1); v.Button != 3 {
+		t.Fatalf("button = %d; want 3", v.Button)
+	}
+	if _, err := table.New(4, table.Button(4)); err == nil {
+		t.Fatal("expected error for a button outside the table")
+	}
+	for _, seats := range []int{1, table.MaxSeats + 1, 1 << 36} {
+		if _, err := table.New(seats); err == nil {
+			t.Fatalf("expected error for a table with %d seats", seats)
+		}
+	}
+}
+
+func TestBigBlindAnte(t *testing.T) {
+	tbl, _ := table.New(3, table.Stakes(1, 2), table.BigBlindAnte(2))
+	tbl.Sit(0, "a", 100)
+	tbl.Sit(1, "b", 100)
+	tbl.Sit(2, "c", 3)
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	// the short big blind posts the blind before the ante
+	var posts []string
+	for _, a := range tbl.Record().Actions {
+		posts = append(posts, fmt.Sprint(a.Seat, " ", a.Type, " ", a.Amount))
+	}
+	if got := fmt.Sprint(posts); got != "[1 PostSmallBlind 1 2 PostBigBlind 2 2 PostAnte 1]" {
+		t.Fatalf("posts = %s", got)
+	}
+	if !tbl.Record().BigBlindAnte || tbl.View(-1).Pot != 4 {
+		t.Fatalf("record = %+v pot = %d", tbl.Record(), tbl.View(-1).Pot)
+	}
+	if err := tbl.Configure(table.Ante(1)); err == nil {
+		t.Fatal("expected error configuring during a hand")
+	}
+	act(t, tbl, "fold", "fold")
+
+	// between hands the level goes up and every player pays the ante
+	if err := tbl.Configure(table.Stakes(2, 4), table.Ante(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.Configure(table.Stakes(4, 2)); err == nil {
+		t.Fatal("expected error for invalid stakes")
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	antes := 0
+	for _, a := range tbl.Record().Actions {
+		if a.Type == table.PostAnte {
+			antes++
+		}
+	}
+	if r := tbl.Record(); antes != 3 || r.BigBlind != 4 || r.BigBlindAnte {
+		t.Fatalf("antes = %d record = %+v", antes, r)
+	}
+}
+
+func TestCheckDown(t *testing.T) {
+	cards := Cards("As", "Ad", "Ks", "Kd", "7c", "2h", "3d", "4c", "9s", "Jh", "Qh")
+	tbl := newTable(t, cards, 100, 100, 100)
+	// button 0, small blind 1, big blind 2
+	if tbl.ToAct() != 0 {
+		t.Fatalf("to act = %d; want 0", tbl.ToAct())
+	}
+	act(t, tbl, "call", "call", "check")
+	v := tbl.View(1)
+	if v.Round != table.Flop || len(v.Board) != 3 || tbl.ToAct() != 1 {
+		t.Fatalf("round %v board %v to act %d", v.Round, v.Board, tbl.ToAct())
+	}
+	if got := tbl.Record().Board; fmt.Sprint(got) != fmt.Sprint(v.Board) {
+		t.Fatalf("record board = %v; want %v", got, v.Board)
+	}
+	for i, p := range v.Players {
+		if (i == 1) != (p.Cards != nil) {
+			t.Fatalf("seat 1 sees cards %v of seat %d", p.Cards, i)
+		}
+	}
+	if err := tbl.Act(0, table.Action{Type: table.Check}); err == nil {
+		t.Fatal("expected error acting out of turn")
+	}
+	act(t, tbl, "check", "check", "check")
+	act(t, tbl, "check", "check", "check")
+	act(t, tbl, "check", "check", "check")
+	if tbl.Active() {
+		t.Fatal("expected showdown")
+	}
+	if got := fmt.Sprint(chips(tbl)); got != "[104 98 98]" {
+		t.Fatalf("chips = %s", got)
+	}
+	v = tbl.View(-1)
+	for i, p := range v.Players {
+		if len(p.Cards) != 2 || p.Hand == nil {
+			t.Fatalf("seat %d wasn't shown at showdown", i)
+		}
+	}
+	if len(v.Pots) != 1 || fmt.Sprint(v.Pots[0].Winners) != "[0]" {
+		t.Fatalf("pots = %+v", v.Pots)
+	}
+}
+
+func TestSidePots(t *testing.T) {
+	// seat 0 has the best hand but is all in for the least, seat 1 beats
+	// seat 2 for the side pot
+	cards := Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd", "7c", "2h", "3d", "9s", "Jh")
+	tbl := newTable(t, cards, 50, 100, 200)
+	act(t, tbl, "raise 50", "raise 100", "call")
+	if tbl.Active() {
+		t.Fatal("expected the board to run out")
+	}
+	r := tbl.Record()
+	if len(r.Board) != 5 {
+		t.Fatalf("board = %v", r.Board)
+	}
+	want := []table.Pot{
+		{Amount: 150, Seats: []int{0, 1, 2}, Winners: []int{0}},
+		{Amount: 100, Seats: []int{1, 2}, Winners: []int{1}},
+	}
+	if fmt.Sprint(r.Pots) != fmt.Sprint(want) {
+		t.Fatalf("pots = %+v; want %+v", r.Pots, want)
+	}
+	// the uncalled part of seat 2's call is returned
+	if got := fmt.Sprint(chips(tbl)); got != "[150 100 100]" {
+		t.Fatalf("chips = %s", got)
+	}
+	if err := tbl.Deal(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitPot(t *testing.T) {
+	cards := Cards("As", "2d", "Ad", "3s", "Kc", "Kh", "Qd", "Qc", "Jh")
+	tbl, _ := table.New(3, table.Stakes(1, 2), table.WithDealer(stacked(cards)))
+	tbl.Sit(0, "a", 100)
+	tbl.Sit(1, "b", 100)
+	tbl.Deal()
+	act(t, tbl, "raise 5", "call", "bet 2", "call", "check", "check", "check", "check")
+	r := tbl.Record()
+	if len(r.Pots[0].Winners) != 2 {
+		t.Fatalf("pots = %+v", r.Pots)
+	}
+	act2 := fmt.Sprint(chips(tbl))
+	if act2 != "[100 100 0]" {
+		t.Fatalf("chips = %s", act2)
+	}
+}
+
+func TestRaises(t *testing.T) {
+	cards := Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd")
+	tbl := newTable(t, cards, 100, 100, 9)
+	if err := tbl.Act(0, table.Action{Type: table.Raise, Amount: 3}); err == nil {
+		t.Fatal("expected error for a raise below the minimum")
+	}
+	if err := tbl.Act(0, table.Action{Type: table.Bet, Amount: 6}); err == nil {
+		t.Fatal("expected error betting facing the blinds")
+	}
+	if err := tbl.Act(0, table.Action{Type: table.Check}); err == nil {
+		t.Fatal("expected error checking facing the blinds")
+	}
+	// seat 1 raises to 6, seat 2 goes all in for 9, which isn't a full
+	// raise, so seat 0 may raise but seat 1 may only call or fold
+	act(t, tbl, "call", "raise 6", "raise 9")
+	if o := tbl.View(0).Options; o.MinRaise != 13 || o.ToCall != 7 {
+		t.Fatalf("seat 0 options = %+v", o)
+	}
+	act(t, tbl, "call")
+	o := tbl.View(1).Options
+	if fmt.Sprint(o.Actions) != "[Fold Call]" || o.ToCall != 3 {
+		t.Fatalf("seat 1 options = %+v", o)
+	}
+	act(t, tbl, "call")
+	if v := tbl.View(-1); v.Round != table.Flop || v.Pot != 27 {
+		t.Fatalf("round %v pot %d", v.Round, v.Pot)
+	}
+}
+
+func TestLeave(t *testing.T) {
+	tbl := newTable(t, Cards("As", "Ad", "Ks", "Kd", "Qs", "Qd"), 100, 100, 100)
+	if err := tbl.Leave(2); err != nil {
+		t.Fatal(err)
+	}
+	// the big blind folds when their turn comes
+	act(t, tbl, "call", "call")
+	if v := tbl.View(-1); !v.Active || v.Round != table.Flop || !v.Players[2].Folded {
+		t.Fatal("expected the hand to continue without seat 2")
+	}
+	act(t, tbl, "bet 2", "fold")
+	if v := tbl.View(-1); v.Players[2].Name != "" {
+		t.Fatal("expected seat 2 to be empty after the hand")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	a := table.Action{Type: table.PostBigBlind, Amount: 2}
+	b, err := json.Marshal(table.RecordAction{Seat: 1, Round: table.Preflop, Action: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"seat":1,"round":"preflop","type":"postBigBlind","amount":2}`
+	if string(b) != want {
+		t.Fatalf("json = %s; want %s", b, want)
+	}
+	var ra table.RecordAction
+	if err := json.Unmarshal(b, &ra); err != nil || ra.Action != a || ra.Round != table.Preflop {
+		t.Fatalf("unmarshaled %+v %v", ra, err)
+	}
+}
@@ -0,0 +1,139 @@
+package table
+
+import "github.com/notnil/joker/pkg/hand"
+
+// View is what a seat can see of the table.  Hole cards are only shown to
+// the player holding them and, after a showdown, to everyone.  Seat is -1
+// for observers.  The options are only set for the player to act.
+type View struct {
+	Seat       int          `json:"seat"`
+	Hand       int          `json:"hand"`
+	Active     bool         `json:"active"`
+	Round      Round        `json:"round,omitempty"`
+	Button     int          `json:"button"`
+	SmallBlind int          `json:"smallBlind"`
+	BigBlind   int          `json:"bigBlind"`
+	Ante       int          `json:"ante,omitempty"`
+	Board      []hand.Card  `json:"board"`
+	Pot        int          `json:"pot"`
+	ToAct      int          `json:"toAct"`
+	Players    []PlayerView `json:"players"`
+	Options    *Options     `json:"options,omitempty"`
+	Pots       []Pot        `json:"pots,omitempty"`
+}
+
+// PlayerView is what can be seen of a seat.  Empty seats have no name.
+type PlayerView struct {
+	Name   string      `json:"name,omitempty"`
+	Chips  int         `json:"chips"`
+	Bet    int         `json:"bet"`
+	InHand bool        `json:"inHand,omitempty"`
+	Folded bool        `json:"folded,omitempty"`
+	AllIn  bool        `json:"allIn,omitempty"`
+	Cards  []hand.Card `json:"cards,omitempty"`
+	Hand   *hand.Hand  `json:"hand,omitempty"`
+	Won    int         `json:"won,omitempty"`
+}
+
+// Options are the actions open to the player to act.  ToCall is the
+// amount needed to call and MinRaise and MaxRaise bound the amount of a
+// bet or raise.
+type Options struct {
+	Actions  []ActionType `json:"actions"`
+	ToCall   int          `json:"toCall"`
+	MinRaise int          `json:"minRaise,omitempty"`
+	MaxRaise int          `json:"maxRaise,omitempty"`
+}
+
+func (o Options) has(a ActionType) bool {
+	for _, t := range o.Actions {
+		if t == a {
+			return true
+		}
+	}
+	return false
+}
+
+// View returns the table as seen from a seat.  Use -1 for an observer.
+func (t *Table) View(seat int) View {
+	v := View{
+		Seat:       seat,
+		Hand:       t.hands,
+		Active:     t.active,
+		Button:     t.button,
+		SmallBlind: t.config.smallBlind,
+		BigBlind:   t.config.bigBlind,
+		Ante:       t.config.ante,
+		Board:      append([]hand.Card{}, t.board...),
+		ToAct:      t.toAct,
+		Players:    make([]PlayerView, len(t.seats)),
+	}
+	if t.active {
+		v.Round = t.round
+	} else if t.record != nil {
+		v.Pots = t.record.Pots
+	}
+	for i, p := range t.seats {
+		if p == nil {
+			continue
+		}
+		v.Pot += p.total
+		pv := PlayerView{
+			Name:   p.name,
+			Chips:  p.chips,
+			Bet:    p.bet,
+			InHand: p.inHand,
+			Folded: p.folded,
+			AllIn:  p.allIn,
+		}
+		if t.record != nil {
+			if rs, ok := t.record.Seat(i); ok && p.inHand {
+				if i == seat || rs.Shown {
+					pv.Cards = rs.Cards
+					pv.Hand = rs.Hand
+				}
+				pv.Won = rs.Won
+			}
+		}
+		v.Players[i] = pv
+	}
+	if !t.active {
+		v.Pot = 0
+	}
+	if t.active && seat == t.toAct {
+		o := t.options(t.seats[seat])
+		v.Options = &o
+	}
+	return v
+}
+
+// options returns the actions open to a player.
+func (t *Table) options(p *player) Options {
+	o := Options{Actions: []ActionType{Fold}, ToCall: t.currentBet - p.bet}
+	if o.ToCall > p.chips {
+		o.ToCall = p.chips
+	}
+	if o.ToCall == 0 {
+		o.Actions = append(o.Actions, Check)
+	} else {
+		o.Actions = append(o.Actions, Call)
+	}
+	// a player may raise if they haven't acted or a full raise has been
+	// made since they did
+	max := p.bet + p.chips
+	reopened := !p.acted || t.currentBet-p.faced >= t.minRaise
+	if max <= t.currentBet || !reopened {
+		return o
+	}
+	if t.currentBet == 0 {
+		o.Actions = append(o.Actions, Bet)
+	} else {
+		o.Actions = append(o.Actions, Raise)
+	}
+	o.MinRaise = t.currentBet + t.minRaise
+	if o.MinRaise > max {
+		o.MinRaise = max
+	}
+	o.MaxRaise = max
+	return o
+}
@@ -0,0 +1,311 @@
+package tournament
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Drawer draws seats at random.  It plays the part hand.Dealer plays for
+// decks so that seat draws can be reproduced.
+type Drawer interface {
+	// Draw returns a random number in [0,n).
+	Draw(n int) int
+}
+
+// NewDrawer returns a drawer that draws with r.
+func NewDrawer(r *rand.Rand) Drawer {
+	return drawer{r: r}
+}
+
+type drawer struct {
+	r *rand.Rand
+}
+
+func (d drawer) Draw(n int) int {
+	return d.r.Intn(n)
+}
+
+// Seat is a seat at a table.
+type Seat struct {
+	Table int `json:"table"`
+	Seat  int `json:"seat"`
+}
+
+// Move is an instruction to move a player from one seat to another.
+// Broken is set when the player's table was broken.
+type Move struct {
+	Player string `json:"player"`
+	From   Seat   `json:"from"`
+	To     Seat   `json:"to"`
+	Broken bool   `json:"broken,omitempty"`
+}
+
+// Table is the seating at a table.  Empty seats hold the empty string.
+type Table struct {
+	ID     int      `json:"id"`
+	Seats  []string `json:"seats"`
+	Button int      `json:"button"`
+}
+
+// Players returns the number of seated players.
+func (t Table) Players() int {
+	n := 0
+	for _, p := range t.Seats {
+		if p != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// NextBigBlind returns the seat of the player who will be in the big
+// blind next hand once the button moves.
+func (t Table) NextBigBlind() int {
+	n := t.Players()
+	if n == 0 {
+		return -1
+	}
+	// heads up the button posts the small blind, so the current button
+	// is next hand's big blind
+	if n == 2 && t.Seats[t.Button] != "" {
+		return t.Button
+	}
+	skip := 3
+	seat := t.Button
+	for skip > 0 {
+		seat = (seat + 1) % len(t.Seats)
+		if t.Seats[seat] != "" {
+			skip--
+		}
+	}
+	return seat
+}
+
+// Floor seats a tournament's players and keeps its tables balanced.
+// Tables are broken from the highest numbered down and players are
+// balanced so that table sizes differ by at most one, following the
+// usual tournament rules.
+type Floor struct {
+	size    int
+	tables  []*Table
+	drawer  Drawer
+	history []Move
+}
+
+// Draw seats players at random at as few tables of the given size as
+// will hold them, with table sizes within one of each other and a button
+// drawn at each table.
+func Draw(players []string, size int, d Drawer) (*Floor, error) {
+	if size < 2 {
+		return nil, errors.New("tournament: tables must seat at least two")
+	}
+	seen := map[string]bool{}
+	for _, p := range players {
+		if p == "" || seen[p] {
+			return nil, errors.New("tournament: invalid or duplicate player")
+		}
+		seen[p] = true
+	}
+	f := &Floor{size: size, drawer: d}
+	n := (len(players) + size - 1) / size
+	for i := 0; i < n; i++ {
+		f.tables = append(f.tables, &Table{ID: i + 1, Seats: make([]string, size)})
+	}
+	order := append([]string{}, players...)
+	for i := len(order) - 1; i > 0; i-- {
+		j := d.Draw(i + 1)
+		order[i], order[j] = order[j], order[i]
+	}
+	for i, p := range order {
+		t := f.tables[i%n]
+		t.Seats[f.emptySeat(t)] = p
+	}
+	for _, t := range f.tables {
+		t.Button = f.occupiedSeat(t)
+	}
+	return f, nil
+}
+
+// Tables returns a copy of the tables in play ordered by ID.
+func (f *Floor) Tables() []Table {
+	tables := make([]Table, len(f.tables))
+	for i, t := range f.tables {
+		tables[i] = Table{ID: t.ID, Seats: append([]string{}, t.Seats...), Button: t.Button}
+	}
+	return tables
+}
+
+// Seat returns the seat of the player.
+func (f *Floor) Seat(player string) (Seat, bool) {
+	for _, t := range f.tables {
+		for i, p := range t.Seats {
+			if p == player {
+				return Seat{Table: t.ID, Seat: i}, true
+			}
+		}
+	}
+	return Seat{}, false
+}
+
+// History returns every move made so far.
+func (f *Floor) History() []Move {
+	return append([]Move{}, f.history...)
+}
+
+// Add seats a late registrant in a random empty seat at the table with
+// the fewest players, opening a new table if every table is full.
+func (f *Floor) Add(player string) (Seat, error) {
+	if _, ok := f.Seat(player); ok || player == "" {
+		return Seat{}, errors.New("tournament: invalid or duplicate player")
+	}
+	t := f.smallest()
+	if t == nil || t.Players() == f.size {
+		id := 1
+		if len(f.tables) > 0 {
+			id = f.tables[len(f.tables)-1].ID + 1
+		}
+		t = &Table{ID: id, Seats: make([]string, f.size)}
+		f.tables = append(f.tables, t)
+	}
+	i := f.emptySeat(t)
+	t.Seats[i] = player
+	if t.Players() == 1 {
+		t.Button = i
+	}
+	return Seat{Table: t.ID, Seat: i}, nil
+}
+
+// Remove takes a busted player's seat away.  The button stays where it is
+// and moves on to the next player after the hand.
+func (f *Floor) Remove(player string) error {
+	s, ok := f.Seat(player)
+	if !ok {
+		return errors.New("tournament: player isn't seated")
+	}
+	f.table(s.Table).Seats[s.Seat] = ""
+	return nil
+}
+
+// MoveButton moves a table's button to the next player, which tables do
+// after every hand.
+func (f *Floor) MoveButton(table int) error {
+	t := f.table(table)
+	if t == nil {
+		return errors.New("tournament: unknown table")
+	}
+	if t.Players() == 0 {
+		return nil
+	}
+	for {
+		t.Button = (t.Button + 1) % len(t.Seats)
+		if t.Seats[t.Button] != "" {
+			return nil
+		}
+	}
+}
+
+// Balance breaks tables that are no longer needed and moves players until
+// table sizes are within one of each other.  Players from broken tables
+// are drawn into random seats at the smallest tables and balancing moves
+// the player who would be big blind next at the biggest table.  The
+// moves made are returned and added to the history.  Balance should be
+// called between hands.
+func (f *Floor) Balance() []Move {
+	var moves []Move
+	for len(f.tables) > 1 && f.players() <= (len(f.tables)-1)*f.size {
+		broken := f.tables[len(f.tables)-1]
+		f.tables = f.tables[:len(f.tables)-1]
+		// players leave starting from the next big blind so the order
+		// doesn't favour any seat
+		from := broken.NextBigBlind()
+		for k := 0; k < len(broken.Seats); k++ {
+			i := (from + k) % len(broken.Seats)
+			if p := broken.Seats[i]; p != "" {
+				moves = append(moves, f.move(p, Seat{Table: broken.ID, Seat: i}, f.smallest(), true))
+			}
+		}
+	}
+	for {
+		big, small := f.biggest(), f.smallest()
+		if big == nil || big.Players()-small.Players() <= 1 {
+			break
+		}
+		i := big.NextBigBlind()
+		p := big.Seats[i]
+		big.Seats[i] = ""
+		moves = append(moves, f.move(p, Seat{Table: big.ID, Seat: i}, small, false))
+	}
+	f.history = append(f.history, moves...)
+	return moves
+}
+
+func (f *Floor) move(p string, from Seat, t *Table, broken bool) Move {
+	i := f.emptySeat(t)
+	t.Seats[i] = p
+	return Move{Player: p, From: from, To: Seat{Table: t.ID, Seat: i}, Broken: broken}
+}
+
+// smallest returns the table with the fewest players, drawing between
+// ties.
+func (f *Floor) smallest() *Table {
+	var ties []*Table
+	for _, t := range f.tables {
+		if len(ties) == 0 || t.Players() < ties[0].Players() {
+			ties = []*Table{t}
+		} else if t.Players() == ties[0].Players() {
+			ties = append(ties, t)
+		}
+	}
+	if len(ties) == 0 {
+		return nil
+	}
+	return ties[f.drawer.Draw(len(ties))]
+}
+
+// biggest returns the lowest numbered table with the most players.
+func (f *Floor) biggest() *Table {
+	var big *Table
+	for _, t := range f.tables {
+		if big == nil || t.Players() > big.Players() {
+			big = t
+		}
+	}
+	return big
+}
+
+func (f *Floor) emptySeat(t *Table) int {
+	var empty []int
+	for i, p := range t.Seats {
+		if p == "" {
+			empty = append(empty, i)
+		}
+	}
+	return empty[f.drawer.Draw(len(empty))]
+}
+
+func (f *Floor) occupiedSeat(t *Table) int {
+	var seats []int
+	for i, p := range t.Seats {
+		if p != "" {
+			seats = append(seats, i)
+		}
+	}
+	return seats[f.drawer.Draw(len(seats))]
+}
+
+func (f *Floor) players() int {
+	n := 0
+	for _, t := range f.tables {
+		n += t.Players()
+	}
+	return n
+}
+
+func (f *Floor) table(id int) *Table {
+	i := sort.Search(len(f.tables), func(i int) bool { return f.tables[i].ID >= id })
+	if i < len(f.tables) && f.tables[i].ID == id {
+		return f.tables[i]
+	}
+	return nil
+}
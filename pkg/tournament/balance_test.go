@@ -0,0 +1,168 @@
+package tournament_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/tournament"
+)
+
+func players(n int) []string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = fmt.Sprint("p", i)
+	}
+	return p
+}
+
+func drawer(seed int64) tournament.Drawer {
+	return tournament.NewDrawer(rand.New(rand.NewSource(seed)))
+}
+
+func sizes(f *tournament.Floor) []int {
+	var s []int
+	for _, t := range f.Tables() {
+		s = append(s, t.Players())
+	}
+	return s
+}
+
+func TestDraw(t *testing.T) {
+	f, err := tournament.Draw(players(20), 9, drawer(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(sizes(f)); got != "[7 7 6]" {
+		t.Fatalf("sizes = %s; want [7 7 6]", got)
+	}
+	for _, p := range players(20) {
+		if _, ok := f.Seat(p); !ok {
+			t.Fatalf("%s isn't seated", p)
+		}
+	}
+	for _, table := range f.Tables() {
+		if table.Seats[table.Button] == "" {
+			t.Fatalf("table %d has the button on an empty seat", table.ID)
+		}
+	}
+	again, _ := tournament.Draw(players(20), 9, drawer(1))
+	if fmt.Sprint(f.Tables()) != fmt.Sprint(again.Tables()) {
+		t.Fatal("draw isn't reproducible")
+	}
+	if _, err := tournament.Draw([]string{"a", "a"}, 9, drawer(1)); err == nil {
+		t.Fatal("expected error for duplicate players")
+	}
+}
+
+func TestNextBigBlind(t *testing.T) {
+	tests := []struct {
+		table tournament.Table
+		seat  int
+	}{
+		{tournament.Table{Seats: []string{"a", "", "b", "", "c", "d"}, Button: 0}, 5},
+		{tournament.Table{Seats: []string{"a", "", "b", "", "c", "d"}, Button: 4}, 2},
+		{tournament.Table{Seats: []string{"a", "", "b", "", "", "d"}, Button: 3}, 2},
+		{tournament.Table{Seats: []string{"a", "", "b", "", "", ""}, Button: 2}, 2},
+	}
+	for _, test := range tests {
+		if got := test.table.NextBigBlind(); got != test.seat {
+			t.Fatalf("NextBigBlind(%+v) = %d; want %d", test.table, got, test.seat)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	f, _ := tournament.Draw(players(20), 8, drawer(2))
+	for _, p := range f.Tables()[0].Seats {
+		if p != "" && f.Tables()[0].Players() > 4 {
+			f.Remove(p)
+		}
+	}
+	if got := fmt.Sprint(sizes(f)); got != "[4 7 6]" {
+		t.Fatalf("sizes = %s; want [4 7 6]", got)
+	}
+	next := f.Tables()[1].NextBigBlind()
+	moved := f.Tables()[1].Seats[next]
+	moves := f.Balance()
+	if len(moves) != 1 {
+		t.Fatalf("moves = %+v; want one", moves)
+	}
+	m := moves[0]
+	if m.Player != moved || m.From != (tournament.Seat{Table: 2, Seat: next}) || m.To.Table != 1 || m.Broken {
+		t.Fatalf("move = %+v; want %s from table 2 seat %d to table 1", m, moved, next)
+	}
+	if s, _ := f.Seat(moved); s != m.To {
+		t.Fatalf("%s is at %+v; want %+v", moved, s, m.To)
+	}
+	if got := fmt.Sprint(sizes(f)); got != "[5 6 6]" {
+		t.Fatalf("sizes = %s; want [5 6 6]", got)
+	}
+	if len(f.Balance()) != 0 {
+		t.Fatal("expected balanced tables to stay put")
+	}
+	// dropping to 16 players lets two tables of eight hold everyone, so
+	// the highest numbered table is broken
+	f.Remove(f.Tables()[2].Seats[f.Tables()[2].NextBigBlind()])
+	moves = f.Balance()
+	if got := fmt.Sprint(sizes(f)); got != "[8 8]" {
+		t.Fatalf("sizes = %s; want [8 8]", got)
+	}
+	for _, m := range moves {
+		if !m.Broken || m.From.Table != 3 {
+			t.Fatalf("unexpected move %+v", m)
+		}
+	}
+	if len(f.History()) != 6 {
+		t.Fatalf("history has %d moves; want 6", len(f.History()))
+	}
+}
+
+func TestBalanceToFinalTable(t *testing.T) {
+	f, _ := tournament.Draw(players(50), 9, drawer(3))
+	r := rand.New(rand.NewSource(3))
+	remaining := players(50)
+	for len(remaining) > 1 {
+		i := r.Intn(len(remaining))
+		if err := f.Remove(remaining[i]); err != nil {
+			t.Fatal(err)
+		}
+		remaining = append(remaining[:i], remaining[i+1:]...)
+		f.Balance()
+		for _, table := range f.Tables() {
+			f.MoveButton(table.ID)
+		}
+		s := sizes(f)
+		if want := (len(remaining) + 8) / 9; len(s) != want {
+			t.Fatalf("%d tables for %d players; want %d", len(s), len(remaining), want)
+		}
+		for _, n := range s {
+			if n-s[0] > 1 || s[0]-n > 1 {
+				t.Fatalf("unbalanced tables %v", s)
+			}
+		}
+		for _, p := range remaining {
+			if _, ok := f.Seat(p); !ok {
+				t.Fatalf("%s isn't seated", p)
+			}
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	f, _ := tournament.Draw(players(9), 9, drawer(4))
+	s, err := f.Add("late")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Table != 2 {
+		t.Fatalf("seated at %+v; want a new table", s)
+	}
+	if _, err := f.Add("late"); err == nil {
+		t.Fatal("expected error adding a seated player")
+	}
+	f.Balance()
+	if got := fmt.Sprint(sizes(f)); got != "[5 5]" {
+		t.Fatalf("sizes = %s; want [5 5]", got)
+	}
+}
@@ -0,0 +1,52 @@
+package tournament
+
+import "time"
+
+//go:generate stringer -type=EventType -output=stringer_autogen.go
+
+// EventType is the kind of an event.
+type EventType int
+
+const (
+	// Started is fired when the tournament starts.
+	Started EventType = iota + 1
+
+	// LevelStarted is fired when a blind level starts.
+	LevelStarted
+
+	// BreakStarted is fired when a break starts.
+	BreakStarted
+
+	// RegistrationClosed is fired when late registration ends.
+	RegistrationClosed
+
+	// RebuysClosed is fired when the rebuy period ends.
+	RebuysClosed
+
+	// Registered is fired when a player registers.
+	Registered
+
+	// Rebought is fired when a player rebuys.
+	Rebought
+
+	// AddedOn is fired when a player buys the add on.
+	AddedOn
+
+	// Eliminated is fired when a player is knocked out.
+	Eliminated
+
+	// Finished is fired with the winner when a single player remains.
+	Finished
+)
+
+// Event is something that happened in a tournament.  Level is the index of
+// the current level and Player, Place, and Prize are set for events about
+// a single player.
+type Event struct {
+	Type   EventType `json:"type"`
+	Time   time.Time `json:"time"`
+	Level  int       `json:"level"`
+	Player string    `json:"player,omitempty"`
+	Place  int       `json:"place,omitempty"`
+	Prize  int       `json:"prize,omitempty"`
+}
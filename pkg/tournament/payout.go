@@ -0,0 +1,101 @@
+package tournament
+
+import (
+	"errors"
+	"math"
+)
+
+// PayoutTier holds the percentages of the prize pool paid to each place,
+// from first down, in tournaments of up to Entries entries.
+type PayoutTier struct {
+	Entries  int       `json:"entries"`
+	Percents []float64 `json:"percents"`
+}
+
+// PayoutTable is a list of payout tiers ordered by entries.  Fields larger
+// than the last tier pay the top fifteen percent of entries on a
+// harmonic curve.
+type PayoutTable []PayoutTier
+
+// DefaultPayouts is a typical payout table.
+var DefaultPayouts = PayoutTable{
+	{Entries: 2, Percents: []float64{100}},
+	{Entries: 6, Percents: []float64{65, 35}},
+	{Entries: 10, Percents: []float64{50, 30, 20}},
+	{Entries: 20, Percents: []float64{40, 25, 17, 11, 7}},
+	{Entries: 30, Percents: []float64{35, 22, 15, 11, 9, 8}},
+	{Entries: 50, Percents: []float64{30, 20, 14, 10, 8, 7, 6, 5}},
+	{Entries: 100, Percents: []float64{27, 17, 12, 9.5, 7.5, 6.5, 5.5, 5, 5, 5}},
+}
+
+// Validate returns an error if the table is out of order or a tier's
+// percentages increase or don't add up to one hundred.
+func (t PayoutTable) Validate() error {
+	for i, tier := range t {
+		if tier.Entries <= 0 || (i > 0 && tier.Entries <= t[i-1].Entries) {
+			return errors.New("tournament: payout tiers out of order")
+		}
+		if err := checkPercents(tier.Percents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Percents returns the percentages of the prize pool paid to each place
+// for the number of entries.
+func (t PayoutTable) Percents(entries int) []float64 {
+	if entries <= 0 {
+		return nil
+	}
+	for _, tier := range t {
+		if entries <= tier.Entries {
+			return tier.Percents
+		}
+	}
+	places := int(math.Ceil(float64(entries) * 0.15))
+	percents := make([]float64, places)
+	total := 0.0
+	for i := range percents {
+		percents[i] = 1 / float64(i+1)
+		total += percents[i]
+	}
+	for i := range percents {
+		percents[i] *= 100 / total
+	}
+	return percents
+}
+
+// Payouts splits the prize pool between places for the number of
+// entries.  Amounts are rounded down and the remainder goes to first
+// place.
+func (t PayoutTable) Payouts(entries, pool int) []int {
+	percents := t.Percents(entries)
+	payouts := make([]int, len(percents))
+	paid := 0
+	for i, p := range percents {
+		payouts[i] = int(float64(pool) * p / 100)
+		paid += payouts[i]
+	}
+	if len(payouts) > 0 {
+		payouts[0] += pool - paid
+	}
+	return payouts
+}
+
+func checkPercents(percents []float64) error {
+	if len(percents) == 0 {
+		return errors.New("tournament: payout tier pays no places")
+	}
+	total := 0.0
+	for i, p := range percents {
+		if p <= 0 || (i > 0 && p > percents[i-1]) {
+			return errors.New("tournament: payout percentages must be positive and non-increasing")
+		}
+		total += p
+	}
+	if math.Abs(total-100) > 1e-6 {
+		return errors.New("tournament: payout percentages must add up to 100")
+	}
+	return nil
+}
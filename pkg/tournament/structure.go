@@ -0,0 +1,138 @@
+package tournament
+
+import (
+	"errors"
+	"time"
+
+	"github.com/notnil/joker/pkg/table"
+)
+
+// Level is a blind level or a break in a tournament's schedule.  A level
+// lasts for its duration, its number of hands, or whichever comes first
+// if both are set.  The last level may set neither and then lasts until
+// the tournament ends.
+type Level struct {
+	SmallBlind int           `json:"smallBlind,omitempty"`
+	BigBlind   int           `json:"bigBlind,omitempty"`
+	Ante       int           `json:"ante,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	Hands      int           `json:"hands,omitempty"`
+
+	// BigBlindAnte indicates the ante is paid once per hand by the
+	// player in the big blind rather than by every player.  Options sets
+	// it on the table.
+	BigBlindAnte bool `json:"bigBlindAnte,omitempty"`
+
+	// Break indicates no hands are dealt during the level.
+	Break bool `json:"break,omitempty"`
+}
+
+// Options returns the table options for the level's blinds and ante.
+func (l Level) Options() []func(*table.Config) {
+	ante := table.Ante(l.Ante)
+	if l.BigBlindAnte {
+		ante = table.BigBlindAnte(l.Ante)
+	}
+	return []func(*table.Config){table.Stakes(l.SmallBlind, l.BigBlind), ante}
+}
+
+// Rebuy describes the rebuys allowed in a tournament.
+type Rebuy struct {
+	Cost  int `json:"cost"`
+	Chips int `json:"chips"`
+
+	// Levels is the number of levels, counted from the first, during
+	// which rebuys are allowed.
+	Levels int `json:"levels"`
+
+	// Max is the most rebuys a player may make or zero for no limit.
+	Max int `json:"max,omitempty"`
+
+	// Threshold is the largest stack a player may rebuy with.  Zero
+	// means the starting stack.
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// AddOn describes the single add-on each player may buy.
+type AddOn struct {
+	Cost  int `json:"cost"`
+	Chips int `json:"chips"`
+
+	// Level is the index of the level, usually a break, during which
+	// add-ons are allowed.
+	Level int `json:"level"`
+}
+
+// Structure is the format of a tournament.  Amounts of money are in the
+// smallest unit of currency.  Each entry pays the buy in into the prize
+// pool and the fee on top of it, which is kept out of the pool and
+// reported by Tournament.Fees.
+type Structure struct {
+	BuyIn         int     `json:"buyIn"`
+	Fee           int     `json:"fee,omitempty"`
+	StartingStack int     `json:"startingStack"`
+	Levels        []Level `json:"levels"`
+
+	// LateRegistration is the number of levels, counted from the first,
+	// during which players may still register after the start.
+	LateRegistration int `json:"lateRegistration,omitempty"`
+
+	Rebuy *Rebuy `json:"rebuy,omitempty"`
+	AddOn *AddOn `json:"addOn,omitempty"`
+
+	// Payouts is the payout table used to split the prize pool.  If nil
+	// DefaultPayouts is used.
+	Payouts PayoutTable `json:"payouts,omitempty"`
+}
+
+// Validate returns an error if the structure can't be played.
+func (s Structure) Validate() error {
+	if s.BuyIn < 0 || s.Fee < 0 {
+		return errors.New("tournament: negative buy in or fee")
+	}
+	if s.StartingStack <= 0 {
+		return errors.New("tournament: starting stack must be positive")
+	}
+	if len(s.Levels) == 0 {
+		return errors.New("tournament: no levels")
+	}
+	for i, l := range s.Levels {
+		last := i == len(s.Levels)-1
+		if l.Duration < 0 || l.Hands < 0 {
+			return errors.New("tournament: negative level length")
+		}
+		if l.Break {
+			if l.Duration == 0 || last {
+				return errors.New("tournament: breaks need a duration and can't end the schedule")
+			}
+			continue
+		}
+		if l.SmallBlind < 0 || l.BigBlind <= 0 || l.Ante < 0 || l.SmallBlind > l.BigBlind {
+			return errors.New("tournament: invalid blinds")
+		}
+		if l.Duration == 0 && l.Hands == 0 && !last {
+			return errors.New("tournament: only the last level may be unbounded")
+		}
+	}
+	if s.LateRegistration < 0 || s.LateRegistration > len(s.Levels) {
+		return errors.New("tournament: invalid late registration")
+	}
+	if r := s.Rebuy; r != nil {
+		if r.Cost < 0 || r.Chips <= 0 || r.Levels <= 0 || r.Max < 0 || r.Threshold < 0 {
+			return errors.New("tournament: invalid rebuy")
+		}
+	}
+	if a := s.AddOn; a != nil {
+		if a.Cost < 0 || a.Chips <= 0 || a.Level < 0 || a.Level >= len(s.Levels) {
+			return errors.New("tournament: invalid add on")
+		}
+	}
+	return s.payouts().Validate()
+}
+
+func (s Structure) payouts() PayoutTable {
+	if s.Payouts == nil {
+		return DefaultPayouts
+	}
+	return s.Payouts
+}
@@ -0,0 +1,539 @@
+// Package tournament runs multi-table tournaments: registration, blind
+// levels and breaks, rebuys and add-ons, eliminations, payouts, and
+// seating.
+package tournament
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// Clock tells the time.  Tournaments read the time from a clock so that
+// tests and simulations can control it.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock reads the system time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Config represents the configuration options for a tournament.
+type Config struct {
+	clock    Clock
+	dealer   hand.Dealer
+	listener func(Event)
+}
+
+// WithClock sets the clock used to time levels.  The system clock is used
+// by default.
+func WithClock(c Clock) func(*Config) {
+	return func(cfg *Config) {
+		cfg.clock = c
+	}
+}
+
+// WithDealer sets the dealer that provides decks to the tournament's
+// tables.  A dealer seeded from the clock is used by default.
+func WithDealer(d hand.Dealer) func(*Config) {
+	return func(cfg *Config) {
+		cfg.dealer = d
+	}
+}
+
+// WithListener sets a function called with every event.  It is called
+// without the tournament locked, so it may call back into the tournament.
+func WithListener(f func(Event)) func(*Config) {
+	return func(cfg *Config) {
+		cfg.listener = f
+	}
+}
+
+// Player is a player's standing in a tournament.  Place is zero while the
+// player is still in.
+type Player struct {
+	Name   string `json:"name"`
+	Chips  int    `json:"chips"`
+	Rebuys int    `json:"rebuys,omitempty"`
+	AddOn  bool   `json:"addOn,omitempty"`
+	Place  int    `json:"place,omitempty"`
+	Prize  int    `json:"prize,omitempty"`
+}
+
+// Hand holds what a table needs to deal a hand, which is dealt with the
+// level's Options.
+type Hand struct {
+	Level Level      `json:"level"`
+	Deck  *hand.Deck `json:"deck"`
+}
+
+// Tournament is a tournament in progress.  Levels only change when the
+// tournament is used, so Update should be called regularly, such as once
+// a second, to fire events on time.  A Tournament is safe for concurrent
+// use by its tables.
+type Tournament struct {
+	mu        sync.Mutex
+	structure Structure
+	config    *Config
+	players   map[string]*Player
+	order     []string
+	remaining int
+	entries   int
+	pool      int
+	fees      int
+	chips     int
+	started   bool
+	paused    time.Time
+	level     int
+	start     time.Time
+	hands     int
+	events    []Event
+}
+
+// New returns a tournament with the given structure that is open for
+// registration.
+func New(s Structure, options ...func(*Config)) (*Tournament, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	c := &Config{clock: SystemClock}
+	for _, option := range options {
+		option(c)
+	}
+	if c.dealer == nil {
+		r := rand.New(rand.NewSource(c.clock.Now().UnixNano()))
+		c.dealer = hand.NewDealer(r)
+	}
+	return &Tournament{
+		structure: s,
+		config:    c,
+		players:   map[string]*Player{},
+	}, nil
+}
+
+// Structure returns the tournament's structure.
+func (t *Tournament) Structure() Structure {
+	return t.structure
+}
+
+// Register enters a player with a starting stack.  Players may register
+// until the tournament starts and during late registration.
+func (t *Tournament) Register(name string) error {
+	return t.do(func() error {
+		if t.finished() {
+			return errors.New("tournament: tournament is over")
+		}
+		if t.started && t.level >= t.structure.LateRegistration {
+			return errors.New("tournament: registration is closed")
+		}
+		if _, ok := t.players[name]; ok {
+			return errors.New("tournament: player is already registered")
+		}
+		t.players[name] = &Player{Name: name, Chips: t.structure.StartingStack}
+		t.order = append(t.order, name)
+		t.remaining++
+		t.entries++
+		t.pool += t.structure.BuyIn
+		t.fees += t.structure.Fee
+		t.chips += t.structure.StartingStack
+		t.fire(Event{Type: Registered, Player: name})
+		return nil
+	})
+}
+
+// Start starts the first level.
+func (t *Tournament) Start() error {
+	return t.do(func() error {
+		if t.started {
+			return errors.New("tournament: already started")
+		}
+		if t.remaining < 2 {
+			return errors.New("tournament: not enough players")
+		}
+		t.started = true
+		t.start = t.now()
+		t.fire(Event{Type: Started})
+		t.enterLevel()
+		return nil
+	})
+}
+
+// Pause stops the clock until Resume is called.
+func (t *Tournament) Pause() error {
+	return t.do(func() error {
+		if !t.started || !t.paused.IsZero() || t.finished() {
+			return errors.New("tournament: not running")
+		}
+		t.paused = t.now()
+		return nil
+	})
+}
+
+// Resume restarts the clock after Pause.
+func (t *Tournament) Resume() error {
+	return t.do(func() error {
+		if t.paused.IsZero() {
+			return errors.New("tournament: not paused")
+		}
+		t.start = t.start.Add(t.now().Sub(t.paused))
+		t.paused = time.Time{}
+		return nil
+	})
+}
+
+// Update advances the levels to the current time.
+func (t *Tournament) Update() {
+	t.do(func() error { return nil })
+}
+
+// Level returns the index and details of the current level.
+func (t *Tournament) Level() (int, Level) {
+	var i int
+	t.do(func() error {
+		i = t.level
+		return nil
+	})
+	return i, t.structure.Levels[i]
+}
+
+// Remaining returns the time left in the current level, or zero if the
+// level isn't timed.
+func (t *Tournament) Remaining() time.Duration {
+	var d time.Duration
+	t.do(func() error {
+		l := t.structure.Levels[t.level]
+		if !t.started || l.Duration == 0 {
+			return nil
+		}
+		now := t.now()
+		if !t.paused.IsZero() {
+			now = t.paused
+		}
+		d = t.start.Add(l.Duration).Sub(now)
+		return nil
+	})
+	return d
+}
+
+// StartHand returns the level and a fresh deck for a table about to deal
+// a hand and counts the hand towards the level.  No hands may be dealt
+// during breaks or while the tournament is paused.
+func (t *Tournament) StartHand() (Hand, error) {
+	var h Hand
+	err := t.do(func() error {
+		switch {
+		case !t.started:
+			return errors.New("tournament: not started")
+		case t.finished():
+			return errors.New("tournament: tournament is over")
+		case !t.paused.IsZero():
+			return errors.New("tournament: paused")
+		}
+		l := t.structure.Levels[t.level]
+		if l.Break {
+			return errors.New("tournament: on break")
+		}
+		h = Hand{Level: l, Deck: t.config.dealer.Deck()}
+		t.hands++
+		if l.Hands > 0 && t.hands >= l.Hands {
+			t.start = t.now()
+			t.nextLevel()
+		}
+		return nil
+	})
+	return h, err
+}
+
+// SetChips records a player's stack, usually after each hand.
+func (t *Tournament) SetChips(name string, chips int) error {
+	return t.do(func() error {
+		p, err := t.active(name)
+		if err != nil {
+			return err
+		}
+		if chips < 0 {
+			return errors.New("tournament: negative chips")
+		}
+		p.Chips = chips
+		return nil
+	})
+}
+
+// Rebuy adds chips to a player's stack during the rebuy period if their
+// stack is at or below the rebuy threshold.
+func (t *Tournament) Rebuy(name string) error {
+	return t.do(func() error {
+		r := t.structure.Rebuy
+		if r == nil || !t.started || t.level >= r.Levels {
+			return errors.New("tournament: rebuys are closed")
+		}
+		p, err := t.active(name)
+		if err != nil {
+			return err
+		}
+		if r.Max > 0 && p.Rebuys >= r.Max {
+			return errors.New("tournament: no rebuys left")
+		}
+		threshold := r.Threshold
+		if threshold == 0 {
+			threshold = t.structure.StartingStack
+		}
+		if p.Chips > threshold {
+			return errors.New("tournament: stack too big to rebuy")
+		}
+		p.Chips += r.Chips
+		p.Rebuys++
+		t.pool += r.Cost
+		t.chips += r.Chips
+		t.fire(Event{Type: Rebought, Player: name})
+		return nil
+	})
+}
+
+// AddOn adds chips to a player's stack once during the add-on level.
+func (t *Tournament) AddOn(name string) error {
+	return t.do(func() error {
+		a := t.structure.AddOn
+		if a == nil || !t.started || t.level != a.Level {
+			return errors.New("tournament: add ons are closed")
+		}
+		p, err := t.active(name)
+		if err != nil {
+			return err
+		}
+		if p.AddOn {
+			return errors.New("tournament: already added on")
+		}
+		p.Chips += a.Chips
+		p.AddOn = true
+		t.pool += a.Cost
+		t.chips += a.Chips
+		t.fire(Event{Type: AddedOn, Player: name})
+		return nil
+	})
+}
+
+// Eliminate knocks a player out and returns their finishing place.  When
+// several players bust in the same hand, eliminate the one who started
+// the hand with fewer chips first.  The last player left wins and the
+// tournament finishes.
+func (t *Tournament) Eliminate(name string) (int, error) {
+	place := 0
+	err := t.do(func() error {
+		if !t.started {
+			return errors.New("tournament: not started")
+		}
+		p, err := t.active(name)
+		if err != nil {
+			return err
+		}
+		t.finish(p)
+		place = p.Place
+		if t.remaining == 1 {
+			for _, n := range t.order {
+				if w := t.players[n]; w.Place == 0 {
+					t.finish(w)
+				}
+			}
+		}
+		return nil
+	})
+	return place, err
+}
+
+// Finished returns whether a single player remains.
+func (t *Tournament) Finished() bool {
+	var f bool
+	t.do(func() error {
+		f = t.finished()
+		return nil
+	})
+	return f
+}
+
+// Player returns the standing of the player with the given name.
+func (t *Tournament) Player(name string) (Player, bool) {
+	var p Player
+	var ok bool
+	t.do(func() error {
+		var pp *Player
+		if pp, ok = t.players[name]; ok {
+			p = *pp
+		}
+		return nil
+	})
+	return p, ok
+}
+
+// Players returns the standings of all players in registration order.
+func (t *Tournament) Players() []Player {
+	var players []Player
+	t.do(func() error {
+		for _, n := range t.order {
+			players = append(players, *t.players[n])
+		}
+		return nil
+	})
+	return players
+}
+
+// Entries returns the number of players registered.
+func (t *Tournament) Entries() int {
+	var n int
+	t.do(func() error {
+		n = t.entries
+		return nil
+	})
+	return n
+}
+
+// Pool returns the prize pool.
+func (t *Tournament) Pool() int {
+	var n int
+	t.do(func() error {
+		n = t.pool
+		return nil
+	})
+	return n
+}
+
+// Fees returns the entry fees collected, which aren't part of the prize
+// pool.
+func (t *Tournament) Fees() int {
+	var n int
+	t.do(func() error {
+		n = t.fees
+		return nil
+	})
+	return n
+}
+
+// Payouts returns the prize for each place, from first down, given the
+// current entries and prize pool.
+func (t *Tournament) Payouts() []int {
+	var payouts []int
+	t.do(func() error {
+		payouts = t.payouts()
+		return nil
+	})
+	return payouts
+}
+
+// do runs f with the tournament locked and up to date and then fires any
+// events it queued.
+func (t *Tournament) do(f func() error) error {
+	t.mu.Lock()
+	t.advance()
+	err := f()
+	events := t.events
+	t.events = nil
+	t.mu.Unlock()
+	if t.config.listener != nil {
+		for _, e := range events {
+			t.config.listener(e)
+		}
+	}
+	return err
+}
+
+// advance moves through every timed level that has ended.  Each level is
+// timed from the scheduled end of the last so that late updates don't
+// shift the schedule.
+func (t *Tournament) advance() {
+	if !t.started || !t.paused.IsZero() || t.finished() {
+		return
+	}
+	now := t.now()
+	for {
+		l := t.structure.Levels[t.level]
+		if l.Duration == 0 || now.Before(t.start.Add(l.Duration)) {
+			return
+		}
+		t.start = t.start.Add(l.Duration)
+		if !t.nextLevel() {
+			return
+		}
+	}
+}
+
+func (t *Tournament) nextLevel() bool {
+	if t.level == len(t.structure.Levels)-1 {
+		return false
+	}
+	t.level++
+	t.hands = 0
+	t.enterLevel()
+	return true
+}
+
+func (t *Tournament) enterLevel() {
+	typ := LevelStarted
+	if t.structure.Levels[t.level].Break {
+		typ = BreakStarted
+	}
+	t.fire(Event{Type: typ, Time: t.start})
+	if t.level == t.structure.LateRegistration {
+		t.fire(Event{Type: RegistrationClosed, Time: t.start})
+	}
+	if r := t.structure.Rebuy; r != nil && t.level == r.Levels {
+		t.fire(Event{Type: RebuysClosed, Time: t.start})
+	}
+}
+
+func (t *Tournament) finish(p *Player) {
+	p.Place = t.remaining
+	t.remaining--
+	if payouts := t.payouts(); p.Place <= len(payouts) {
+		p.Prize = payouts[p.Place-1]
+	}
+	typ := Eliminated
+	p.Chips = 0
+	if p.Place == 1 {
+		typ = Finished
+		p.Chips = t.chips
+	}
+	t.fire(Event{Type: typ, Player: p.Name, Place: p.Place, Prize: p.Prize})
+}
+
+func (t *Tournament) payouts() []int {
+	return t.structure.payouts().Payouts(t.entries, t.pool)
+}
+
+func (t *Tournament) active(name string) (*Player, error) {
+	p, ok := t.players[name]
+	if !ok {
+		return nil, errors.New("tournament: unknown player")
+	}
+	if p.Place != 0 {
+		return nil, errors.New("tournament: player is eliminated")
+	}
+	return p, nil
+}
+
+func (t *Tournament) finished() bool {
+	return t.started && t.remaining <= 1
+}
+
+// fire queues an event, timed now unless it already has a time.
+func (t *Tournament) fire(e Event) {
+	if e.Time.IsZero() {
+		e.Time = t.now()
+	}
+	e.Level = t.level
+	t.events = append(t.events, e)
+}
+
+func (t *Tournament) now() time.Time {
+	return t.config.clock.Now()
+}
@@ -0,0 +1,322 @@
+package tournament_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/table"
+	"github.com/notnil/joker/pkg/tournament"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+var structure = tournament.Structure{
+	BuyIn:         100,
+	Fee:           10,
+	StartingStack: 1000,
+	Levels: []tournament.Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+		{Break: true, Duration: 5 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Ante: 100, BigBlindAnte: true, Hands: 2},
+		{SmallBlind: 100, BigBlind: 200, Ante: 200, BigBlindAnte: true},
+	},
+	LateRegistration: 2,
+	Rebuy:            &tournament.Rebuy{Cost: 100, Chips: 1000, Levels: 2, Max: 1},
+	AddOn:            &tournament.AddOn{Cost: 100, Chips: 2000, Level: 2},
+}
+
+func newTournament(t *testing.T, players int) (*tournament.Tournament, *clock, *[]tournament.Event) {
+	c := &clock{now: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)}
+	events := &[]tournament.Event{}
+	r := rand.New(rand.NewSource(0))
+	tour, err := tournament.New(structure,
+		tournament.WithClock(c),
+		tournament.WithDealer(hand.NewDealer(r)),
+		tournament.WithListener(func(e tournament.Event) {
+			*events = append(*events, e)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < players; i++ {
+		if err := tour.Register(fmt.Sprint("p", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tour, c, events
+}
+
+func types(events []tournament.Event) []tournament.EventType {
+	var t []tournament.EventType
+	for _, e := range events {
+		if e.Type != tournament.Registered {
+			t = append(t, e.Type)
+		}
+	}
+	return t
+}
+
+func TestLevels(t *testing.T) {
+	tour, c, events := newTournament(t, 3)
+	if _, err := tour.StartHand(); err == nil {
+		t.Fatal("expected error dealing before the start")
+	}
+	if err := tour.Start(); err != nil {
+		t.Fatal(err)
+	}
+	c.Advance(9 * time.Minute)
+	if i, _ := tour.Level(); i != 0 {
+		t.Fatalf("level = %d; want 0", i)
+	}
+	if d := tour.Remaining(); d != time.Minute {
+		t.Fatalf("remaining = %v; want 1m", d)
+	}
+	// a late update passes through every level that ended
+	c.Advance(12 * time.Minute)
+	tour.Update()
+	if i, l := tour.Level(); i != 2 || !l.Break {
+		t.Fatalf("level = %d %+v; want break", i, l)
+	}
+	if d := tour.Remaining(); d != 4*time.Minute {
+		t.Fatalf("remaining = %v; want 4m", d)
+	}
+	if _, err := tour.StartHand(); err == nil {
+		t.Fatal("expected error dealing on a break")
+	}
+	c.Advance(4 * time.Minute)
+	tour.Update()
+	// hand count levels advance after their last hand
+	for i := 0; i < 3; i++ {
+		h, err := tour.StartHand()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int{100, 100, 200}[i]
+		if h.Level.BigBlind != want || !h.Level.BigBlindAnte || len(h.Deck.Cards) != 52 {
+			t.Fatalf("hand %d got %+v; want big blind %d", i, h.Level, want)
+		}
+	}
+	c.Advance(time.Hour)
+	if i, _ := tour.Level(); i != 4 {
+		t.Fatalf("level = %d; want the unbounded last level", i)
+	}
+	want := []tournament.EventType{
+		tournament.Started, tournament.LevelStarted,
+		tournament.LevelStarted,
+		tournament.BreakStarted, tournament.RegistrationClosed, tournament.RebuysClosed,
+		tournament.LevelStarted,
+		tournament.LevelStarted,
+	}
+	if got := types(*events); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("events = %v; want %v", got, want)
+	}
+	// level events are timed by the schedule rather than the update
+	if e := (*events)[len(*events)-5]; e.Level != 2 || e.Time.Format("15:04") != "12:20" {
+		t.Fatalf("unexpected break event %+v", e)
+	}
+}
+
+func TestLevelOptions(t *testing.T) {
+	for _, l := range structure.Levels[3:] {
+		tbl, err := table.New(3, l.Options()...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for seat := 0; seat < 3; seat++ {
+			tbl.Sit(seat, fmt.Sprint("p", seat), 1000)
+		}
+		if err := tbl.Deal(); err != nil {
+			t.Fatal(err)
+		}
+		// the big blind alone pays the ante
+		r := tbl.Record()
+		antes := 0
+		for _, a := range r.Actions {
+			if a.Type == table.PostAnte {
+				antes += a.Amount
+			}
+		}
+		if !r.BigBlindAnte || r.BigBlind != l.BigBlind || antes != l.Ante || tbl.View(-1).Pot != l.SmallBlind+l.BigBlind+l.Ante {
+			t.Fatalf("level %+v dealt %+v", l, r)
+		}
+	}
+}
+
+func TestPause(t *testing.T) {
+	tour, c, _ := newTournament(t, 2)
+	tour.Start()
+	c.Advance(5 * time.Minute)
+	if err := tour.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	c.Advance(time.Hour)
+	if i, _ := tour.Level(); i != 0 {
+		t.Fatalf("level = %d; want 0 while paused", i)
+	}
+	if _, err := tour.StartHand(); err == nil {
+		t.Fatal("expected error dealing while paused")
+	}
+	if err := tour.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	if d := tour.Remaining(); d != 5*time.Minute {
+		t.Fatalf("remaining = %v; want 5m", d)
+	}
+}
+
+func TestRegistrationAndRebuys(t *testing.T) {
+	tour, c, _ := newTournament(t, 2)
+	if err := tour.Register("p0"); err == nil {
+		t.Fatal("expected error registering twice")
+	}
+	if err := tour.Rebuy("p0"); err == nil {
+		t.Fatal("expected error rebuying before the start")
+	}
+	tour.Start()
+	c.Advance(15 * time.Minute)
+	if err := tour.Register("late"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tour.SetChips("p0", 1200); err != nil {
+		t.Fatal(err)
+	}
+	if err := tour.Rebuy("p0"); err == nil {
+		t.Fatal("expected error rebuying above the threshold")
+	}
+	tour.SetChips("p0", 0)
+	if err := tour.Rebuy("p0"); err != nil {
+		t.Fatal(err)
+	}
+	tour.SetChips("p0", 0)
+	if err := tour.Rebuy("p0"); err == nil {
+		t.Fatal("expected error rebuying more than the maximum")
+	}
+	if err := tour.AddOn("p1"); err == nil {
+		t.Fatal("expected error adding on outside the add on level")
+	}
+	c.Advance(5 * time.Minute)
+	if err := tour.Register("later"); err == nil {
+		t.Fatal("expected error registering after late registration")
+	}
+	if err := tour.AddOn("p1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tour.AddOn("p1"); err == nil {
+		t.Fatal("expected error adding on twice")
+	}
+	p, _ := tour.Player("p1")
+	if p.Chips != 3000 || !p.AddOn {
+		t.Fatalf("p1 = %+v", p)
+	}
+	if p, _ := tour.Player("p0"); p.Rebuys != 1 {
+		t.Fatalf("p0 = %+v", p)
+	}
+	if tour.Entries() != 3 || tour.Pool() != 500 || tour.Fees() != 30 {
+		t.Fatalf("entries %d pool %d fees %d; want 3, 500, and 30", tour.Entries(), tour.Pool(), tour.Fees())
+	}
+}
+
+func TestEliminations(t *testing.T) {
+	tour, _, events := newTournament(t, 10)
+	if err := tour.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(tour.Payouts()); got != "[500 300 200]" {
+		t.Fatalf("payouts = %s", got)
+	}
+	for i := 9; i > 0; i-- {
+		place, err := tour.Eliminate(fmt.Sprint("p", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if place != i+1 {
+			t.Fatalf("place = %d; want %d", place, i+1)
+		}
+	}
+	if !tour.Finished() {
+		t.Fatal("expected tournament to be finished")
+	}
+	if _, err := tour.Eliminate("p0"); err == nil {
+		t.Fatal("expected error eliminating the winner")
+	}
+	prizes := map[string]int{"p0": 500, "p1": 300, "p2": 200, "p3": 0}
+	for name, prize := range prizes {
+		if p, _ := tour.Player(name); p.Prize != prize {
+			t.Fatalf("%s = %+v; want prize %d", name, p, prize)
+		}
+	}
+	if p, _ := tour.Player("p0"); p.Place != 1 || p.Chips != 10000 {
+		t.Fatalf("winner = %+v", p)
+	}
+	last := (*events)[len(*events)-1]
+	if last.Type != tournament.Finished || last.Player != "p0" || last.Prize != 500 {
+		t.Fatalf("last event = %+v", last)
+	}
+}
+
+func TestPayoutTable(t *testing.T) {
+	table := tournament.DefaultPayouts
+	if err := table.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(table.Payouts(9, 1001)); got != "[501 300 200]" {
+		t.Fatalf("payouts = %s", got)
+	}
+	percents := table.Percents(1000)
+	if len(percents) != 150 {
+		t.Fatalf("paid %d places; want 150", len(percents))
+	}
+	sum := 0.0
+	for _, p := range percents {
+		sum += p
+	}
+	if math.Abs(sum-100) > 1e-9 {
+		t.Fatalf("percentages sum to %v", sum)
+	}
+	payouts := table.Payouts(1000, 100000)
+	total := 0
+	for i, p := range payouts {
+		total += p
+		if i > 0 && p > payouts[i-1] {
+			t.Fatal("payouts increase")
+		}
+	}
+	if total != 100000 {
+		t.Fatalf("paid %d of 100000", total)
+	}
+	bad := tournament.PayoutTable{{Entries: 10, Percents: []float64{50, 30}}}
+	if err := bad.Validate(); err == nil {
+		t.Fatal("expected error for percentages that don't add up")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []tournament.Structure{
+		{StartingStack: 1000},
+		{StartingStack: 1000, Levels: []tournament.Level{{SmallBlind: 10, BigBlind: 20}, {SmallBlind: 20, BigBlind: 40}}},
+		{StartingStack: 1000, Levels: []tournament.Level{{SmallBlind: 10, BigBlind: 20, Hands: 5}, {Break: true, Duration: time.Minute}}},
+		{StartingStack: 1000, Levels: []tournament.Level{{SmallBlind: 30, BigBlind: 20}}},
+		{StartingStack: 1000, Levels: []tournament.Level{{SmallBlind: 10, BigBlind: 20}}, AddOn: &tournament.AddOn{Chips: 10, Level: 1}},
+	}
+	for _, s := range bad {
+		if _, err := tournament.New(s); err == nil {
+			t.Fatalf("expected error for %+v", s)
+		}
+	}
+}